@@ -5,8 +5,10 @@ import (
 	"TaskManagmentApis/internal/bootstrap"
 	"TaskManagmentApis/internal/middleware"
 	"TaskManagmentApis/internal/routes"
+	"context"
 	"log"
 	"net/http"
+	_ "time/tzdata" // embed the time zone database for users' recurring tasks
 
 	"github.com/gin-gonic/gin"
 )
@@ -18,6 +20,21 @@ func main() {
 		log.Fatal("❌ App initialization failed:", err)
 	}
 
+	// purge tasks and users that have been in the trash past the retention period
+	app.TrashPurger.Start(context.Background())
+
+	// send due-soon and overdue reminders at each user's reminder offsets
+	app.Reminders.Start(context.Background())
+
+	// deliver queued emails, retrying failures with backoff
+	app.EmailSender.Start(context.Background())
+
+	// deliver queued webhook events, retrying failures and backing off from failing endpoints
+	app.Webhooks.Start(context.Background())
+
+	// relay task changes from every server to the event streams connected to this one
+	app.EventStream.Start(context.Background())
+
 	// Initalize Gin router
 	router := gin.Default()
 
@@ -32,6 +49,48 @@ func main() {
 	// routes for auth
 	routes.SetupAuthRoutes(router, app.Handler.Auth)
 
+	// routes for tasks
+	routes.SetupTaskRoutes(router, app.Handler.Task)
+
+	// routes for tags
+	routes.SetupTagRoutes(router, app.Handler.Tag)
+
+	// routes for projects
+	routes.SetupProjectRoutes(router, app.Handler.Project)
+
+	// routes for kanban boards
+	routes.SetupBoardRoutes(router, app.Handler.Board)
+
+	// routes for task comments
+	routes.SetupCommentRoutes(router, app.Handler.Comment)
+
+	// routes for notifications
+	routes.SetupNotificationRoutes(router, app.Handler.Notification)
+
+	// routes for task attachments and signed downloads
+	routes.SetupAttachmentRoutes(router, app.Handler.Attachment)
+
+	// routes for workspaces, memberships and switching the active workspace
+	routes.SetupWorkspaceRoutes(router, app.Handler.Workspace)
+
+	// admin routes, each guarded by an RBAC permission
+	routes.SetupAdminRoutes(router, app.Handler.Admin)
+
+	// routes for the audit log
+	routes.SetupAuditRoutes(router, app.Handler.Audit)
+
+	// routes for the trash: listing, restoring and purging deleted tasks
+	routes.SetupTrashRoutes(router, app.Handler.Task)
+
+	// routes for the ICS calendar feed and managing its secret URL
+	routes.SetupCalendarRoutes(router, app.Handler.Calendar)
+
+	// routes for webhooks and their delivery log
+	routes.SetupWebhookRoutes(router, app.Handler.Webhook)
+
+	// route for the live task event stream (Server-Sent Events)
+	routes.SetupEventRoutes(router, app.Handler.Event)
+
 	// Get port from config (with fallback)
 	port := config.Config.Port
 	if port == "" {
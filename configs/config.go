@@ -10,25 +10,67 @@ import (
 
 // type of Config *AppConfig
 type AppConfig struct {
-	AppName                  string
-	AppEnv                   string
-	Port                     string
-	DBHost                   string
-	DBPort                   string
-	DBUser                   string
-	DBPassword               string
-	DBName                   string
-	RedisHost                string
-	RedisPort                string
-	RedisPassword            string
-	JWTSecretKey             string
-	AccessTokenExpireMinutes int
-	RefreshTokenExpireHours  int
+	AppName                    string
+	AppEnv                     string
+	Port                       string
+	DBHost                     string
+	DBPort                     string
+	DBUser                     string
+	DBPassword                 string
+	DBName                     string
+	RedisHost                  string
+	RedisPort                  string
+	RedisPassword              string
+	JWTSecretKey               string
+	AccessTokenExpireMinutes   int
+	RefreshTokenExpireHours    int
+	SubtaskDeletePolicy        string
+	PublicBaseURL              string
+	StorageBackend             string
+	StorageLocalDir            string
+	StorageSigningKey          string
+	SignedURLExpireMinutes     int
+	AttachmentMaxBytes         int
+	AttachmentAllowedTypes     string
+	S3Endpoint                 string
+	S3Region                   string
+	S3Bucket                   string
+	S3AccessKey                string
+	S3SecretKey                string
+	S3UsePathStyle             bool
+	AdminEmails                string
+	TrashRetentionDays         int
+	TrashPurgeIntervalMins     int
+	TaskImportMaxBytes         int
+	ReminderIntervalSecs       int
+	MailBackend                string
+	MailFrom                   string
+	SMTPHost                   string
+	SMTPPort                   int
+	SMTPUsername               string
+	SMTPPassword               string
+	SMTPSecurity               string
+	MailMaxAttempts            int
+	MailRetryBaseSecs          int
+	MailPollIntervalSecs       int
+	WebhookTimeoutSecs         int
+	WebhookMaxAttempts         int
+	WebhookRetryBaseSecs       int
+	WebhookPollIntervalSecs    int
+	WebhookBreakerThreshold    int
+	WebhookBreakerCooldownSecs int
+	WebhookAllowedNetworks     string
+	EventsHeartbeatSecs        int
+	EventsReplayBufferSize     int
+	EventsTokenExpireSecs      int
 }
 
 // var
 var Config *AppConfig
 
+// defaultAttachmentTypes are the MIME types accepted for attachments unless ATTACHMENT_ALLOWED_TYPES is set
+const defaultAttachmentTypes = "image/png,image/jpeg,image/gif,image/webp,application/pdf,text/plain,application/zip"
+
 // func for load
 func LoadEnv() {
 	// Load .env ffile
@@ -37,20 +79,59 @@ func LoadEnv() {
 	}
 
 	Config = &AppConfig{
-		AppName:                  MustGetEnvOrDefault("APP_NAME", "TaskManagmentApis"),
-		AppEnv:                   MustGetEnvOrDefault("APP_ENV", "development"),
-		Port:                     MustGetEnvOrDefault("PORT", "8080"),
-		DBHost:                   MustGetEnvOrDefault("DB_HOST", "localhost"),
-		DBPort:                   MustGetEnvOrDefault("DB_PORT", "5432"),
-		DBUser:                   MustGetEnvOrDefault("DB_USER", "Bishalkoirala"),
-		DBPassword:               MustGetEnvOrDefault("DB_PASSWORD", "bishal1212"),
-		DBName:                   MustGetEnvOrDefault("DB_NAME", "task_db"),
-		RedisHost:                MustGetEnvOrDefault("REDIS_HOST", "localhost"),
-		RedisPort:                MustGetEnvOrDefault("REDIS_PORT", "6379"),
-		RedisPassword:            MustGetEnvOrDefault("REDIS_PASSWORD", ""),
-		JWTSecretKey:             MustGetEnvOrDefault("JWT_SECRET", "mysecretkey"),
-		AccessTokenExpireMinutes: mustGetEnvASInt("ACCESS_TOKEN_EXPIRE_MINUTES", 15),
-		RefreshTokenExpireHours:  mustGetEnvASInt("REFRESH_TOKEN_EXPIRE_HOURS", 24),
+		AppName:                    MustGetEnvOrDefault("APP_NAME", "TaskManagmentApis"),
+		AppEnv:                     MustGetEnvOrDefault("APP_ENV", "development"),
+		Port:                       MustGetEnvOrDefault("PORT", "8080"),
+		DBHost:                     MustGetEnvOrDefault("DB_HOST", "localhost"),
+		DBPort:                     MustGetEnvOrDefault("DB_PORT", "5432"),
+		DBUser:                     MustGetEnvOrDefault("DB_USER", "Bishalkoirala"),
+		DBPassword:                 MustGetEnvOrDefault("DB_PASSWORD", "bishal1212"),
+		DBName:                     MustGetEnvOrDefault("DB_NAME", "task_db"),
+		RedisHost:                  MustGetEnvOrDefault("REDIS_HOST", "localhost"),
+		RedisPort:                  MustGetEnvOrDefault("REDIS_PORT", "6379"),
+		RedisPassword:              MustGetEnvOrDefault("REDIS_PASSWORD", ""),
+		JWTSecretKey:               MustGetEnvOrDefault("JWT_SECRET", "mysecretkey"),
+		AccessTokenExpireMinutes:   mustGetEnvASInt("ACCESS_TOKEN_EXPIRE_MINUTES", 15),
+		RefreshTokenExpireHours:    mustGetEnvASInt("REFRESH_TOKEN_EXPIRE_HOURS", 24),
+		SubtaskDeletePolicy:        MustGetEnvOrDefault("SUBTASK_DELETE_POLICY", "reparent"),
+		PublicBaseURL:              MustGetEnvOrDefault("PUBLIC_BASE_URL", "http://localhost:8080"),
+		StorageBackend:             MustGetEnvOrDefault("STORAGE_BACKEND", "local"),
+		StorageLocalDir:            MustGetEnvOrDefault("STORAGE_LOCAL_DIR", "./uploads"),
+		StorageSigningKey:          MustGetEnvOrDefault("STORAGE_SIGNING_KEY", MustGetEnvOrDefault("JWT_SECRET", "mysecretkey")),
+		SignedURLExpireMinutes:     mustGetEnvASInt("SIGNED_URL_EXPIRE_MINUTES", 5),
+		AttachmentMaxBytes:         mustGetEnvASInt("ATTACHMENT_MAX_BYTES", 10<<20),
+		AttachmentAllowedTypes:     MustGetEnvOrDefault("ATTACHMENT_ALLOWED_TYPES", defaultAttachmentTypes),
+		S3Endpoint:                 MustGetEnvOrDefault("S3_ENDPOINT", "http://localhost:9000"),
+		S3Region:                   MustGetEnvOrDefault("S3_REGION", "us-east-1"),
+		S3Bucket:                   MustGetEnvOrDefault("S3_BUCKET", "attachments"),
+		S3AccessKey:                MustGetEnvOrDefault("S3_ACCESS_KEY", ""),
+		S3SecretKey:                MustGetEnvOrDefault("S3_SECRET_KEY", ""),
+		S3UsePathStyle:             MustGetEnvOrDefault("S3_USE_PATH_STYLE", "true") == "true",
+		AdminEmails:                MustGetEnvOrDefault("ADMIN_EMAILS", ""),
+		TrashRetentionDays:         mustGetEnvASInt("TRASH_RETENTION_DAYS", 30),
+		TrashPurgeIntervalMins:     mustGetEnvASInt("TRASH_PURGE_INTERVAL_MINUTES", 60),
+		TaskImportMaxBytes:         mustGetEnvASInt("TASK_IMPORT_MAX_BYTES", 10<<20),
+		ReminderIntervalSecs:       mustGetEnvASInt("REMINDER_INTERVAL_SECONDS", 60),
+		MailBackend:                MustGetEnvOrDefault("MAIL_BACKEND", "log"),
+		MailFrom:                   MustGetEnvOrDefault("MAIL_FROM", "TaskManagmentApis <no-reply@localhost>"),
+		SMTPHost:                   MustGetEnvOrDefault("SMTP_HOST", "localhost"),
+		SMTPPort:                   mustGetEnvASInt("SMTP_PORT", 1025),
+		SMTPUsername:               MustGetEnvOrDefault("SMTP_USERNAME", ""),
+		SMTPPassword:               MustGetEnvOrDefault("SMTP_PASSWORD", ""),
+		SMTPSecurity:               MustGetEnvOrDefault("SMTP_SECURITY", "none"),
+		MailMaxAttempts:            mustGetEnvASInt("MAIL_MAX_ATTEMPTS", 6),
+		MailRetryBaseSecs:          mustGetEnvASInt("MAIL_RETRY_BASE_SECONDS", 30),
+		MailPollIntervalSecs:       mustGetEnvASInt("MAIL_POLL_INTERVAL_SECONDS", 5),
+		WebhookTimeoutSecs:         mustGetEnvASInt("WEBHOOK_TIMEOUT_SECONDS", 10),
+		WebhookMaxAttempts:         mustGetEnvASInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		WebhookRetryBaseSecs:       mustGetEnvASInt("WEBHOOK_RETRY_BASE_SECONDS", 30),
+		WebhookPollIntervalSecs:    mustGetEnvASInt("WEBHOOK_POLL_INTERVAL_SECONDS", 5),
+		WebhookBreakerThreshold:    mustGetEnvASInt("WEBHOOK_BREAKER_THRESHOLD", 5),
+		WebhookBreakerCooldownSecs: mustGetEnvASInt("WEBHOOK_BREAKER_COOLDOWN_SECONDS", 300),
+		WebhookAllowedNetworks:     MustGetEnvOrDefault("WEBHOOK_ALLOWED_NETWORKS", ""),
+		EventsHeartbeatSecs:        mustGetEnvASInt("EVENTS_HEARTBEAT_SECONDS", 25),
+		EventsReplayBufferSize:     mustGetEnvASInt("EVENTS_REPLAY_BUFFER_SIZE", 1000),
+		EventsTokenExpireSecs:      mustGetEnvASInt("EVENTS_TOKEN_EXPIRE_SECONDS", 60),
 	}
 }
 
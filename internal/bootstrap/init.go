@@ -4,8 +4,10 @@ import (
 	config "TaskManagmentApis/configs"
 	"TaskManagmentApis/internal/database"
 	"TaskManagmentApis/internal/handlers"
+	"TaskManagmentApis/internal/mail"
 	"TaskManagmentApis/internal/repositories"
 	service "TaskManagmentApis/internal/services"
+	"TaskManagmentApis/internal/storage"
 	"context"
 	"fmt"
 	"log"
@@ -14,13 +16,31 @@ import (
 )
 
 type Handlers struct {
-	Auth *handlers.AuthHandler
+	Auth         *handlers.AuthHandler
+	Task         *handlers.TaskHandler
+	Tag          *handlers.TagHandler
+	Project      *handlers.ProjectHandler
+	Board        *handlers.BoardHandler
+	Comment      *handlers.CommentHandler
+	Notification *handlers.NotificationHandler
+	Attachment   *handlers.AttachmentHandler
+	Workspace    *handlers.WorkspaceHandler
+	Admin        *handlers.AdminHandler
+	Audit        *handlers.AuditHandler
+	Calendar     *handlers.CalendarHandler
+	Webhook      *handlers.WebhookHandler
+	Event        *handlers.EventHandler
 }
 
 type AppContainer struct {
 	DB           *gorm.DB
 	RedisService database.RedisService
 	Handler      Handlers
+	TrashPurger  *service.TrashPurger
+	EmailSender  *service.EmailSender
+	Webhooks     *service.WebhookSender
+	EventStream  service.EventStreamService
+	Reminders    *service.ReminderScheduler
 }
 
 func InitalizeApp() (*AppContainer, error) {
@@ -45,26 +65,112 @@ func InitalizeApp() (*AppContainer, error) {
 
 	}
 
+	// Attachment storage backend
+	log.Println("🗄️  Initializing attachment storage...")
+	fileStorage, err := storage.NewFromConfig()
+	if err != nil {
+		return nil, fmt.Errorf("❌ Failed to initialize attachment storage: %w", err)
+	}
+
+	// Email delivery
+	log.Println("📧 Initializing mailer...")
+	mailer, err := mail.NewFromConfig()
+	if err != nil {
+		return nil, fmt.Errorf("❌ Failed to initialize mailer: %w", err)
+	}
+	mailTemplates, err := mail.LoadTemplates(config.Config.AppName, config.Config.PublicBaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("❌ Failed to load email templates: %w", err)
+	}
+
 	// repo->service->handler
 
 	// Initialize repo
 	log.Println("📦 Initializing repositories...")
 	authRepo := repositories.NewAuthRepository(db)
+	taskRepo := repositories.NewTaskRepository(db)
+	tagRepo := repositories.NewTagRepository(db)
+	projectRepo := repositories.NewProjectRepository(db)
+	boardRepo := repositories.NewBoardRepository(db)
+	commentRepo := repositories.NewCommentRepository(db)
+	notificationRepo := repositories.NewNotificationRepository(db)
+	attachmentRepo := repositories.NewAttachmentRepository(db)
+	workspaceRepo := repositories.NewWorkspaceRepository(db)
+	auditRepo := repositories.NewAuditRepository(db)
+	calendarRepo := repositories.NewCalendarRepository(db)
+	reminderRepo := repositories.NewReminderRepository(db)
+	emailRepo := repositories.NewEmailRepository(db)
+	webhookRepo := repositories.NewWebhookRepository(db)
 
 	// initialize service
 	log.Println("🧠 Initializing services...")
-	authService := service.NewAuthService(authRepo)
+	auditService := service.NewAuditService(auditRepo, authRepo)
+	emailService := service.NewEmailService(emailRepo, mailTemplates)
+	authService := service.NewAuthService(authRepo, workspaceRepo, auditService)
+	notificationService := service.NewNotificationService(notificationRepo)
+	attachmentService := service.NewAttachmentService(attachmentRepo, taskRepo, fileStorage, auditService)
+	taskService := service.NewTaskService(taskRepo, authRepo, projectRepo, workspaceRepo, tagRepo, boardRepo, notificationService, auditService, attachmentService)
+	tagService := service.NewTagService(tagRepo, taskRepo, auditService)
+	projectService := service.NewProjectService(projectRepo, workspaceRepo, auditService)
+	boardService := service.NewBoardService(boardRepo, taskRepo, projectRepo, workspaceRepo, taskService, auditService)
+	commentService := service.NewCommentService(commentRepo, taskRepo, notificationService)
+	workspaceService := service.NewWorkspaceService(workspaceRepo, authRepo, emailService, attachmentService)
+	adminService := service.NewAdminService(authRepo, auditService)
+	calendarService := service.NewCalendarService(calendarRepo, authRepo)
+	// one address policy for checking webhook URLs when saved and when delivered to
+	webhookAddresses := service.NewWebhookAddressPolicy()
+	webhookService := service.NewWebhookService(webhookRepo, taskRepo, webhookAddresses)
+	eventStreamService := service.NewEventStreamService(taskRepo, auditRepo, redisService)
+	auditService.AddListener(webhookService)
+	auditService.AddListener(eventStreamService)
 
 	// Initialize handler
 	log.Println("🧠 Initializing services...")
 	authHandler := handlers.NewAuthHandler(authService)
+	taskHandler := handlers.NewTaskHandler(taskService)
+	tagHandler := handlers.NewTagHandler(tagService)
+	projectHandler := handlers.NewProjectHandler(projectService)
+	boardHandler := handlers.NewBoardHandler(boardService)
+	commentHandler := handlers.NewCommentHandler(commentService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+	workspaceHandler := handlers.NewWorkspaceHandler(workspaceService)
+	adminHandler := handlers.NewAdminHandler(adminService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	calendarHandler := handlers.NewCalendarHandler(calendarService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	eventHandler := handlers.NewEventHandler(eventStreamService)
+
+	// Background jobs
+	trashPurger := service.NewTrashPurger(taskRepo, authRepo, attachmentService)
+	reminderScheduler := service.NewReminderScheduler(reminderRepo, emailService, redisService)
+	emailSender := service.NewEmailSender(emailRepo, mailer)
+	webhookSender := service.NewWebhookSender(webhookRepo, webhookAddresses)
 
 	return &AppContainer{
 		DB:           db,
 		RedisService: redisService,
 		Handler: Handlers{
-			Auth: authHandler,
+			Auth:         authHandler,
+			Task:         taskHandler,
+			Tag:          tagHandler,
+			Project:      projectHandler,
+			Board:        boardHandler,
+			Comment:      commentHandler,
+			Notification: notificationHandler,
+			Attachment:   attachmentHandler,
+			Workspace:    workspaceHandler,
+			Admin:        adminHandler,
+			Audit:        auditHandler,
+			Calendar:     calendarHandler,
+			Webhook:      webhookHandler,
+			Event:        eventHandler,
 		},
+		TrashPurger: trashPurger,
+		Reminders:   reminderScheduler,
+		EmailSender: emailSender,
+		Webhooks:    webhookSender,
+		EventStream: eventStreamService,
 	}, nil
 
 }
@@ -17,6 +17,9 @@ type RedisService interface {
 	Delete(ctx context.Context, key string) error
 	Incr(ctx context.Context, key string) (int64, error)                            // Add this if needed for Incr operation
 	Expire(ctx context.Context, key string, expiration time.Duration) (bool, error) // Add Expire method
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+	Publish(ctx context.Context, channel string, message interface{}) error
+	Subscribe(ctx context.Context, channels ...string) *redis.PubSub
 	GetClient() *redis.Client
 	Ping() error
 	Close() error
@@ -79,6 +82,21 @@ func (r *redisClient) Expire(ctx context.Context, key string, expiration time.Du
 	return r.client.Expire(ctx, key, expiration).Result()
 }
 
+// SetNX stores a value only if the key does not exist yet and reports whether it did
+func (r *redisClient) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, key, value, expiration).Result()
+}
+
+// Publish sends a message to every subscriber of the channel
+func (r *redisClient) Publish(ctx context.Context, channel string, message interface{}) error {
+	return r.client.Publish(ctx, channel, message).Err()
+}
+
+// Subscribe listens on the channels; the subscription reconnects by itself until it is closed
+func (r *redisClient) Subscribe(ctx context.Context, channels ...string) *redis.PubSub {
+	return r.client.Subscribe(ctx, channels...)
+}
+
 // GetClient returns the Redis client
 func (r *redisClient) GetClient() *redis.Client {
 	return r.client
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"TaskManagmentApis/internal/models"
+	service "TaskManagmentApis/internal/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	AdminService service.AdminService
+}
+
+func NewAdminHandler(adminService service.AdminService) *AdminHandler {
+	return &AdminHandler{
+		AdminService: adminService,
+	}
+}
+
+// respondWithAdminError maps service errors to HTTP responses
+func respondWithAdminError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrPermissionDenied):
+		respondWithError(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrUserNotFound):
+		respondWithError(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidRole):
+		respondWithError(ctx, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrOwnRoleChange), errors.Is(err, service.ErrOwnAccountDelete), errors.Is(err, service.ErrEmailInUse):
+		respondWithError(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrPreconditionFailed):
+		respondWithError(ctx, http.StatusPreconditionFailed, err.Error())
+	default:
+		respondWithError(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// ListRoles returns the defined roles and the permissions each one grants
+func (h *AdminHandler) ListRoles(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	roles, err := h.AdminService.ListRoles(userID)
+	if err != nil {
+		respondWithAdminError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"roles": roles})
+}
+
+// ListUsers returns a page of users; ?role= narrows it to one role
+func (h *AdminHandler) ListUsers(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var query models.UserListQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	users, total, err := h.AdminService.ListUsers(userID, query)
+	if err != nil {
+		respondWithAdminError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"users":       users,
+		"total_count": total,
+	})
+}
+
+// UpdateUserRole changes the system role of a user
+func (h *AdminHandler) UpdateUserRole(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	targetID, ok := parseUUIDParam(ctx, "userId", "user ID")
+	if !ok {
+		return
+	}
+	version, ok := requireIfMatch(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.UpdateUserRoleRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	user, err := h.AdminService.UpdateUserRole(userID, targetID, version, input, requestMeta(ctx))
+	if err != nil {
+		respondWithAdminError(ctx, err)
+		return
+	}
+
+	setETag(ctx, user.Version)
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Role updated successfully",
+		"user":    user,
+	})
+}
+
+// DeleteUser moves a user to the trash
+func (h *AdminHandler) DeleteUser(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	targetID, ok := parseUUIDParam(ctx, "userId", "user ID")
+	if !ok {
+		return
+	}
+	version, ok := requireIfMatch(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.AdminService.DeleteUser(userID, targetID, version, requestMeta(ctx)); err != nil {
+		respondWithAdminError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "User deleted successfully"})
+}
+
+// ListDeletedUsers returns the users in the trash
+func (h *AdminHandler) ListDeletedUsers(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	users, err := h.AdminService.ListDeletedUsers(userID)
+	if err != nil {
+		respondWithAdminError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"users": users})
+}
+
+// RestoreUser takes a user out of the trash
+func (h *AdminHandler) RestoreUser(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	targetID, ok := parseUUIDParam(ctx, "userId", "user ID")
+	if !ok {
+		return
+	}
+
+	user, err := h.AdminService.RestoreUser(userID, targetID, requestMeta(ctx))
+	if err != nil {
+		respondWithAdminError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "User restored successfully",
+		"user":    user,
+	})
+}
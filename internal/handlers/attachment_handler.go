@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	config "TaskManagmentApis/configs"
+	service "TaskManagmentApis/internal/services"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead leaves room for boundaries and part headers on top of the file size limit
+const multipartOverhead = 1 << 20
+
+type AttachmentHandler struct {
+	AttachmentService service.AttachmentService
+}
+
+func NewAttachmentHandler(attachmentService service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		AttachmentService: attachmentService,
+	}
+}
+
+// respondWithAttachmentError maps service errors to HTTP responses
+func respondWithAttachmentError(ctx *gin.Context, err error) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, service.ErrAttachmentNotFound), errors.Is(err, service.ErrTaskNotFound):
+		respondWithError(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrAttachmentTooLarge), errors.As(err, &maxBytesErr):
+		respondWithError(ctx, http.StatusRequestEntityTooLarge, service.ErrAttachmentTooLarge.Error())
+	case errors.Is(err, service.ErrAttachmentTypeNotAllowed):
+		respondWithError(ctx, http.StatusUnsupportedMediaType, err.Error())
+	case errors.Is(err, service.ErrInvalidAttachment):
+		respondWithError(ctx, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrInvalidDownloadLink), errors.Is(err, service.ErrAttachmentForbidden):
+		respondWithError(ctx, http.StatusForbidden, err.Error())
+	default:
+		respondWithError(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// UploadAttachment streams the "file" part of a multipart form into storage
+func (h *AttachmentHandler) UploadAttachment(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	maxBytes := int64(config.Config.AttachmentMaxBytes) + multipartOverhead
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBytes)
+	reader, err := ctx.Request.MultipartReader()
+	if err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Expected a multipart/form-data upload")
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			respondWithError(ctx, http.StatusBadRequest, "Form field \"file\" is required")
+			return
+		}
+		if err != nil {
+			respondWithAttachmentError(ctx, err)
+			return
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		attachment, created, err := h.AttachmentService.UploadAttachment(userID, taskID, part.FileName(), part, requestMeta(ctx))
+		part.Close()
+		if err != nil {
+			respondWithAttachmentError(ctx, err)
+			return
+		}
+
+		if !created {
+			ctx.JSON(http.StatusOK, gin.H{
+				"message":    "Task already has this file",
+				"attachment": attachment,
+			})
+			return
+		}
+		ctx.JSON(http.StatusCreated, gin.H{
+			"message":    "Attachment uploaded successfully",
+			"attachment": attachment,
+		})
+		return
+	}
+}
+
+// ListAttachments returns the attachments of a task
+func (h *AttachmentHandler) ListAttachments(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	attachments, err := h.AttachmentService.ListAttachments(userID, taskID)
+	if err != nil {
+		respondWithAttachmentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"attachments": attachments})
+}
+
+// GetDownloadURL returns a short-lived signed URL for an attachment
+func (h *AttachmentHandler) GetDownloadURL(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+	attachmentID, ok := parseUUIDParam(ctx, "attachmentId", "attachment ID")
+	if !ok {
+		return
+	}
+
+	download, err := h.AttachmentService.GetDownloadURL(userID, taskID, attachmentID)
+	if err != nil {
+		respondWithAttachmentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"download": download})
+}
+
+// DeleteAttachment
+func (h *AttachmentHandler) DeleteAttachment(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+	attachmentID, ok := parseUUIDParam(ctx, "attachmentId", "attachment ID")
+	if !ok {
+		return
+	}
+
+	if err := h.AttachmentService.DeleteAttachment(userID, taskID, attachmentID, requestMeta(ctx)); err != nil {
+		respondWithAttachmentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Attachment deleted successfully"})
+}
+
+// ServeSignedFile serves a file from a signed URL; the signature replaces the bearer token
+func (h *AttachmentHandler) ServeSignedFile(ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+
+	body, download, err := h.AttachmentService.OpenSignedDownload(key, ctx.Request.URL.Query())
+	if err != nil {
+		respondWithAttachmentError(ctx, err)
+		return
+	}
+	defer body.Close()
+
+	ctx.Header("Content-Type", download.ContentType)
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": download.FileName}))
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.Header("Cache-Control", "private, no-store")
+
+	if seeker, ok := body.(io.ReadSeeker); ok {
+		http.ServeContent(ctx.Writer, ctx.Request, "", time.Time{}, seeker)
+		return
+	}
+	ctx.Status(http.StatusOK)
+	io.Copy(ctx.Writer, body)
+}
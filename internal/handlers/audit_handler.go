@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"TaskManagmentApis/internal/models"
+	service "TaskManagmentApis/internal/services"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	AuditService service.AuditService
+}
+
+func NewAuditHandler(auditService service.AuditService) *AuditHandler {
+	return &AuditHandler{
+		AuditService: auditService,
+	}
+}
+
+// respondWithAuditError maps service errors to HTTP responses
+func respondWithAuditError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrPermissionDenied):
+		respondWithError(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrInvalidAuditQuery):
+		respondWithError(ctx, http.StatusBadRequest, err.Error())
+	default:
+		respondWithError(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// ListEvents returns a page of audit events, newest first; pass next_cursor back as ?cursor=
+func (h *AuditHandler) ListEvents(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var query models.AuditQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	page, err := h.AuditService.ListEvents(userID, query)
+	if err != nil {
+		respondWithAuditError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// ExportEvents streams every matching audit event as newline-delimited JSON, oldest first
+func (h *AuditHandler) ExportEvents(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var query models.AuditQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	// headers are only sent with the first event, so permission and filter errors can still
+	// be answered with a normal JSON error
+	started := false
+	start := func() {
+		ctx.Header("Content-Type", "application/x-ndjson")
+		ctx.Header("Content-Disposition", `attachment; filename="audit-events.ndjson"`)
+		ctx.Status(http.StatusOK)
+		started = true
+	}
+	encoder := json.NewEncoder(ctx.Writer)
+	err := h.AuditService.ExportEvents(userID, query, func(event *models.AuditEvent) error {
+		if !started {
+			start()
+		}
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+		ctx.Writer.Flush()
+		return nil
+	})
+	if err != nil {
+		if started {
+			// the status is already on the wire; the client sees a truncated stream
+			log.Printf("Audit export for user %s aborted: %v", userID, err)
+			return
+		}
+		respondWithAuditError(ctx, err)
+		return
+	}
+	if !started {
+		start()
+		ctx.Writer.WriteHeaderNow()
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"TaskManagmentApis/internal/models"
 	service "TaskManagmentApis/internal/services"
 	"TaskManagmentApis/pkg/utils"
+	"errors"
 	"log"
 	"net/http"
 	"time"
@@ -29,6 +30,39 @@ func respondWithError(ctx *gin.Context, code int, message string) {
 	ctx.JSON(code, gin.H{"error": message})
 }
 
+// getUserIDFromContext reads the user ID set by AuthMiddleware and writes an error response if it is missing
+func getUserIDFromContext(ctx *gin.Context) (uuid.UUID, bool) {
+	userIDValue, exists := ctx.Get("user_id")
+	if !exists {
+		respondWithError(ctx, http.StatusUnauthorized, "User ID is not found in context")
+		return uuid.Nil, false
+	}
+
+	// Convert interface {} to uuid.UUID (if it's a string, parse it)
+	switch v := userIDValue.(type) {
+	case string:
+		parsedUUID, err := uuid.Parse(v)
+		if err != nil {
+			respondWithError(ctx, http.StatusInternalServerError, "Invalid user ID format")
+			return uuid.Nil, false
+		}
+		return parsedUUID, true
+	case uuid.UUID:
+		return v, true
+	default:
+		respondWithError(ctx, http.StatusInternalServerError, "Invalid user ID format")
+		return uuid.Nil, false
+	}
+}
+
+// requestMeta captures where a request came from so services can record it in the audit log
+func requestMeta(ctx *gin.Context) models.RequestMeta {
+	return models.RequestMeta{
+		IP:        ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+	}
+}
+
 // Register handles user registration
 func (h *AuthHandler) Register(ctx *gin.Context) {
 	var input models.RegisterRequest
@@ -40,7 +74,7 @@ func (h *AuthHandler) Register(ctx *gin.Context) {
 	}
 
 	// Register service
-	user, accessToken, refreshToken, err := h.AuthService.RegisterUser(input.Name, input.Email, input.Password)
+	user, accessToken, refreshToken, err := h.AuthService.RegisterUser(input.Name, input.Email, input.Password, requestMeta(ctx))
 	if err != nil {
 		respondWithError(ctx, http.StatusConflict, err.Error())
 		return
@@ -72,7 +106,7 @@ func (h *AuthHandler) Login(ctx *gin.Context) {
 	}
 
 	// Login service
-	user, accessToken, refreshToken, err := h.AuthService.LoginUser(input.Email, input.Password)
+	user, accessToken, refreshToken, err := h.AuthService.LoginUser(input.Email, input.Password, requestMeta(ctx))
 	if err != nil {
 		respondWithError(ctx, http.StatusConflict, err.Error())
 		return
@@ -96,27 +130,8 @@ func (h *AuthHandler) Login(ctx *gin.Context) {
 // Logout handles user logout
 func (h *AuthHandler) Logout(ctx *gin.Context) {
 	// Get user ID from context
-	userIDValue, exists := ctx.Get("user_id")
-	if !exists {
-		respondWithError(ctx, http.StatusUnauthorized, "User ID is not found in context")
-		return
-	}
-
-	// Convert interface {} to uuid.UUID (if it's a string, parse it)
-	var userID uuid.UUID
-	switch v := userIDValue.(type) {
-	case string:
-		// Attempt to convert string to uuid.UUID
-		parsedUUID, err := uuid.Parse(v)
-		if err != nil {
-			respondWithError(ctx, http.StatusInternalServerError, "Invalid user ID format")
-			return
-		}
-		userID = parsedUUID
-	case uuid.UUID:
-		userID = v
-	default:
-		respondWithError(ctx, http.StatusInternalServerError, "Invalid user ID format")
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
 		return
 	}
 
@@ -124,7 +139,7 @@ func (h *AuthHandler) Logout(ctx *gin.Context) {
 	log.Printf("Logging out user %s", userID)
 
 	// Call service to delete the refresh token
-	if err := h.AuthService.LogoutUser(userID); err != nil {
+	if err := h.AuthService.LogoutUser(userID, requestMeta(ctx)); err != nil {
 		log.Printf("Logout failed for user %v: %v", userID, err) // Log the error
 		respondWithError(ctx, http.StatusInternalServerError, err.Error())
 		return
@@ -150,7 +165,7 @@ func (h *AuthHandler) RefreshToken(ctx *gin.Context) {
 	}
 
 	// Generate a new access token
-	newAccessToken, newRefreshToken, err := h.AuthService.GenerateAccessTokenByRefreshToken(req.RefreshToken)
+	newAccessToken, newRefreshToken, err := h.AuthService.GenerateAccessTokenByRefreshToken(req.RefreshToken, requestMeta(ctx))
 	if err != nil {
 		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
@@ -165,3 +180,95 @@ func (h *AuthHandler) RefreshToken(ctx *gin.Context) {
 		"expires_in":   time.Duration(config.Config.AccessTokenExpireMinutes) * time.Minute,
 	})
 }
+
+// respondWithProfileError maps errors of the profile endpoints to HTTP responses
+func respondWithProfileError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		respondWithError(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrPreconditionFailed):
+		respondWithError(ctx, http.StatusPreconditionFailed, err.Error())
+	default:
+		respondWithError(ctx, http.StatusBadRequest, err.Error())
+	}
+}
+
+// Me returns the authenticated user's profile with its ETag
+func (h *AuthHandler) Me(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	user, err := h.AuthService.GetCurrentUser(userID)
+	if err != nil {
+		respondWithProfileError(ctx, err)
+		return
+	}
+
+	if notModified(ctx, user.Version) {
+		return
+	}
+	setETag(ctx, user.Version)
+	ctx.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+// UpdateTimezone sets the authenticated user's time zone
+func (h *AuthHandler) UpdateTimezone(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	version, ok := requireIfMatch(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.UpdateTimezoneRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	user, err := h.AuthService.UpdateTimezone(userID, version, input.Timezone)
+	if err != nil {
+		respondWithProfileError(ctx, err)
+		return
+	}
+
+	setETag(ctx, user.Version)
+	ctx.JSON(http.StatusOK, gin.H{
+		"message":  "Timezone updated successfully",
+		"timezone": user.Timezone,
+	})
+}
+
+// UpdateReminderOffsets sets when the authenticated user is reminded of due tasks
+func (h *AuthHandler) UpdateReminderOffsets(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	version, ok := requireIfMatch(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.UpdateReminderOffsetsRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input: offsets_minutes must list up to 10 offsets between -10080 and 10080")
+		return
+	}
+
+	user, err := h.AuthService.UpdateReminderOffsets(userID, version, input.Offsets)
+	if err != nil {
+		respondWithProfileError(ctx, err)
+		return
+	}
+
+	setETag(ctx, user.Version)
+	ctx.JSON(http.StatusOK, gin.H{
+		"message":         "Reminders updated successfully",
+		"offsets_minutes": user.ReminderOffsets,
+	})
+}
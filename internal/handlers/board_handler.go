@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"TaskManagmentApis/internal/models"
+	service "TaskManagmentApis/internal/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type BoardHandler struct {
+	BoardService service.BoardService
+}
+
+func NewBoardHandler(boardService service.BoardService) *BoardHandler {
+	return &BoardHandler{
+		BoardService: boardService,
+	}
+}
+
+// respondWithBoardError maps service errors to HTTP responses
+func respondWithBoardError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrBoardNotFound), errors.Is(err, service.ErrColumnNotFound),
+		errors.Is(err, service.ErrCardNotFound), errors.Is(err, service.ErrTaskNotFound),
+		errors.Is(err, service.ErrProjectNotFound), errors.Is(err, service.ErrWorkspaceNotFound):
+		respondWithError(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrWorkspaceForbidden):
+		respondWithError(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrInvalidCardMove), errors.Is(err, service.ErrInvalidTaskInput):
+		respondWithError(ctx, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrColumnNotEmpty), errors.Is(err, service.ErrTaskBlocked):
+		respondWithError(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrInvalidStatusTransition):
+		respondWithError(ctx, http.StatusUnprocessableEntity, err.Error())
+	default:
+		respondWithError(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// parseUUIDParam reads a UUID path parameter and writes an error response if it is malformed
+func parseUUIDParam(ctx *gin.Context, name, label string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(name))
+	if err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid "+label)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// CreateBoard handles board creation in the active workspace
+func (h *BoardHandler) CreateBoard(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := getWorkspaceIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.CreateBoardRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	board, err := h.BoardService.CreateBoard(userID, workspaceID, input)
+	if err != nil {
+		respondWithBoardError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message": "Board created successfully",
+		"board":   board,
+	})
+}
+
+// ListBoards returns the user's boards in the active workspace
+func (h *BoardHandler) ListBoards(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := getWorkspaceIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	boards, err := h.BoardService.ListBoards(userID, workspaceID)
+	if err != nil {
+		respondWithBoardError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"boards": boards})
+}
+
+// GetBoard returns a board with columns and ordered cards
+func (h *BoardHandler) GetBoard(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	boardID, ok := parseUUIDParam(ctx, "boardId", "board ID")
+	if !ok {
+		return
+	}
+
+	board, err := h.BoardService.GetBoard(userID, boardID)
+	if err != nil {
+		respondWithBoardError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"board": board})
+}
+
+// RenameBoard
+func (h *BoardHandler) RenameBoard(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	boardID, ok := parseUUIDParam(ctx, "boardId", "board ID")
+	if !ok {
+		return
+	}
+
+	var input models.UpdateBoardRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	board, err := h.BoardService.RenameBoard(userID, boardID, input.Name)
+	if err != nil {
+		respondWithBoardError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Board updated successfully",
+		"board":   board,
+	})
+}
+
+// DeleteBoard
+func (h *BoardHandler) DeleteBoard(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	boardID, ok := parseUUIDParam(ctx, "boardId", "board ID")
+	if !ok {
+		return
+	}
+
+	if err := h.BoardService.DeleteBoard(userID, boardID); err != nil {
+		respondWithBoardError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Board deleted successfully"})
+}
+
+// AddColumn appends a column to a board
+func (h *BoardHandler) AddColumn(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	boardID, ok := parseUUIDParam(ctx, "boardId", "board ID")
+	if !ok {
+		return
+	}
+
+	var input models.BoardColumnRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	column, err := h.BoardService.AddColumn(userID, boardID, input)
+	if err != nil {
+		respondWithBoardError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message": "Column added successfully",
+		"column":  column,
+	})
+}
+
+// UpdateColumn renames, re-maps or repositions a column
+func (h *BoardHandler) UpdateColumn(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	boardID, ok := parseUUIDParam(ctx, "boardId", "board ID")
+	if !ok {
+		return
+	}
+	columnID, ok := parseUUIDParam(ctx, "columnId", "column ID")
+	if !ok {
+		return
+	}
+
+	var input models.UpdateBoardColumnRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	column, err := h.BoardService.UpdateColumn(userID, boardID, columnID, input)
+	if err != nil {
+		respondWithBoardError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Column updated successfully",
+		"column":  column,
+	})
+}
+
+// DeleteColumn removes an empty column
+func (h *BoardHandler) DeleteColumn(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	boardID, ok := parseUUIDParam(ctx, "boardId", "board ID")
+	if !ok {
+		return
+	}
+	columnID, ok := parseUUIDParam(ctx, "columnId", "column ID")
+	if !ok {
+		return
+	}
+
+	if err := h.BoardService.DeleteColumn(userID, boardID, columnID); err != nil {
+		respondWithBoardError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Column deleted successfully"})
+}
+
+// AddCard puts a task on a board
+func (h *BoardHandler) AddCard(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	boardID, ok := parseUUIDParam(ctx, "boardId", "board ID")
+	if !ok {
+		return
+	}
+
+	var input models.AddCardRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	card, err := h.BoardService.AddCard(userID, boardID, input, requestMeta(ctx))
+	if err != nil {
+		respondWithBoardError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"card": card})
+}
+
+// MoveCard changes a card's column and position in one step
+func (h *BoardHandler) MoveCard(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	boardID, ok := parseUUIDParam(ctx, "boardId", "board ID")
+	if !ok {
+		return
+	}
+	taskID, ok := parseUUIDParam(ctx, "taskId", "task ID")
+	if !ok {
+		return
+	}
+
+	var input models.MoveCardRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	card, err := h.BoardService.MoveCard(userID, boardID, taskID, input, requestMeta(ctx))
+	if err != nil {
+		respondWithBoardError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"card": card})
+}
+
+// RemoveCard takes a task off a board
+func (h *BoardHandler) RemoveCard(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	boardID, ok := parseUUIDParam(ctx, "boardId", "board ID")
+	if !ok {
+		return
+	}
+	taskID, ok := parseUUIDParam(ctx, "taskId", "task ID")
+	if !ok {
+		return
+	}
+
+	if err := h.BoardService.RemoveCard(userID, boardID, taskID); err != nil {
+		respondWithBoardError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Card removed successfully"})
+}
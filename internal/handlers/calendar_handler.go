@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"TaskManagmentApis/internal/models"
+	service "TaskManagmentApis/internal/services"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarHandler struct {
+	CalendarService service.CalendarService
+}
+
+func NewCalendarHandler(calendarService service.CalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		CalendarService: calendarService,
+	}
+}
+
+// respondWithCalendarError maps service errors to HTTP responses
+func respondWithCalendarError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrCalendarFeedNotFound):
+		respondWithError(ctx, http.StatusNotFound, err.Error())
+	default:
+		respondWithError(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// GetFeed reports whether the user has a feed and when it was last fetched
+func (h *CalendarHandler) GetFeed(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	feed, err := h.CalendarService.GetFeed(userID)
+	if err != nil {
+		respondWithCalendarError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, feed)
+}
+
+// RegenerateToken issues a new secret feed URL and revokes the previous one
+func (h *CalendarHandler) RegenerateToken(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	token, err := h.CalendarService.RegenerateFeedToken(userID)
+	if err != nil {
+		respondWithCalendarError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, token)
+}
+
+// DeleteFeed revokes the user's feed URL
+func (h *CalendarHandler) DeleteFeed(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.CalendarService.DeleteFeed(userID); err != nil {
+		respondWithCalendarError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Calendar feed deleted successfully"})
+}
+
+// ServeFeed renders the iCalendar document for GET /calendar/{token}.ics
+func (h *CalendarHandler) ServeFeed(ctx *gin.Context) {
+	token, ok := strings.CutSuffix(ctx.Param("token"), ".ics")
+	if !ok || token == "" {
+		respondWithError(ctx, http.StatusNotFound, service.ErrCalendarFeedNotFound.Error())
+		return
+	}
+
+	var query models.CalendarFeedQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+	if query.Type == "" {
+		query.Type = models.CalendarEntryEvent
+	}
+
+	body, err := h.CalendarService.RenderFeed(token, query.Type)
+	if err != nil {
+		respondWithCalendarError(ctx, err)
+		return
+	}
+
+	// the URL is a credential; keep it and the document out of shared caches
+	ctx.Header("Cache-Control", "private, max-age=300")
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(body))
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"TaskManagmentApis/internal/models"
+	service "TaskManagmentApis/internal/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CommentHandler struct {
+	CommentService service.CommentService
+}
+
+func NewCommentHandler(commentService service.CommentService) *CommentHandler {
+	return &CommentHandler{
+		CommentService: commentService,
+	}
+}
+
+// respondWithCommentError maps service errors to HTTP responses
+func respondWithCommentError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrCommentNotFound), errors.Is(err, service.ErrTaskNotFound):
+		respondWithError(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrCommentForbidden):
+		respondWithError(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrInvalidCommentInput):
+		respondWithError(ctx, http.StatusBadRequest, err.Error())
+	default:
+		respondWithError(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// ListComments returns the threaded comments of a task
+func (h *CommentHandler) ListComments(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	comments, err := h.CommentService.ListComments(userID, taskID)
+	if err != nil {
+		respondWithCommentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"comments": comments})
+}
+
+// CreateComment adds a comment or a reply to a task
+func (h *CommentHandler) CreateComment(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.CreateCommentRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	comment, err := h.CommentService.CreateComment(userID, taskID, input)
+	if err != nil {
+		respondWithCommentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message": "Comment created successfully",
+		"comment": comment,
+	})
+}
+
+// UpdateComment edits the body of a comment
+func (h *CommentHandler) UpdateComment(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+	commentID, ok := parseUUIDParam(ctx, "commentId", "comment ID")
+	if !ok {
+		return
+	}
+
+	var input models.UpdateCommentRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	comment, err := h.CommentService.UpdateComment(userID, taskID, commentID, input)
+	if err != nil {
+		respondWithCommentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Comment updated successfully",
+		"comment": comment,
+	})
+}
+
+// DeleteComment soft deletes a comment
+func (h *CommentHandler) DeleteComment(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+	commentID, ok := parseUUIDParam(ctx, "commentId", "comment ID")
+	if !ok {
+		return
+	}
+
+	if err := h.CommentService.DeleteComment(userID, taskID, commentID); err != nil {
+		respondWithCommentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Comment deleted successfully"})
+}
+
+// GetRevisions returns the earlier versions of a comment
+func (h *CommentHandler) GetRevisions(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+	commentID, ok := parseUUIDParam(ctx, "commentId", "comment ID")
+	if !ok {
+		return
+	}
+
+	revisions, err := h.CommentService.GetRevisions(userID, taskID, commentID)
+	if err != nil {
+		respondWithCommentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
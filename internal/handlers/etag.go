@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etag formats a record version as a strong entity tag
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// setETag advertises the version of the record in the response
+func setETag(ctx *gin.Context, version int) {
+	ctx.Header("ETag", etag(version))
+}
+
+// notModified answers 304 when If-None-Match already names the current version
+func notModified(ctx *gin.Context, version int) bool {
+	for _, tag := range strings.Split(ctx.GetHeader("If-None-Match"), ",") {
+		if tag = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(tag), "W/")); tag == etag(version) || tag == "*" {
+			setETag(ctx, version)
+			ctx.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// requireIfMatch reads the version a write is based on from If-Match. Writes without the header
+// get 428 and tags that are not a version get 412. "*" matches any version and yields 0.
+func requireIfMatch(ctx *gin.Context) (int, bool) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" {
+		respondWithError(ctx, http.StatusPreconditionRequired, "If-Match header with the ETag of the record is required")
+		return 0, false
+	}
+	if header == "*" {
+		return 0, true
+	}
+
+	// If-Match uses strong comparison, so weak tags never match
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		respondWithError(ctx, http.StatusPreconditionFailed, "If-Match must be a single ETag")
+		return 0, false
+	}
+	version, err := strconv.Atoi(header[1 : len(header)-1])
+	if err != nil || version <= 0 {
+		respondWithError(ctx, http.StatusPreconditionFailed, "If-Match does not match the current version")
+		return 0, false
+	}
+	return version, true
+}
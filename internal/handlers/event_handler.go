@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	config "TaskManagmentApis/configs"
+	"TaskManagmentApis/internal/models"
+	service "TaskManagmentApis/internal/services"
+	"TaskManagmentApis/pkg/utils"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// eventStreamRetry is how long browsers wait before reconnecting a dropped stream
+	eventStreamRetry            = 3 * time.Second
+	defaultEventStreamHeartbeat = 25 * time.Second
+)
+
+type EventHandler struct {
+	EventStreamService service.EventStreamService
+}
+
+func NewEventHandler(eventStreamService service.EventStreamService) *EventHandler {
+	return &EventHandler{
+		EventStreamService: eventStreamService,
+	}
+}
+
+// lastEventID reads where a reconnecting client left off: browsers send the Last-Event-ID header,
+// clients that cannot set headers may pass last_event_id instead
+func lastEventID(ctx *gin.Context) int64 {
+	value := ctx.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = ctx.Query("last_event_id")
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0
+	}
+	return id
+}
+
+// IssueStreamToken returns a short-lived token carrying the caller's claims for opening the stream
+// as GET /events?token=..., for clients that cannot set the Authorization header. The token is only
+// checked when the stream is opened, so a client whose stream dropped after it expired fetches a new one.
+func (h *EventHandler) IssueStreamToken(ctx *gin.Context) {
+	value, _ := ctx.Get("claims")
+	claims, ok := value.(*utils.Claims)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	token, expiresAt, err := utils.GenerateStreamToken(claims)
+	if err != nil {
+		log.Printf("Error generating stream token for user %s: %v", claims.UserID, err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate stream token"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"token": token, "expires_at": expiresAt})
+}
+
+// renderStreamEvent writes one event in the text/event-stream format
+func renderStreamEvent(ctx *gin.Context, event models.StreamEvent) {
+	sseEvent := sse.Event{Event: event.Type, Data: event.Data}
+	if event.ID > 0 {
+		sseEvent.Id = strconv.FormatInt(event.ID, 10)
+	}
+	ctx.Render(-1, sseEvent)
+}
+
+// Stream pushes the user's task changes as Server-Sent Events until the client disconnects. A
+// client resuming with Last-Event-ID first gets the events it missed, or a reset event when they
+// are no longer buffered. Comment lines are sent as heartbeats to keep proxies from timing out.
+func (h *EventHandler) Stream(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	subscription, missed, reset := h.EventStreamService.Subscribe(userID, lastEventID(ctx))
+	defer h.EventStreamService.Unsubscribe(subscription)
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	// keep nginx from buffering the stream
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	io.WriteString(ctx.Writer, "retry: "+strconv.FormatInt(eventStreamRetry.Milliseconds(), 10)+"\n\n")
+	if reset {
+		ctx.Render(-1, sse.Event{Event: models.StreamEventReset, Data: gin.H{}})
+	}
+	for _, event := range missed {
+		renderStreamEvent(ctx, event)
+	}
+	ctx.Writer.Flush()
+
+	interval := time.Duration(config.Config.EventsHeartbeatSecs) * time.Second
+	if interval <= 0 {
+		interval = defaultEventStreamHeartbeat
+	}
+	heartbeat := time.NewTicker(interval)
+	defer heartbeat.Stop()
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return false
+			}
+			renderStreamEvent(ctx, event)
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return false
+			}
+		}
+		return true
+	})
+}
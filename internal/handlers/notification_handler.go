@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"TaskManagmentApis/internal/models"
+	service "TaskManagmentApis/internal/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	NotificationService service.NotificationService
+}
+
+func NewNotificationHandler(notificationService service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		NotificationService: notificationService,
+	}
+}
+
+// ListNotifications returns the user's notifications, newest first
+func (h *NotificationHandler) ListNotifications(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var query models.NotificationListQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	notifications, unread, err := h.NotificationService.ListNotifications(userID, query)
+	if err != nil {
+		respondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"notifications": notifications,
+		"unread":        unread,
+	})
+}
+
+// MarkRead marks a single notification as read
+func (h *NotificationHandler) MarkRead(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	notificationID, ok := parseUUIDParam(ctx, "notificationId", "notification ID")
+	if !ok {
+		return
+	}
+
+	if err := h.NotificationService.MarkRead(userID, notificationID); err != nil {
+		if errors.Is(err, service.ErrNotificationNotFound) {
+			respondWithError(ctx, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Notification marked as read"})
+}
+
+// MarkAllRead marks every notification of the user as read
+func (h *NotificationHandler) MarkAllRead(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	count, err := h.NotificationService.MarkAllRead(userID)
+	if err != nil {
+		respondWithError(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"updated": count})
+}
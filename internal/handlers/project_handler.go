@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"TaskManagmentApis/internal/models"
+	service "TaskManagmentApis/internal/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ProjectHandler struct {
+	ProjectService service.ProjectService
+}
+
+func NewProjectHandler(projectService service.ProjectService) *ProjectHandler {
+	return &ProjectHandler{
+		ProjectService: projectService,
+	}
+}
+
+// respondWithProjectError maps service errors to HTTP responses
+func respondWithProjectError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrProjectNotFound), errors.Is(err, service.ErrTaskNotFound),
+		errors.Is(err, service.ErrWorkspaceNotFound):
+		respondWithError(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrWorkspaceForbidden):
+		respondWithError(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrProjectArchived):
+		respondWithError(ctx, http.StatusConflict, err.Error())
+	default:
+		respondWithError(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// parseProjectID reads the :projectId path parameter
+func parseProjectID(ctx *gin.Context) (uuid.UUID, bool) {
+	projectID, err := uuid.Parse(ctx.Param("projectId"))
+	if err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid project ID")
+		return uuid.Nil, false
+	}
+	return projectID, true
+}
+
+// CreateProject handles project creation
+func (h *ProjectHandler) CreateProject(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := getWorkspaceIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.CreateProjectRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	project, err := h.ProjectService.CreateProject(userID, workspaceID, input)
+	if err != nil {
+		respondWithProjectError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message": "Project created successfully",
+		"project": project,
+	})
+}
+
+// ListProjects returns the user's projects; ?archived=true includes archived ones
+func (h *ProjectHandler) ListProjects(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := getWorkspaceIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	projects, err := h.ProjectService.ListProjects(userID, workspaceID, ctx.Query("archived") == "true")
+	if err != nil {
+		respondWithProjectError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"projects": projects})
+}
+
+// GetProject returns a single project
+func (h *ProjectHandler) GetProject(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	project, err := h.ProjectService.GetProject(userID, projectID)
+	if err != nil {
+		respondWithProjectError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"project": project})
+}
+
+// UpdateProject handles partial project updates
+func (h *ProjectHandler) UpdateProject(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.UpdateProjectRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	project, err := h.ProjectService.UpdateProject(userID, projectID, input)
+	if err != nil {
+		respondWithProjectError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Project updated successfully",
+		"project": project,
+	})
+}
+
+// DeleteProject handles project deletion
+func (h *ProjectHandler) DeleteProject(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.ProjectService.DeleteProject(userID, projectID); err != nil {
+		respondWithProjectError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully"})
+}
+
+// MoveTasks moves tasks between projects
+func (h *ProjectHandler) MoveTasks(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := getWorkspaceIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.MoveTasksRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	if err := h.ProjectService.MoveTasks(userID, workspaceID, input, requestMeta(ctx)); err != nil {
+		respondWithProjectError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Tasks moved successfully"})
+}
+
+// GetSummary returns task counts by status for a project
+func (h *ProjectHandler) GetSummary(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	summary, err := h.ProjectService.GetSummary(userID, projectID)
+	if err != nil {
+		respondWithProjectError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"TaskManagmentApis/internal/models"
+	service "TaskManagmentApis/internal/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TagHandler struct {
+	TagService service.TagService
+}
+
+func NewTagHandler(tagService service.TagService) *TagHandler {
+	return &TagHandler{
+		TagService: tagService,
+	}
+}
+
+// respondWithTagError maps service errors to HTTP responses
+func respondWithTagError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrTagNotFound), errors.Is(err, service.ErrTaskNotFound):
+		respondWithError(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrTagNameTaken):
+		respondWithError(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrInvalidTagInput):
+		respondWithError(ctx, http.StatusBadRequest, err.Error())
+	default:
+		respondWithError(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// parseTagID reads the :tagId path parameter
+func parseTagID(ctx *gin.Context) (uuid.UUID, bool) {
+	tagID, err := uuid.Parse(ctx.Param("tagId"))
+	if err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid tag ID")
+		return uuid.Nil, false
+	}
+	return tagID, true
+}
+
+// CreateTag handles tag creation
+func (h *TagHandler) CreateTag(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.CreateTagRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	tag, err := h.TagService.CreateTag(userID, input)
+	if err != nil {
+		respondWithTagError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message": "Tag created successfully",
+		"tag":     tag,
+	})
+}
+
+// ListTags returns the user's tags
+func (h *TagHandler) ListTags(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	tags, err := h.TagService.ListTags(userID)
+	if err != nil {
+		respondWithTagError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+// UpdateTag renames or recolors a tag
+func (h *TagHandler) UpdateTag(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	tagID, ok := parseTagID(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.UpdateTagRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	tag, err := h.TagService.UpdateTag(userID, tagID, input)
+	if err != nil {
+		respondWithTagError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Tag updated successfully",
+		"tag":     tag,
+	})
+}
+
+// DeleteTag deletes a tag
+func (h *TagHandler) DeleteTag(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	tagID, ok := parseTagID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.TagService.DeleteTag(userID, tagID, requestMeta(ctx)); err != nil {
+		respondWithTagError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Tag deleted successfully"})
+}
+
+// MergeTag merges the tag in the path into the target tag
+func (h *TagHandler) MergeTag(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	tagID, ok := parseTagID(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.MergeTagRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	tag, err := h.TagService.MergeTags(userID, tagID, input.TargetID, requestMeta(ctx))
+	if err != nil {
+		respondWithTagError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Tags merged successfully",
+		"tag":     tag,
+	})
+}
+
+// UpdateTaskTags attaches and detaches tags on a task
+func (h *TagHandler) UpdateTaskTags(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.UpdateTaskTagsRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	tags, err := h.TagService.UpdateTaskTags(userID, taskID, input, requestMeta(ctx))
+	if err != nil {
+		respondWithTagError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"tags": tags})
+}
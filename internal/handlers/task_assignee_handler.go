@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"TaskManagmentApis/internal/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListAssignedTasks returns a filtered, sorted page of the tasks assigned to the user
+func (h *TaskHandler) ListAssignedTasks(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := getWorkspaceIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var query models.TaskListQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	result, err := h.TaskService.ListAssignedTasks(userID, workspaceID, query)
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// GetAssignees lists the users assigned to a task
+func (h *TaskHandler) GetAssignees(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	assignees, err := h.TaskService.GetAssignees(userID, taskID)
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"assignees": assignees})
+}
+
+// AssignTask adds assignees to a task
+func (h *TaskHandler) AssignTask(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.AssignTaskRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	assignees, err := h.TaskService.AssignTask(userID, taskID, input.UserIDs, requestMeta(ctx))
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message":   "Task assigned successfully",
+		"assignees": assignees,
+	})
+}
+
+// ReplaceAssignees reassigns a task to exactly the given users; If-Match must carry the task's ETag
+func (h *TaskHandler) ReplaceAssignees(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+	version, ok := requireIfMatch(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.ReplaceAssigneesRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	assignees, err := h.TaskService.ReplaceAssignees(userID, taskID, version, input.UserIDs, requestMeta(ctx))
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message":   "Task reassigned successfully",
+		"assignees": assignees,
+	})
+}
+
+// UnassignTask removes one assignee from a task; If-Match must carry the task's ETag
+func (h *TaskHandler) UnassignTask(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+	assigneeID, ok := parseUUIDParam(ctx, "userId", "user ID")
+	if !ok {
+		return
+	}
+	version, ok := requireIfMatch(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.TaskService.UnassignTask(userID, taskID, assigneeID, version, requestMeta(ctx)); err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "User unassigned successfully"})
+}
+
+// GetAssignmentHistory returns the recorded assignment changes of a task
+func (h *TaskHandler) GetAssignmentHistory(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	events, err := h.TaskService.GetAssignmentHistory(userID, taskID)
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"history": events})
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"TaskManagmentApis/internal/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetDependencies lists the blockers of a task and the tasks it blocks
+func (h *TaskHandler) GetDependencies(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	deps, err := h.TaskService.GetDependencies(userID, taskID)
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deps)
+}
+
+// AddDependency marks another task as a blocker of this one
+func (h *TaskHandler) AddDependency(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.AddDependencyRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	if err := h.TaskService.AddDependency(userID, taskID, input.BlockerID, requestMeta(ctx)); err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"message": "Dependency added successfully"})
+}
+
+// RemoveDependency deletes a blocker from a task; If-Match must carry the task's ETag
+func (h *TaskHandler) RemoveDependency(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+	blockerID, err := uuid.Parse(ctx.Param("blockerId"))
+	if err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid blocker ID")
+		return
+	}
+	version, ok := requireIfMatch(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.TaskService.RemoveDependency(userID, taskID, blockerID, version, requestMeta(ctx)); err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Dependency removed successfully"})
+}
+
+// GetExecutionPlan returns the user's open tasks in dependency (topological) order
+func (h *TaskHandler) GetExecutionPlan(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := getWorkspaceIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	plan, err := h.TaskService.GetExecutionPlan(userID, workspaceID)
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"tasks": plan})
+}
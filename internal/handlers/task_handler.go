@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"TaskManagmentApis/internal/models"
+	service "TaskManagmentApis/internal/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TaskHandler struct {
+	TaskService service.TaskService
+}
+
+func NewTaskHandler(taskService service.TaskService) *TaskHandler {
+	return &TaskHandler{
+		TaskService: taskService,
+	}
+}
+
+// parseTaskID reads the :id path parameter and writes an error response if it is not a UUID
+func parseTaskID(ctx *gin.Context) (uuid.UUID, bool) {
+	taskID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid task ID")
+		return uuid.Nil, false
+	}
+	return taskID, true
+}
+
+// respondWithTaskError maps service errors to HTTP responses
+func respondWithTaskError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrTaskNotFound), errors.Is(err, service.ErrDependencyNotFound),
+		errors.Is(err, service.ErrProjectNotFound), errors.Is(err, service.ErrAssigneeNotFound),
+		errors.Is(err, service.ErrWorkspaceNotFound), errors.Is(err, service.ErrTagNotFound):
+		respondWithError(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidTaskQuery), errors.Is(err, service.ErrParentTaskNotFound),
+		errors.Is(err, service.ErrInvalidRecurrence), errors.Is(err, service.ErrInvalidTaskInput):
+		respondWithError(ctx, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrTaskCycle), errors.Is(err, service.ErrDependencyCycle), errors.Is(err, service.ErrTaskBlocked),
+		errors.Is(err, service.ErrProjectArchived):
+		respondWithError(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrTaskForbidden), errors.Is(err, service.ErrWorkspaceForbidden):
+		respondWithError(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrInvalidStatusTransition):
+		respondWithError(ctx, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, service.ErrPreconditionFailed):
+		respondWithError(ctx, http.StatusPreconditionFailed, err.Error())
+	default:
+		respondWithError(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// CreateTask handles task creation
+func (h *TaskHandler) CreateTask(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := getWorkspaceIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.CreateTaskRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	task, err := h.TaskService.CreateTask(userID, workspaceID, input, requestMeta(ctx))
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	setETag(ctx, task.Version)
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message": "Task created successfully",
+		"task":    task,
+	})
+}
+
+// ListTasks returns a filtered, sorted page of the user's tasks
+func (h *TaskHandler) ListTasks(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := getWorkspaceIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var query models.TaskListQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	result, err := h.TaskService.ListTasks(userID, workspaceID, query)
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// SearchTasks runs a full-text search over the user's tasks
+func (h *TaskHandler) SearchTasks(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := getWorkspaceIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var query models.TaskSearchQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Query parameter q is required")
+		return
+	}
+
+	hits, err := h.TaskService.SearchTasks(userID, workspaceID, query)
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"results": hits})
+}
+
+// GetTask returns a single task
+func (h *TaskHandler) GetTask(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	task, err := h.TaskService.GetTask(userID, taskID)
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	if notModified(ctx, task.Version) {
+		return
+	}
+	setETag(ctx, task.Version)
+	ctx.JSON(http.StatusOK, gin.H{"task": task})
+}
+
+// UpdateTask handles partial task updates
+func (h *TaskHandler) UpdateTask(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	version, ok := requireIfMatch(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.UpdateTaskRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	task, err := h.TaskService.UpdateTask(userID, taskID, version, input, requestMeta(ctx))
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	setETag(ctx, task.Version)
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Task updated successfully",
+		"task":    task,
+	})
+}
+
+// DeleteTask handles task deletion
+func (h *TaskHandler) DeleteTask(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	version, ok := requireIfMatch(ctx)
+	if !ok {
+		return
+	}
+
+	// ?policy=cascade|reparent decides what happens to subtasks
+	if err := h.TaskService.DeleteTask(userID, taskID, version, ctx.Query("policy"), requestMeta(ctx)); err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Task deleted successfully"})
+}
+
+// GetSubtree returns a task with all nested subtasks and rolled up completion
+func (h *TaskHandler) GetSubtree(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	tree, err := h.TaskService.GetSubtree(userID, taskID)
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"task": tree})
+}
+
+// MoveTask moves a task and its subtasks under a new parent
+func (h *TaskHandler) MoveTask(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.MoveTaskRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	task, err := h.TaskService.MoveTask(userID, taskID, input.ParentID, requestMeta(ctx))
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	setETag(ctx, task.Version)
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Task moved successfully",
+		"task":    task,
+	})
+}
+
+// PreviewRecurrence lists the next dates a recurrence rule produces
+func (h *TaskHandler) PreviewRecurrence(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var query models.RecurrencePreviewQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	dates, err := h.TaskService.PreviewRecurrence(userID, query)
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"dates": dates})
+}
+
+// GetStatusTransitions returns the status history of a task
+func (h *TaskHandler) GetStatusTransitions(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	transitions, err := h.TaskService.GetStatusTransitions(userID, taskID)
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"transitions": transitions})
+}
+
+// BulkUpdateTasks applies one operation to many tasks and reports the outcome per task
+func (h *TaskHandler) BulkUpdateTasks(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := getWorkspaceIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.BulkTaskRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	result, err := h.TaskService.BulkUpdateTasks(userID, workspaceID, input, requestMeta(ctx))
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	// 409 when an atomic batch was rolled back, 207 when only some tasks of a best effort batch failed
+	status := http.StatusOK
+	switch {
+	case !result.Applied:
+		status = http.StatusConflict
+	case result.Failed > 0:
+		status = http.StatusMultiStatus
+	}
+	ctx.JSON(status, result)
+}
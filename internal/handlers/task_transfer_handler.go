@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	config "TaskManagmentApis/configs"
+	"TaskManagmentApis/internal/models"
+	service "TaskManagmentApis/internal/services"
+	"log"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// taskFormatContentTypes are the response types of the export formats, and the request types
+// an import is recognized by when ?format= is left out
+var taskFormatContentTypes = map[string]string{
+	models.TaskFormatCSV:    "text/csv",
+	models.TaskFormatJSON:   "application/json",
+	models.TaskFormatNDJSON: "application/x-ndjson",
+}
+
+// ExportTasks streams the user's tasks as CSV, JSON or NDJSON; GET /tasks filters apply
+func (h *TaskHandler) ExportTasks(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := getWorkspaceIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var query models.TaskExportQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+	if query.Format == "" {
+		query.Format = models.TaskFormatJSON
+	}
+
+	// like the audit export, headers wait for the first task so that filter errors are still JSON
+	var encoder service.TaskRecordEncoder
+	start := func() {
+		ctx.Header("Content-Type", taskFormatContentTypes[query.Format])
+		ctx.Header("Content-Disposition", `attachment; filename="tasks.`+query.Format+`"`)
+		ctx.Status(http.StatusOK)
+		encoder = service.NewTaskRecordEncoder(query.Format, ctx.Writer)
+	}
+	err := h.TaskService.ExportTasks(userID, workspaceID, query.TaskListQuery, func(record *models.TaskRecord) error {
+		if encoder == nil {
+			start()
+		}
+		return encoder.Encode(record)
+	})
+	if err != nil {
+		if encoder != nil {
+			// the status is already on the wire; the client sees a truncated file
+			log.Printf("Task export for user %s aborted: %v", userID, err)
+			return
+		}
+		respondWithTaskError(ctx, err)
+		return
+	}
+	if encoder == nil {
+		start()
+	}
+	if err := encoder.Close(); err != nil {
+		log.Printf("Task export for user %s could not be completed: %v", userID, err)
+	}
+}
+
+// ImportTasks creates tasks from a CSV, JSON or NDJSON request body and reports on every row
+func (h *TaskHandler) ImportTasks(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := getWorkspaceIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var query models.TaskImportQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+	if query.Format == "" {
+		mediaType, _, _ := mime.ParseMediaType(ctx.GetHeader("Content-Type"))
+		for format, contentType := range taskFormatContentTypes {
+			if mediaType == contentType {
+				query.Format = format
+			}
+		}
+		if query.Format == "" {
+			respondWithError(ctx, http.StatusUnsupportedMediaType, "Send text/csv, application/json or application/x-ndjson, or set ?format=")
+			return
+		}
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, int64(config.Config.TaskImportMaxBytes))
+	report, err := h.TaskService.ImportTasks(userID, workspaceID, ctx.Request.Body, query, requestMeta(ctx))
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListTrash returns the user's deleted tasks in the active workspace
+func (h *TaskHandler) ListTrash(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := getWorkspaceIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	tasks, err := h.TaskService.ListDeletedTasks(userID, workspaceID)
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"tasks": tasks})
+}
+
+// RestoreTask takes a task out of the trash
+func (h *TaskHandler) RestoreTask(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	task, err := h.TaskService.RestoreTask(userID, taskID, requestMeta(ctx))
+	if err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	setETag(ctx, task.Version)
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Task restored successfully",
+		"task":    task,
+	})
+}
+
+// PurgeTask permanently deletes a task from the trash
+func (h *TaskHandler) PurgeTask(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	taskID, ok := parseTaskID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.TaskService.PurgeTask(userID, taskID, requestMeta(ctx)); err != nil {
+		respondWithTaskError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Task permanently deleted"})
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"TaskManagmentApis/internal/models"
+	service "TaskManagmentApis/internal/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	WebhookService service.WebhookService
+}
+
+func NewWebhookHandler(webhookService service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		WebhookService: webhookService,
+	}
+}
+
+// respondWithWebhookError maps service errors to HTTP responses
+func respondWithWebhookError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrWebhookNotFound), errors.Is(err, service.ErrWebhookDeliveryNotFound):
+		respondWithError(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidWebhookInput):
+		respondWithError(ctx, http.StatusBadRequest, err.Error())
+	default:
+		respondWithError(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// CreateWebhook registers an endpoint; the response carries the signing secret, shown only this once
+func (h *WebhookHandler) CreateWebhook(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.CreateWebhookRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	webhook, err := h.WebhookService.CreateWebhook(userID, input)
+	if err != nil {
+		respondWithWebhookError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message": "Webhook created successfully",
+		"webhook": webhook,
+	})
+}
+
+// ListWebhooks returns the user's webhooks
+func (h *WebhookHandler) ListWebhooks(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	webhooks, err := h.WebhookService.ListWebhooks(userID)
+	if err != nil {
+		respondWithWebhookError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+}
+
+// GetWebhook
+func (h *WebhookHandler) GetWebhook(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	webhookID, ok := parseUUIDParam(ctx, "webhookId", "webhook ID")
+	if !ok {
+		return
+	}
+
+	webhook, err := h.WebhookService.GetWebhook(userID, webhookID)
+	if err != nil {
+		respondWithWebhookError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"webhook": webhook})
+}
+
+// UpdateWebhook changes the URL, description, events or active flag
+func (h *WebhookHandler) UpdateWebhook(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	webhookID, ok := parseUUIDParam(ctx, "webhookId", "webhook ID")
+	if !ok {
+		return
+	}
+
+	var input models.UpdateWebhookRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	webhook, err := h.WebhookService.UpdateWebhook(userID, webhookID, input)
+	if err != nil {
+		respondWithWebhookError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Webhook updated successfully",
+		"webhook": webhook,
+	})
+}
+
+// DeleteWebhook
+func (h *WebhookHandler) DeleteWebhook(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	webhookID, ok := parseUUIDParam(ctx, "webhookId", "webhook ID")
+	if !ok {
+		return
+	}
+
+	if err := h.WebhookService.DeleteWebhook(userID, webhookID); err != nil {
+		respondWithWebhookError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// RotateSecret replaces the signing secret and returns the new one
+func (h *WebhookHandler) RotateSecret(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	webhookID, ok := parseUUIDParam(ctx, "webhookId", "webhook ID")
+	if !ok {
+		return
+	}
+
+	webhook, err := h.WebhookService.RotateSecret(userID, webhookID)
+	if err != nil {
+		respondWithWebhookError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Webhook secret rotated successfully",
+		"webhook": webhook,
+	})
+}
+
+// ListDeliveries returns the webhook's delivery log, optionally filtered by status
+func (h *WebhookHandler) ListDeliveries(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	webhookID, ok := parseUUIDParam(ctx, "webhookId", "webhook ID")
+	if !ok {
+		return
+	}
+
+	var query models.WebhookDeliveryQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+
+	deliveries, err := h.WebhookService.ListDeliveries(userID, webhookID, query)
+	if err != nil {
+		respondWithWebhookError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"deliveries": deliveries})
+}
+
+// Redeliver queues a delivery's event to be sent again
+func (h *WebhookHandler) Redeliver(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	webhookID, ok := parseUUIDParam(ctx, "webhookId", "webhook ID")
+	if !ok {
+		return
+	}
+	deliveryID, ok := parseUUIDParam(ctx, "deliveryId", "delivery ID")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.WebhookService.Redeliver(userID, webhookID, deliveryID)
+	if err != nil {
+		respondWithWebhookError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{
+		"message":  "Delivery queued",
+		"delivery": delivery,
+	})
+}
@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"TaskManagmentApis/internal/models"
+	service "TaskManagmentApis/internal/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WorkspaceHandler struct {
+	WorkspaceService service.WorkspaceService
+}
+
+func NewWorkspaceHandler(workspaceService service.WorkspaceService) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		WorkspaceService: workspaceService,
+	}
+}
+
+// getWorkspaceIDFromContext reads the active workspace from the token claims set by the auth middleware.
+// Tokens issued before workspaces existed carry none and have to be refreshed.
+func getWorkspaceIDFromContext(ctx *gin.Context) (uuid.UUID, bool) {
+	workspaceID, err := uuid.Parse(ctx.GetString("workspace_id"))
+	if err != nil {
+		respondWithError(ctx, http.StatusUnauthorized, "Token has no active workspace, please refresh it")
+		return uuid.Nil, false
+	}
+	return workspaceID, true
+}
+
+// respondWithWorkspaceError maps service errors to HTTP responses
+func respondWithWorkspaceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrWorkspaceNotFound), errors.Is(err, service.ErrWorkspaceMemberNotFound):
+		respondWithError(ctx, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrWorkspaceForbidden):
+		respondWithError(ctx, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrWorkspaceMemberExists), errors.Is(err, service.ErrLastWorkspaceOwner):
+		respondWithError(ctx, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrInvalidWorkspaceInput):
+		respondWithError(ctx, http.StatusBadRequest, err.Error())
+	default:
+		respondWithError(ctx, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// CreateWorkspace handles workspace creation
+func (h *WorkspaceHandler) CreateWorkspace(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var input models.CreateWorkspaceRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	workspace, err := h.WorkspaceService.CreateWorkspace(userID, input)
+	if err != nil {
+		respondWithWorkspaceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message":   "Workspace created successfully",
+		"workspace": workspace,
+	})
+}
+
+// ListWorkspaces returns the workspaces the user belongs to and their role in each
+func (h *WorkspaceHandler) ListWorkspaces(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	memberships, err := h.WorkspaceService.ListWorkspaces(userID)
+	if err != nil {
+		respondWithWorkspaceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"workspaces":          memberships,
+		"active_workspace_id": ctx.GetString("workspace_id"),
+	})
+}
+
+// GetWorkspace returns a workspace together with the user's role in it
+func (h *WorkspaceHandler) GetWorkspace(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := parseUUIDParam(ctx, "workspaceId", "workspace ID")
+	if !ok {
+		return
+	}
+
+	member, err := h.WorkspaceService.GetWorkspace(userID, workspaceID)
+	if err != nil {
+		respondWithWorkspaceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"workspace": member.Workspace,
+		"role":      member.Role,
+	})
+}
+
+// UpdateWorkspace renames a workspace
+func (h *WorkspaceHandler) UpdateWorkspace(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := parseUUIDParam(ctx, "workspaceId", "workspace ID")
+	if !ok {
+		return
+	}
+
+	var input models.UpdateWorkspaceRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	workspace, err := h.WorkspaceService.UpdateWorkspace(userID, workspaceID, input)
+	if err != nil {
+		respondWithWorkspaceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message":   "Workspace updated successfully",
+		"workspace": workspace,
+	})
+}
+
+// DeleteWorkspace deletes a workspace and everything in it
+func (h *WorkspaceHandler) DeleteWorkspace(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := parseUUIDParam(ctx, "workspaceId", "workspace ID")
+	if !ok {
+		return
+	}
+
+	if err := h.WorkspaceService.DeleteWorkspace(userID, workspaceID); err != nil {
+		respondWithWorkspaceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Workspace deleted successfully"})
+}
+
+// ListMembers returns the members of a workspace
+func (h *WorkspaceHandler) ListMembers(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := parseUUIDParam(ctx, "workspaceId", "workspace ID")
+	if !ok {
+		return
+	}
+
+	members, err := h.WorkspaceService.ListMembers(userID, workspaceID)
+	if err != nil {
+		respondWithWorkspaceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"members": members})
+}
+
+// AddMember adds an existing user to a workspace
+func (h *WorkspaceHandler) AddMember(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := parseUUIDParam(ctx, "workspaceId", "workspace ID")
+	if !ok {
+		return
+	}
+
+	var input models.AddWorkspaceMemberRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	member, err := h.WorkspaceService.AddMember(userID, workspaceID, input)
+	if err != nil {
+		respondWithWorkspaceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message": "Member added successfully",
+		"member":  member,
+	})
+}
+
+// UpdateMember changes the role of a workspace member
+func (h *WorkspaceHandler) UpdateMember(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := parseUUIDParam(ctx, "workspaceId", "workspace ID")
+	if !ok {
+		return
+	}
+	memberID, ok := parseUUIDParam(ctx, "userId", "user ID")
+	if !ok {
+		return
+	}
+
+	var input models.UpdateWorkspaceMemberRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		respondWithError(ctx, http.StatusBadRequest, "Invalid input")
+		return
+	}
+
+	member, err := h.WorkspaceService.UpdateMemberRole(userID, workspaceID, memberID, input)
+	if err != nil {
+		respondWithWorkspaceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Member updated successfully",
+		"member":  member,
+	})
+}
+
+// RemoveMember removes a member from a workspace, or lets the user leave it
+func (h *WorkspaceHandler) RemoveMember(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := parseUUIDParam(ctx, "workspaceId", "workspace ID")
+	if !ok {
+		return
+	}
+	memberID, ok := parseUUIDParam(ctx, "userId", "user ID")
+	if !ok {
+		return
+	}
+
+	if err := h.WorkspaceService.RemoveMember(userID, workspaceID, memberID); err != nil {
+		respondWithWorkspaceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Member removed successfully"})
+}
+
+// SwitchWorkspace makes a workspace active and returns an access token scoped to it
+func (h *WorkspaceHandler) SwitchWorkspace(ctx *gin.Context) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return
+	}
+	workspaceID, ok := parseUUIDParam(ctx, "workspaceId", "workspace ID")
+	if !ok {
+		return
+	}
+
+	result, err := h.WorkspaceService.SwitchWorkspace(userID, workspaceID)
+	if err != nil {
+		respondWithWorkspaceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
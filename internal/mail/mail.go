@@ -0,0 +1,75 @@
+package mail
+
+import (
+	config "TaskManagmentApis/configs"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+)
+
+// Message is a single email with a plain-text body and an optional HTML alternative
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Mailer delivers email. An error wrapped as permanent (see IsPermanent) will not succeed on a
+// retry, e.g. a mailbox the server refused; any other error may be temporary.
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks err as one that retrying will not fix
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked by Permanent
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+// LogMailer writes messages to the log instead of sending them, for development
+type LogMailer struct{}
+
+func (LogMailer) Send(ctx context.Context, msg *Message) error {
+	log.Printf("📧 Mail to %v from %s: %s\n%s", msg.To, msg.From, msg.Subject, msg.Text)
+	return nil
+}
+
+// NewFromConfig builds the mailer selected by MAIL_BACKEND
+func NewFromConfig() (Mailer, error) {
+	cfg := config.Config
+	switch cfg.MailBackend {
+	case "log":
+		return LogMailer{}, nil
+	case "smtp":
+		return NewSMTPMailer(SMTPOptions{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			Security: cfg.SMTPSecurity,
+			Timeout:  DefaultTimeout,
+		})
+	default:
+		return nil, fmt.Errorf("unknown mail backend %q", cfg.MailBackend)
+	}
+}
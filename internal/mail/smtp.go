@@ -0,0 +1,251 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Connection security for SMTPOptions.Security
+const (
+	SMTPSecurityNone     = "none"
+	SMTPSecurityStartTLS = "starttls"
+	SMTPSecurityTLS      = "tls"
+)
+
+// DefaultTimeout is the SMTPOptions.Timeout used when none is set
+const DefaultTimeout = 30 * time.Second
+
+// SMTPOptions configures an SMTP relay such as a provider's submission port or a local fake server
+type SMTPOptions struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// Security is none, starttls (upgrade a plain connection, usually port 587) or tls (port 465)
+	Security string
+	Timeout  time.Duration
+	// TLSConfig overrides the default verification of the server certificate against Host
+	TLSConfig *tls.Config
+}
+
+// SMTPMailer sends each message over its own SMTP connection
+type SMTPMailer struct {
+	opts SMTPOptions
+}
+
+func NewSMTPMailer(opts SMTPOptions) (*SMTPMailer, error) {
+	if opts.Host == "" || opts.Port <= 0 {
+		return nil, errors.New("smtp mailer needs a host and port")
+	}
+	switch opts.Security {
+	case SMTPSecurityNone, SMTPSecurityStartTLS, SMTPSecurityTLS:
+	default:
+		return nil, fmt.Errorf("invalid smtp security %q", opts.Security)
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	return &SMTPMailer{opts: opts}, nil
+}
+
+func (m *SMTPMailer) tlsConfig() *tls.Config {
+	if m.opts.TLSConfig != nil {
+		return m.opts.TLSConfig
+	}
+	return &tls.Config{ServerName: m.opts.Host}
+}
+
+// Send delivers msg. Replies in the 5xx range are permanent failures; everything else, from
+// connection errors to 4xx replies, may succeed later.
+func (m *SMTPMailer) Send(ctx context.Context, msg *Message) error {
+	from, err := netmail.ParseAddress(msg.From)
+	if err != nil {
+		return Permanent(fmt.Errorf("invalid sender %q: %v", msg.From, err))
+	}
+	if len(msg.To) == 0 {
+		return Permanent(errors.New("message has no recipients"))
+	}
+	recipients := make([]*netmail.Address, len(msg.To))
+	for i, to := range msg.To {
+		if recipients[i], err = netmail.ParseAddress(to); err != nil {
+			return Permanent(fmt.Errorf("invalid recipient %q: %v", to, err))
+		}
+	}
+	body, err := buildMessage(from, recipients, msg)
+	if err != nil {
+		return Permanent(err)
+	}
+
+	client, err := m.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.Mail(from.Address); err != nil {
+		return classifySMTPError(err)
+	}
+	for _, to := range recipients {
+		if err := client.Rcpt(to.Address); err != nil {
+			return classifySMTPError(err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return classifySMTPError(err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return classifySMTPError(err)
+	}
+	// the message is accepted once DATA is; a failed QUIT does not change that
+	_ = client.Quit()
+	return nil
+}
+
+// dial connects, secures the connection and authenticates
+func (m *SMTPMailer) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(m.opts.Host, strconv.Itoa(m.opts.Port))
+	dialer := &net.Dialer{Timeout: m.opts.Timeout}
+
+	var conn net.Conn
+	var err error
+	if m.opts.Security == SMTPSecurityTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: m.tlsConfig()}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	deadline := time.Now().Add(m.opts.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, m.opts.Host)
+	if err != nil {
+		conn.Close()
+		return nil, classifySMTPError(err)
+	}
+	if m.opts.Security == SMTPSecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, fmt.Errorf("%s does not offer STARTTLS", addr)
+		}
+		if err := client.StartTLS(m.tlsConfig()); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+	if m.opts.Username != "" {
+		// PlainAuth itself refuses to send the password over an unencrypted connection to another host
+		if err := client.Auth(smtp.PlainAuth("", m.opts.Username, m.opts.Password, m.opts.Host)); err != nil {
+			client.Close()
+			return nil, classifySMTPError(err)
+		}
+	}
+	return client, nil
+}
+
+func classifySMTPError(err error) error {
+	var reply *textproto.Error
+	if errors.As(err, &reply) && reply.Code >= 500 {
+		return Permanent(err)
+	}
+	return err
+}
+
+// buildMessage renders msg as a MIME message, multipart/alternative when it has an HTML body
+func buildMessage(from *netmail.Address, to []*netmail.Address, msg *Message) ([]byte, error) {
+	var buf bytes.Buffer
+	header := func(name, value string) {
+		buf.WriteString(name + ": " + value + "\r\n")
+	}
+
+	recipients := make([]string, len(to))
+	for i, address := range to {
+		recipients[i] = address.String()
+	}
+	// line breaks in a subject would start new headers
+	subject := strings.Join(strings.Fields(msg.Subject), " ")
+
+	header("From", from.String())
+	header("To", strings.Join(recipients, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", messageID(from.Address))
+	header("MIME-Version", "1.0")
+
+	if msg.HTML == "" {
+		header("Content-Type", "text/plain; charset=utf-8")
+		header("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, msg.Text); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&buf)
+	header("Content-Type", `multipart/alternative; boundary="`+parts.Boundary()+`"`)
+	buf.WriteString("\r\n")
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, text string) error {
+	qp := quotedprintable.NewWriter(w)
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\n", "\r\n")
+	if _, err := qp.Write([]byte(text)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// messageID makes a unique Message-ID in the sender's domain
+func messageID(sender string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(sender, "@"); at >= 0 {
+		domain = sender[at+1:]
+	}
+	random := make([]byte, 16)
+	rand.Read(random)
+	return "<" + hex.EncodeToString(random) + "@" + domain + ">"
+}
@@ -0,0 +1,101 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Email templates, each a pair of <name>.txt and <name>.html files. Both define a "subject"
+// template; the HTML one defines "content", which templates/layout.html wraps.
+const (
+	TemplateTaskReminder         = "task_reminder"
+	TemplateWorkspaceMemberAdded = "workspace_member_added"
+)
+
+// TaskReminderData fills TemplateTaskReminder
+type TaskReminderData struct {
+	UserName  string
+	TaskTitle string
+	// DueDate is already formatted in the recipient's time zone
+	DueDate string
+	Overdue bool
+	TaskURL string
+}
+
+// WorkspaceMemberAddedData fills TemplateWorkspaceMemberAdded
+type WorkspaceMemberAddedData struct {
+	UserName      string
+	ActorName     string
+	WorkspaceName string
+	Role          string
+	WorkspaceURL  string
+}
+
+//go:embed templates
+var templateFS embed.FS
+
+// Templates renders emails from the embedded templates
+type Templates struct {
+	text map[string]*texttemplate.Template
+	html map[string]*htmltemplate.Template
+}
+
+// LoadTemplates parses every template pair; appName and baseURL are available to them as functions
+func LoadTemplates(appName, baseURL string) (*Templates, error) {
+	funcs := map[string]interface{}{
+		"appName": func() string { return appName },
+		"baseURL": func() string { return baseURL },
+	}
+
+	names, err := fs.Glob(templateFS, "templates/*.txt")
+	if err != nil {
+		return nil, err
+	}
+	t := &Templates{
+		text: make(map[string]*texttemplate.Template, len(names)),
+		html: make(map[string]*htmltemplate.Template, len(names)),
+	}
+	for _, path := range names {
+		name := strings.TrimSuffix(strings.TrimPrefix(path, "templates/"), ".txt")
+		text, err := texttemplate.New(name+".txt").Funcs(funcs).ParseFS(templateFS, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		html, err := htmltemplate.New("layout.html").Funcs(funcs).ParseFS(templateFS, "templates/layout.html", "templates/"+name+".html")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s.html: %w", name, err)
+		}
+		t.text[name], t.html[name] = text, html
+	}
+	return t, nil
+}
+
+// Render fills in the subject and both bodies of a message from the named templates
+func (t *Templates) Render(name string, data interface{}) (*Message, error) {
+	text, ok := t.text[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", name)
+	}
+
+	var subject, textBody, htmlBody bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render subject of %s: %w", name, err)
+	}
+	if err := text.Execute(&textBody, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s.txt: %w", name, err)
+	}
+	if err := t.html[name].ExecuteTemplate(&htmlBody, "layout", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s.html: %w", name, err)
+	}
+
+	return &Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    textBody.String(),
+		HTML:    htmlBody.String(),
+	}, nil
+}
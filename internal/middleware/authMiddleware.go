@@ -41,11 +41,40 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// Set values in context
-		ctx.Set("claims", claims)
-		ctx.Set("user_id", claims.UserID) // Corrected key from user_idad to user_id
-		ctx.Set("email", claims.Email)
+		setClaims(ctx, claims)
+		ctx.Next()
+	}
+}
+
+// setClaims puts the token's claims in the context for the handlers
+func setClaims(ctx *gin.Context, claims *utils.Claims) {
+	ctx.Set("claims", claims)
+	ctx.Set("user_id", claims.UserID) // Corrected key from user_idad to user_id
+	ctx.Set("email", claims.Email)
+	ctx.Set("workspace_id", claims.WorkspaceID)
+	ctx.Set("role", claims.Role)
+}
+
+// StreamAuthMiddleware authenticates the event stream with a stream token in the token query
+// parameter, which browsers' EventSource can send, and otherwise like AuthMiddleware
+func StreamAuthMiddleware() gin.HandlerFunc {
+	authenticate := AuthMiddleware()
+	return func(ctx *gin.Context) {
+		tokenString := ctx.Query("token")
+		if tokenString == "" {
+			authenticate(ctx)
+			return
+		}
+
+		claims, err := utils.ValidateStreamToken(tokenString)
+		if err != nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired stream token"})
+			log.Printf("Stream token validation failed: %v", err)
+			ctx.Abort()
+			return
+		}
 
+		setClaims(ctx, claims)
 		ctx.Next()
 	}
 }
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"TaskManagmentApis/internal/rbac"
+	"TaskManagmentApis/pkg/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission only lets requests through whose token role grants every given permission.
+// It must run after AuthMiddleware; services check the stored role again, so a role change
+// takes effect before old tokens expire.
+func RequirePermission(permissions ...rbac.Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		value, exists := ctx.Get("claims")
+		claims, ok := value.(*utils.Claims)
+		if !exists || !ok {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			ctx.Abort()
+			return
+		}
+
+		if !rbac.HasPermission(claims.Role, permissions...) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to perform this action"})
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Attachment is a file uploaded to a task. Files with the same content share one stored
+// object, addressed by their SHA-256 checksum.
+type Attachment struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	TaskID      uuid.UUID `gorm:"type:uuid;not null;index" json:"task_id"`
+	UploaderID  uuid.UUID `gorm:"type:uuid;not null" json:"uploader_id"`
+	FileName    string    `gorm:"size:255;not null" json:"file_name"`
+	ContentType string    `gorm:"size:100;not null" json:"content_type"`
+	Size        int64     `gorm:"not null" json:"size"`
+	Checksum    string    `gorm:"size:64;not null;index" json:"checksum"`
+	StorageKey  string    `gorm:"size:255;not null" json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	Task Task `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (a *Attachment) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
+
+// AttachmentDownload is a short-lived URL that downloads an attachment without a bearer token
+type AttachmentDownload struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit actions, named <entity>.<event>
+const (
+	AuditActionRegister           = "auth.register"
+	AuditActionLogin              = "auth.login"
+	AuditActionLoginFailed        = "auth.login_failed"
+	AuditActionLogout             = "auth.logout"
+	AuditActionTokenRefresh       = "auth.token_refresh"
+	AuditActionTokenRefreshFailed = "auth.token_refresh_failed"
+	AuditActionRoleChange         = "user.role_change"
+	AuditActionUserDelete         = "user.delete"
+	AuditActionUserRestore        = "user.restore"
+	AuditActionTaskCreate         = "task.create"
+	AuditActionTaskUpdate         = "task.update"
+	AuditActionTaskDelete         = "task.delete"
+	AuditActionTaskRestore        = "task.restore"
+	AuditActionTaskPurge          = "task.purge"
+	AuditActionTaskMove           = "task.move"
+	AuditActionTaskDependencyAdd  = "task.dependency_add"
+	AuditActionTaskDependencyDrop = "task.dependency_remove"
+	AuditActionTaskAssignees      = "task.assignees_change"
+	AuditActionTaskProjectChange  = "task.project_change"
+	AuditActionTaskTags           = "task.tags_change"
+	AuditActionTaskAttachmentAdd  = "task.attachment_add"
+	AuditActionTaskAttachmentDrop = "task.attachment_delete"
+)
+
+// Entity types audit events can point at
+const (
+	AuditEntityUser = "user"
+	AuditEntityTask = "task"
+)
+
+// JSONMap is a JSON object stored in a jsonb column
+type JSONMap map[string]interface{}
+
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (m *JSONMap) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into JSONMap", value)
+	}
+	return json.Unmarshal(data, m)
+}
+
+// RequestMeta describes where a request came from, for the audit log
+type RequestMeta struct {
+	IP        string
+	UserAgent string
+}
+
+// AuditEvent is one row of the append-only audit log. Before and After hold only the fields
+// that changed for updates, the full record for creates and deletes.
+type AuditEvent struct {
+	ID         int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ActorID    *uuid.UUID `gorm:"type:uuid" json:"actor_id,omitempty"`
+	Action     string     `gorm:"size:64;not null" json:"action"`
+	EntityType string     `gorm:"size:32" json:"entity_type,omitempty"`
+	EntityID   string     `gorm:"size:64" json:"entity_id,omitempty"`
+	IP         string     `gorm:"size:64" json:"ip,omitempty"`
+	UserAgent  string     `gorm:"type:text" json:"user_agent,omitempty"`
+	Before     JSONMap    `gorm:"type:jsonb" json:"before,omitempty"`
+	After      JSONMap    `gorm:"type:jsonb" json:"after,omitempty"`
+	Metadata   JSONMap    `gorm:"type:jsonb" json:"metadata,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// AuditQuery filters the audit log; Cursor is the ID of the last event of the previous page
+type AuditQuery struct {
+	ActorID    string     `form:"actor_id"`
+	Action     []string   `form:"action"`
+	EntityType string     `form:"entity_type"`
+	EntityID   string     `form:"entity_id"`
+	From       *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Cursor     int64      `form:"cursor"`
+	Limit      int        `form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+// AuditPage is one page of audit events, newest first
+type AuditPage struct {
+	Events     []AuditEvent `json:"events"`
+	NextCursor int64        `json:"next_cursor,omitempty"`
+}
@@ -10,3 +10,7 @@ type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
 }
+
+type UpdateTimezoneRequest struct {
+	Timezone string `json:"timezone" binding:"required,max=64"`
+}
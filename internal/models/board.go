@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Board is a kanban board of one workspace; its columns map to task statuses
+type Board struct {
+	ID          uuid.UUID     `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	OwnerID     uuid.UUID     `gorm:"type:uuid;not null;index" json:"owner_id"`
+	WorkspaceID uuid.UUID     `gorm:"type:uuid;not null;index" json:"workspace_id"`
+	ProjectID   *uuid.UUID    `gorm:"type:uuid;index" json:"project_id,omitempty"`
+	Name        string        `gorm:"size:100;not null" json:"name"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	Columns     []BoardColumn `gorm:"foreignKey:BoardID" json:"columns,omitempty"`
+
+	Owner User `gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (b *Board) BeforeCreate(tx *gorm.DB) (err error) {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return
+}
+
+// BoardColumn is a column of a board; cards moved into it take its status
+type BoardColumn struct {
+	ID        uuid.UUID   `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	BoardID   uuid.UUID   `gorm:"type:uuid;not null;index" json:"board_id"`
+	Name      string      `gorm:"size:100;not null" json:"name"`
+	Status    string      `gorm:"size:20;not null" json:"status"`
+	Position  int         `gorm:"not null" json:"position"`
+	CreatedAt time.Time   `json:"created_at"`
+	Cards     []BoardCard `gorm:"foreignKey:ColumnID" json:"cards,omitempty"`
+}
+
+func (c *BoardColumn) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
+
+// BoardCard places a task in a board column. Rank is a lexicographic key; cards sort by it.
+type BoardCard struct {
+	BoardID  uuid.UUID `gorm:"type:uuid;primaryKey" json:"board_id"`
+	TaskID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"task_id"`
+	ColumnID uuid.UUID `gorm:"type:uuid;not null;index" json:"column_id"`
+	Rank     string    `gorm:"size:64;not null" json:"rank"`
+	Task     *Task     `gorm:"foreignKey:TaskID" json:"task,omitempty"`
+}
+
+type BoardColumnRequest struct {
+	Name   string `json:"name" binding:"required,min=1,max=100"`
+	Status string `json:"status" binding:"required,max=20"`
+}
+
+// CreateBoardRequest creates a board; without columns it gets To do / In progress / Done
+type CreateBoardRequest struct {
+	Name      string               `json:"name" binding:"required,min=1,max=100"`
+	ProjectID *uuid.UUID           `json:"project_id"`
+	Columns   []BoardColumnRequest `json:"columns" binding:"omitempty,max=20,dive"`
+}
+
+type UpdateBoardRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+// UpdateBoardColumnRequest only changes the fields that are present in the body
+type UpdateBoardColumnRequest struct {
+	Name     *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Status   *string `json:"status" binding:"omitempty,max=20"`
+	Position *int    `json:"position" binding:"omitempty,min=0"`
+}
+
+type AddCardRequest struct {
+	TaskID   uuid.UUID  `json:"task_id" binding:"required"`
+	ColumnID *uuid.UUID `json:"column_id"`
+}
+
+// MoveCardRequest places a card in a column, after AfterTaskID and/or before BeforeTaskID.
+// With neither neighbour the card goes to the end of the column.
+type MoveCardRequest struct {
+	ColumnID     uuid.UUID  `json:"column_id" binding:"required"`
+	AfterTaskID  *uuid.UUID `json:"after_task_id"`
+	BeforeTaskID *uuid.UUID `json:"before_task_id"`
+	Reason       string     `json:"reason" binding:"omitempty,max=1000"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of calendar entries a feed renders tasks as
+const (
+	CalendarEntryEvent = "event"
+	CalendarEntryTodo  = "todo"
+)
+
+// CalendarFeed is a user's secret iCalendar subscription. The token itself is only shown
+// when it is generated; TokenHash is its SHA-256.
+type CalendarFeed struct {
+	UserID         uuid.UUID  `gorm:"type:uuid;primaryKey" json:"-"`
+	TokenHash      string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+
+	User User `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// CalendarFeedQuery holds the query string parameters of GET /calendar/{token}.ics
+type CalendarFeedQuery struct {
+	Type string `form:"type" binding:"omitempty,oneof=event todo"`
+}
+
+// CalendarFeedToken is returned once, right after a feed token is generated
+type CalendarFeedToken struct {
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Comment is a message on a task; replies point at their parent comment
+type Comment struct {
+	ID        uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	TaskID    uuid.UUID      `gorm:"type:uuid;not null;index" json:"task_id"`
+	ParentID  *uuid.UUID     `gorm:"type:uuid;index" json:"parent_id,omitempty"`
+	AuthorID  uuid.UUID      `gorm:"type:uuid;not null" json:"author_id"`
+	Body      string         `gorm:"type:text;not null" json:"body"`
+	EditedAt  *time.Time     `json:"edited_at,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	Task     Task       `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	Author   *User      `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+	Mentions []User     `gorm:"many2many:comment_mentions;joinForeignKey:CommentID;joinReferences:UserID" json:"mentions,omitempty"`
+	Replies  []*Comment `gorm:"-" json:"replies,omitempty"`
+}
+
+func (c *Comment) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
+
+// CommentRevision keeps the body a comment had before an edit
+type CommentRevision struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	CommentID uuid.UUID `gorm:"type:uuid;not null;index" json:"comment_id"`
+	EditorID  uuid.UUID `gorm:"type:uuid;not null" json:"editor_id"`
+	Body      string    `gorm:"type:text;not null" json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (r *CommentRevision) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
+
+type CreateCommentRequest struct {
+	Body     string     `json:"body" binding:"required,max=10000"`
+	ParentID *uuid.UUID `json:"parent_id"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" binding:"required,max=10000"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	EmailStatusPending = "pending"
+	EmailStatusSent    = "sent"
+	EmailStatusFailed  = "failed"
+)
+
+// OutboundEmail is a rendered email waiting in the outbox, or the record of one that was sent or
+// given up on. Pending emails are sent once NextAttemptAt has passed.
+type OutboundEmail struct {
+	ID            uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Recipient     string     `gorm:"size:255;not null" json:"recipient"`
+	Template      string     `gorm:"size:50;not null" json:"template"`
+	Subject       string     `gorm:"size:255;not null" json:"subject"`
+	TextBody      string     `gorm:"not null" json:"-"`
+	HTMLBody      string     `gorm:"not null" json:"-"`
+	Status        string     `gorm:"size:20;not null;default:pending" json:"status"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"not null" json:"next_attempt_at"`
+	LastError     string     `json:"last_error,omitempty"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func (e *OutboundEmail) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return
+}
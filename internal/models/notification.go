@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	NotificationTypeMention    = "comment.mention"
+	NotificationTypeAssigned   = "task.assigned"
+	NotificationTypeUnassigned = "task.unassigned"
+)
+
+// Notification tells a user that someone else did something that concerns them
+type Notification struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	ActorID   uuid.UUID  `gorm:"type:uuid;not null" json:"actor_id"`
+	Type      string     `gorm:"size:50;not null" json:"type"`
+	TaskID    *uuid.UUID `gorm:"type:uuid" json:"task_id,omitempty"`
+	CommentID *uuid.UUID `gorm:"type:uuid" json:"comment_id,omitempty"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (n *Notification) BeforeCreate(tx *gorm.DB) (err error) {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return
+}
+
+type NotificationListQuery struct {
+	UnreadOnly bool `form:"unread"`
+	Limit      int  `form:"limit" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Project groups tasks of one workspace; it is owned by the user who created it
+type Project struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	OwnerID     uuid.UUID `gorm:"type:uuid;not null;index" json:"owner_id"`
+	WorkspaceID uuid.UUID `gorm:"type:uuid;not null;index" json:"workspace_id"`
+	Name        string    `gorm:"size:100;not null" json:"name"`
+	Description string    `gorm:"type:text" json:"description,omitempty"`
+	Color       string    `gorm:"size:7;not null;default:'#808080'" json:"color"`
+	Archived    bool      `gorm:"not null;default:false" json:"archived"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	Owner User `gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (p *Project) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return
+}
+
+type CreateProjectRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=100"`
+	Description string `json:"description"`
+	Color       string `json:"color" binding:"omitempty,hexcolor,len=7"`
+}
+
+// UpdateProjectRequest only changes the fields that are present in the body
+type UpdateProjectRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Description *string `json:"description"`
+	Color       *string `json:"color" binding:"omitempty,hexcolor,len=7"`
+	Archived    *bool   `json:"archived"`
+}
+
+// MoveTasksRequest moves tasks into a project, or out of any project when ProjectID is null
+type MoveTasksRequest struct {
+	ProjectID *uuid.UUID  `json:"project_id"`
+	TaskIDs   []uuid.UUID `json:"task_ids" binding:"required,min=1,max=500"`
+}
+
+// ProjectSummary counts a project's tasks by status
+type ProjectSummary struct {
+	ProjectID uuid.UUID        `json:"project_id"`
+	Total     int64            `json:"total"`
+	ByStatus  map[string]int64 `json:"by_status"`
+}
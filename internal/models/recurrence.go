@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecurrenceSeries is the template every occurrence of a recurring task is generated from
+type RecurrenceSeries struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Rule        string    `gorm:"size:255;not null" json:"rule"`
+	Timezone    string    `gorm:"size:64;not null;default:UTC" json:"timezone"`
+	StartAt     time.Time `gorm:"not null" json:"start_at"`
+	Title       string    `gorm:"size:255;not null" json:"title"`
+	Description string    `gorm:"type:text" json:"description,omitempty"`
+	Priority    string    `gorm:"size:20;default:medium" json:"priority"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	User User `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (RecurrenceSeries) TableName() string {
+	return "recurrence_series"
+}
+
+func (r *RecurrenceSeries) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
+
+// RecurrencePreviewQuery holds the query string parameters accepted by GET /tasks/recurrence/preview
+type RecurrencePreviewQuery struct {
+	Rule  string     `form:"rule" binding:"required"`
+	Start *time.Time `form:"start" time_format:"2006-01-02T15:04:05Z07:00"`
+	Count int        `form:"count" binding:"omitempty,min=1,max=100"`
+}
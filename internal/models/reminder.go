@@ -0,0 +1,106 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NotificationTypeDueSoon = "task.due_soon"
+	NotificationTypeOverdue = "task.overdue"
+)
+
+// MaxReminderOffsetMinutes bounds reminder offsets to a week either side of the due date
+const MaxReminderOffsetMinutes = 7 * 24 * 60
+
+// ReminderOffsets are the minutes before a task's due date at which its owner and assignees are
+// reminded; zero and negative offsets remind when, or that long after, the task became overdue.
+// They are stored as a comma separated list.
+type ReminderOffsets []int
+
+// Value stores the offsets as "1440,60"
+func (o ReminderOffsets) Value() (driver.Value, error) {
+	parts := make([]string, len(o))
+	for i, offset := range o {
+		parts[i] = strconv.Itoa(offset)
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// Scan reads offsets written by Value; NULL is an empty list
+func (o *ReminderOffsets) Scan(src interface{}) error {
+	var text string
+	switch src := src.(type) {
+	case nil:
+		*o = ReminderOffsets{}
+		return nil
+	case string:
+		text = src
+	case []byte:
+		text = string(src)
+	default:
+		return fmt.Errorf("cannot scan %T into ReminderOffsets", src)
+	}
+
+	offsets := ReminderOffsets{}
+	for _, part := range strings.Split(text, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		offset, err := strconv.Atoi(part)
+		if err != nil {
+			return fmt.Errorf("invalid reminder offset %q", part)
+		}
+		offsets = append(offsets, offset)
+	}
+	*o = offsets
+	return nil
+}
+
+// Normalize sorts the offsets from earliest to latest reminder and drops duplicates
+func (o ReminderOffsets) Normalize() ReminderOffsets {
+	sorted := append(ReminderOffsets{}, o...)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+	normalized := ReminderOffsets{}
+	for i, offset := range sorted {
+		if i == 0 || offset != sorted[i-1] {
+			normalized = append(normalized, offset)
+		}
+	}
+	return normalized
+}
+
+// TaskReminder records that a reminder for a task, recipient, offset and due date was due.
+// Notified is false for reminders that were passed over for a later one in the same run.
+type TaskReminder struct {
+	TaskID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"task_id"`
+	UserID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	OffsetMinutes int       `gorm:"primaryKey" json:"offset_minutes"`
+	DueDate       time.Time `gorm:"primaryKey" json:"due_date"`
+	Notified      bool      `gorm:"not null" json:"notified"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ReminderCandidate is an open task with a due date near enough to remind one of its recipients,
+// together with the recipient's offsets and the offsets already handled for this due date
+type ReminderCandidate struct {
+	TaskID          uuid.UUID
+	TaskTitle       string
+	UserID          uuid.UUID
+	UserName        string
+	UserEmail       string
+	UserTimezone    string
+	DueDate         time.Time
+	ReminderOffsets ReminderOffsets
+	SentOffsets     ReminderOffsets
+}
+
+type UpdateReminderOffsetsRequest struct {
+	Offsets []int `json:"offsets_minutes" binding:"required,max=10,dive,min=-10080,max=10080"`
+}
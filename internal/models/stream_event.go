@@ -0,0 +1,36 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// Events pushed to connected clients on GET /events
+const (
+	StreamEventTaskCreated = "task.created"
+	StreamEventTaskUpdated = "task.updated"
+	StreamEventTaskDeleted = "task.deleted"
+	// StreamEventReset tells a resuming client that events were lost and it should reload its data
+	StreamEventReset = "reset"
+)
+
+// StreamEvent is one event for the live event streams of its recipients. ID is the ID of the audit
+// event it came from, so it is the same on every server and orders events for Last-Event-ID resumes;
+// it is 0 when the audit event could not be stored, and such events cannot be resumed.
+type StreamEvent struct {
+	ID         int64           `json:"id"`
+	Type       string          `json:"type"`
+	Recipients []uuid.UUID     `json:"recipients"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// IsFor reports whether userID is one of the event's recipients
+func (e *StreamEvent) IsFor(userID uuid.UUID) bool {
+	for _, recipient := range e.Recipients {
+		if recipient == userID {
+			return true
+		}
+	}
+	return false
+}
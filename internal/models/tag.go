@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Tag is a per-user label that can be attached to many tasks
+type Tag struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name      string    `gorm:"size:50;not null" json:"name"`
+	Color     string    `gorm:"size:7;not null;default:'#808080'" json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	User User `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (t *Tag) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return
+}
+
+type CreateTagRequest struct {
+	Name  string `json:"name" binding:"required,min=1,max=50"`
+	Color string `json:"color" binding:"omitempty,hexcolor,len=7"`
+}
+
+// UpdateTagRequest renames and/or recolors a tag
+type UpdateTagRequest struct {
+	Name  *string `json:"name" binding:"omitempty,min=1,max=50"`
+	Color *string `json:"color" binding:"omitempty,hexcolor,len=7"`
+}
+
+type MergeTagRequest struct {
+	TargetID uuid.UUID `json:"target_id" binding:"required"`
+}
+
+// UpdateTaskTagsRequest attaches and detaches tags on a task in one atomic change
+type UpdateTaskTagsRequest struct {
+	Attach []uuid.UUID `json:"attach"`
+	Detach []uuid.UUID `json:"detach"`
+}
@@ -7,18 +7,86 @@ import (
 	"gorm.io/gorm"
 )
 
+// Task statuses, enforced by the chk_tasks_status constraint
+const (
+	TaskStatusPending    = "pending"
+	TaskStatusInProgress = "in_progress"
+	TaskStatusDone       = "done"
+	TaskStatusReopened   = "reopened"
+	TaskStatusCancelled  = "cancelled"
+)
+
+// Task priorities, enforced by the chk_tasks_priority constraint
+const (
+	TaskPriorityLow    = "low"
+	TaskPriorityMedium = "medium"
+	TaskPriorityHigh   = "high"
+	TaskPriorityUrgent = "urgent"
+)
+
+var TaskStatuses = []string{TaskStatusPending, TaskStatusInProgress, TaskStatusDone, TaskStatusReopened, TaskStatusCancelled}
+
+// ClosedTaskStatuses are the terminal statuses: a closed task no longer blocks or waits on anything
+var ClosedTaskStatuses = []string{TaskStatusDone, TaskStatusCancelled}
+
+var TaskPriorities = []string{TaskPriorityLow, TaskPriorityMedium, TaskPriorityHigh, TaskPriorityUrgent}
+
+func IsValidTaskStatus(status string) bool {
+	for _, s := range TaskStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func IsValidTaskPriority(priority string) bool {
+	for _, p := range TaskPriorities {
+		if p == priority {
+			return true
+		}
+	}
+	return false
+}
+
+// Task is always serialized with deleted_at, which stays null until the task is moved to the trash
 type Task struct {
-	ID          uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
-	Title       string     `gorm:"size:255;not null" json:"title"`
-	Description string     `gorm:"type:text" json:"description,omitempty"`
-	Status      string     `gorm:"size:20;default:pending" json:"status"`
-	Priority    string     `gorm:"size:20;default:medium" json:"priority"`
-	DueDate     *time.Time `json:"due_date,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-
-	User User `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	ID              uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID          uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	WorkspaceID     uuid.UUID      `gorm:"type:uuid;not null;index" json:"workspace_id"`
+	ParentID        *uuid.UUID     `gorm:"type:uuid;index" json:"parent_id,omitempty"`
+	ProjectID       *uuid.UUID     `gorm:"type:uuid;index" json:"project_id,omitempty"`
+	ExternalID      *string        `gorm:"size:255" json:"external_id,omitempty"`
+	Title           string         `gorm:"size:255;not null" json:"title"`
+	Description     string         `gorm:"type:text" json:"description,omitempty"`
+	Status          string         `gorm:"size:20;default:pending" json:"status"`
+	Priority        string         `gorm:"size:20;default:medium" json:"priority"`
+	DueDate         *time.Time     `json:"due_date,omitempty"`
+	SeriesID        *uuid.UUID     `gorm:"type:uuid;index" json:"series_id,omitempty"`
+	OccurrenceIndex int            `gorm:"not null;default:0" json:"occurrence_index,omitempty"`
+	Version         int            `gorm:"not null;default:1" json:"version"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+
+	User      User           `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	Tags      []Tag          `gorm:"many2many:task_tags" json:"tags,omitempty"`
+	Assignees []TaskAssignee `gorm:"foreignKey:TaskID" json:"assignees,omitempty"`
+}
+
+// PriorityRank orders priorities by importance, unknown values rank lowest
+func PriorityRank(priority string) int {
+	switch priority {
+	case TaskPriorityUrgent:
+		return 4
+	case TaskPriorityHigh:
+		return 3
+	case TaskPriorityMedium:
+		return 2
+	case TaskPriorityLow:
+		return 1
+	}
+	return 0
 }
 
 func (t *Task) BeforeCreate(tx *gorm.DB) (err error) {
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	AssignmentActionAssigned   = "assigned"
+	AssignmentActionUnassigned = "unassigned"
+)
+
+// TaskAssignee links a task to a user who works on it besides the owner
+type TaskAssignee struct {
+	TaskID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"-"`
+	UserID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	AssignedBy uuid.UUID `gorm:"type:uuid;not null" json:"assigned_by"`
+	AssignedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP" json:"assigned_at"`
+
+	User *User `json:"user,omitempty"`
+}
+
+// TaskAssignmentEvent records one assignment or unassignment
+type TaskAssignmentEvent struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	TaskID    uuid.UUID `gorm:"type:uuid;not null;index" json:"task_id"`
+	ActorID   uuid.UUID `gorm:"type:uuid;not null" json:"actor_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null" json:"user_id"`
+	Action    string    `gorm:"size:20;not null" json:"action"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (e *TaskAssignmentEvent) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return
+}
+
+// AssignTaskRequest adds assignees to a task
+type AssignTaskRequest struct {
+	UserIDs []uuid.UUID `json:"user_ids" binding:"required,min=1,max=50"`
+}
+
+// ReplaceAssigneesRequest sets the complete list of assignees; an empty list unassigns everyone
+type ReplaceAssigneesRequest struct {
+	UserIDs []uuid.UUID `json:"user_ids" binding:"max=50"`
+}
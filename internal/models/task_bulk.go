@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Operations accepted by POST /tasks/bulk
+const (
+	BulkOpSetStatus   = "set_status"
+	BulkOpSetPriority = "set_priority"
+	BulkOpAddTags     = "add_tags"
+	BulkOpRemoveTags  = "remove_tags"
+	BulkOpDelete      = "delete"
+)
+
+// Bulk modes: atomic applies all changes or none, best_effort applies every change that succeeds
+const (
+	BulkModeAtomic     = "atomic"
+	BulkModeBestEffort = "best_effort"
+)
+
+// BulkTaskFilter selects tasks with the same criteria as the GET /tasks query parameters
+type BulkTaskFilter struct {
+	Status      []string   `json:"status"`
+	Priority    []string   `json:"priority"`
+	DueFrom     *time.Time `json:"due_from"`
+	DueTo       *time.Time `json:"due_to"`
+	CreatedFrom *time.Time `json:"created_from"`
+	CreatedTo   *time.Time `json:"created_to"`
+	UpdatedFrom *time.Time `json:"updated_from"`
+	UpdatedTo   *time.Time `json:"updated_to"`
+	ProjectID   string     `json:"project_id"`
+	Tags        []string   `json:"tags"`
+	TagMode     string     `json:"tag_mode" binding:"omitempty,oneof=all any"`
+}
+
+// BulkTaskRequest applies one operation to the tasks named in TaskIDs or, when no IDs are given,
+// to the tasks of the active workspace that match Filter. Which of the remaining fields are
+// used depends on the operation.
+type BulkTaskRequest struct {
+	TaskIDs      []uuid.UUID     `json:"task_ids"`
+	Filter       *BulkTaskFilter `json:"filter"`
+	Operation    string          `json:"operation" binding:"required,oneof=set_status set_priority add_tags remove_tags delete"`
+	Mode         string          `json:"mode" binding:"omitempty,oneof=atomic best_effort"`
+	Status       string          `json:"status" binding:"omitempty,max=20"`
+	StatusReason string          `json:"status_reason" binding:"omitempty,max=1000"`
+	Priority     string          `json:"priority" binding:"omitempty,max=20"`
+	TagIDs       []uuid.UUID     `json:"tag_ids"`
+	Policy       string          `json:"policy" binding:"omitempty,oneof=cascade reparent"`
+}
+
+// BulkTaskItemResult is the outcome of a bulk operation for one task
+type BulkTaskItemResult struct {
+	TaskID  uuid.UUID `json:"task_id"`
+	Success bool      `json:"success"`
+	Version int       `json:"version,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// BulkTaskResult reports a bulk operation item by item. Applied is false when an atomic
+// operation was rolled back, in which case no task was changed.
+type BulkTaskResult struct {
+	Operation string               `json:"operation"`
+	Mode      string               `json:"mode"`
+	Applied   bool                 `json:"applied"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	Results   []BulkTaskItemResult `json:"results"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskDependency records that BlockerID must be done before TaskID can be completed
+type TaskDependency struct {
+	TaskID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"task_id"`
+	BlockerID uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"blocker_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Task    Task `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"-"`
+	Blocker Task `gorm:"foreignKey:BlockerID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type AddDependencyRequest struct {
+	BlockerID uuid.UUID `json:"blocker_id" binding:"required"`
+}
+
+// TaskDependencies lists the tasks blocking a task and the tasks it blocks
+type TaskDependencies struct {
+	BlockedBy []Task `json:"blocked_by"`
+	Blocks    []Task `json:"blocks"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// TaskListQuery holds the query string parameters accepted by GET /tasks
+type TaskListQuery struct {
+	Status      []string   `form:"status"`
+	Priority    []string   `form:"priority"`
+	DueFrom     *time.Time `form:"due_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	DueTo       *time.Time `form:"due_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedFrom *time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   *time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	UpdatedFrom *time.Time `form:"updated_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	UpdatedTo   *time.Time `form:"updated_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	ProjectID   string     `form:"project_id"`
+	Tags        []string   `form:"tags"`
+	TagMode     string     `form:"tag_mode" binding:"omitempty,oneof=all any"`
+	Sort        string     `form:"sort"`
+	Cursor      string     `form:"cursor"`
+	Limit       int        `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// TaskListResult is the paginated envelope returned by GET /tasks
+type TaskListResult struct {
+	Tasks      []Task `json:"tasks"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	TotalCount int64  `json:"total_count"`
+}
+
+// TaskSearchQuery holds the query string parameters accepted by GET /tasks/search
+type TaskSearchQuery struct {
+	Q     string `form:"q" binding:"required"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// TaskSearchHit is a single ranked full-text search result
+type TaskSearchHit struct {
+	Task               Task    `json:"task"`
+	Rank               float64 `json:"rank"`
+	TitleHighlight     string  `json:"title_highlight"`
+	DescriptionSnippet string  `json:"description_snippet,omitempty"`
+}
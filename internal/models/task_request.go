@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateTaskRequest creates a task. When RecurrenceRule (an RFC 5545 RRULE) is set,
+// DueDate is the first occurrence and is required.
+type CreateTaskRequest struct {
+	Title          string     `json:"title" binding:"required,min=1,max=255"`
+	Description    string     `json:"description"`
+	Status         string     `json:"status" binding:"omitempty,max=20"`
+	Priority       string     `json:"priority" binding:"omitempty,max=20"`
+	DueDate        *time.Time `json:"due_date"`
+	ParentID       *uuid.UUID `json:"parent_id"`
+	ProjectID      *uuid.UUID `json:"project_id"`
+	RecurrenceRule string     `json:"recurrence_rule" binding:"omitempty,max=255"`
+}
+
+// UpdateTaskRequest only changes the fields that are present in the body.
+// For recurring tasks Scope is "this" (default) to edit only this occurrence
+// or "future" to also change every occurrence generated from now on.
+type UpdateTaskRequest struct {
+	Title          *string    `json:"title" binding:"omitempty,min=1,max=255"`
+	Description    *string    `json:"description"`
+	Status         *string    `json:"status" binding:"omitempty,max=20"`
+	StatusReason   string     `json:"status_reason" binding:"omitempty,max=1000"`
+	Priority       *string    `json:"priority" binding:"omitempty,max=20"`
+	DueDate        *time.Time `json:"due_date"`
+	ClearDue       bool       `json:"clear_due_date"`
+	Scope          string     `json:"scope" binding:"omitempty,oneof=this future"`
+	RecurrenceRule *string    `json:"recurrence_rule" binding:"omitempty,max=255"`
+}
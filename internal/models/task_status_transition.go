@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TaskStatusTransition is one recorded change of Task.Status
+type TaskStatusTransition struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	TaskID     uuid.UUID `gorm:"type:uuid;not null;index" json:"task_id"`
+	ActorID    uuid.UUID `gorm:"type:uuid;not null" json:"actor_id"`
+	FromStatus string    `gorm:"size:20;not null" json:"from_status"`
+	ToStatus   string    `gorm:"size:20;not null" json:"to_status"`
+	Reason     string    `gorm:"type:text" json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	Task Task `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (t *TaskStatusTransition) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Formats accepted by task import and export
+const (
+	TaskFormatCSV    = "csv"
+	TaskFormatJSON   = "json"
+	TaskFormatNDJSON = "ndjson"
+)
+
+// TaskRecordFields are the export columns in order. In CSV, tags are joined with ";".
+var TaskRecordFields = []string{
+	"id", "external_id", "title", "description", "status", "priority",
+	"due_date", "project_id", "parent_id", "tags", "created_at", "updated_at",
+}
+
+// TaskImportFields are the fields an import can set; every other column is ignored
+var TaskImportFields = []string{"external_id", "title", "description", "status", "priority", "due_date", "project_id", "tags"}
+
+// TaskRecord is a task as written by GET /tasks/export
+type TaskRecord struct {
+	ID          uuid.UUID  `json:"id"`
+	ExternalID  string     `json:"external_id,omitempty"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	Status      string     `json:"status"`
+	Priority    string     `json:"priority"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	ProjectID   *uuid.UUID `json:"project_id,omitempty"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
+	Tags        []string   `json:"tags"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TaskExportQuery holds the query string parameters accepted by GET /tasks/export: the GET /tasks
+// filters plus the output format. Sorting and paging parameters are ignored.
+type TaskExportQuery struct {
+	TaskListQuery
+	Format string `form:"format" binding:"omitempty,oneof=csv json ndjson"`
+}
+
+// TaskImportQuery holds the query string parameters accepted by POST /tasks/import. Each Mapping
+// entry reads a field from a differently named column, e.g. map=title=Summary.
+type TaskImportQuery struct {
+	Format  string   `form:"format" binding:"omitempty,oneof=csv json ndjson"`
+	DryRun  bool     `form:"dry_run"`
+	Mapping []string `form:"map"`
+}
+
+// Outcomes of one import row. In a dry run "created" means the row would be created.
+const (
+	TaskImportCreated = "created"
+	TaskImportExists  = "exists"
+	TaskImportFailed  = "failed"
+)
+
+// TaskImportRow reports what happened to one row; Row counts data rows from 1
+type TaskImportRow struct {
+	Row        int        `json:"row"`
+	ExternalID string     `json:"external_id,omitempty"`
+	Action     string     `json:"action"`
+	TaskID     *uuid.UUID `json:"task_id,omitempty"`
+	Errors     []string   `json:"errors,omitempty"`
+}
+
+// TaskImportReport is the response of POST /tasks/import
+type TaskImportReport struct {
+	Format   string          `json:"format"`
+	DryRun   bool            `json:"dry_run"`
+	Total    int             `json:"total"`
+	Created  int             `json:"created"`
+	Existing int             `json:"existing"`
+	Failed   int             `json:"failed"`
+	Rows     []TaskImportRow `json:"rows"`
+}
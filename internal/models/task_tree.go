@@ -0,0 +1,16 @@
+package models
+
+import "github.com/google/uuid"
+
+// TaskTreeNode is a task together with its nested subtasks
+type TaskTreeNode struct {
+	Task
+	Depth           int             `json:"depth"`
+	PercentComplete float64         `json:"percent_complete"`
+	Children        []*TaskTreeNode `json:"children"`
+}
+
+// MoveTaskRequest moves a task (and its subtree) under a new parent, or to the top level when ParentID is null
+type MoveTaskRequest struct {
+	ParentID *uuid.UUID `json:"parent_id"`
+}
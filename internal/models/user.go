@@ -7,17 +7,23 @@ import (
 	"gorm.io/gorm"
 )
 
+// User is always serialized with deleted_at, which stays null until the account is deleted
 type User struct {
-	ID            uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	Name          string         `gorm:"size:100;not null" json:"name"`
-	Email         string         `gorm:"size:100;not null;uniqueIndex" json:"email"`
-	PasswordHash  string         `gorm:"not null" json:"-"`
-	IsVerified    bool           `gorm:"default:false" json:"is_verified"`
-	Role          string         `gorm:"size:20;default:user" json:"role"`
-	Tasks         []Task         `gorm:"foreignKey:UserID" json:"tasks,omitempty"`
-	RefreshTokens []RefreshToken `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
+	ID                uuid.UUID       `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Name              string          `gorm:"size:100;not null" json:"name"`
+	Email             string          `gorm:"size:100;not null;uniqueIndex:idx_users_email_active,where:deleted_at IS NULL" json:"email"`
+	PasswordHash      string          `gorm:"not null" json:"-"`
+	IsVerified        bool            `gorm:"default:false" json:"is_verified"`
+	Role              string          `gorm:"size:20;not null;default:user" json:"role"`
+	Timezone          string          `gorm:"size:64;not null;default:UTC" json:"timezone"`
+	ReminderOffsets   ReminderOffsets `gorm:"size:100;not null;default:1440,60" json:"reminder_offsets"`
+	ActiveWorkspaceID *uuid.UUID      `gorm:"type:uuid" json:"active_workspace_id,omitempty"`
+	Version           int             `gorm:"not null;default:1" json:"version"`
+	Tasks             []Task          `gorm:"foreignKey:UserID" json:"tasks,omitempty"`
+	RefreshTokens     []RefreshToken  `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt  `gorm:"index" json:"deleted_at"`
 }
 
 func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
@@ -26,3 +32,14 @@ func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
 	}
 	return
 }
+
+// UserListQuery filters and pages the admin user list
+type UserListQuery struct {
+	Role   string `form:"role"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
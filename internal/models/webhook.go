@@ -0,0 +1,168 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Events a webhook can subscribe to
+const (
+	WebhookEventTaskCreated   = "task.created"
+	WebhookEventTaskUpdated   = "task.updated"
+	WebhookEventTaskCompleted = "task.completed"
+	WebhookEventTaskDeleted   = "task.deleted"
+	WebhookEventTaskRestored  = "task.restored"
+	WebhookEventUserLogin     = "user.login"
+	WebhookEventUserLogout    = "user.logout"
+)
+
+var WebhookEventTypes = []string{
+	WebhookEventTaskCreated, WebhookEventTaskUpdated, WebhookEventTaskCompleted,
+	WebhookEventTaskDeleted, WebhookEventTaskRestored, WebhookEventUserLogin, WebhookEventUserLogout,
+}
+
+// IsValidWebhookEvent reports whether event is one webhooks can subscribe to
+func IsValidWebhookEvent(event string) bool {
+	for _, e := range WebhookEventTypes {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// StringList is a list of strings without commas, stored as a comma separated list
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	return strings.Join(l, ","), nil
+}
+
+func (l *StringList) Scan(src interface{}) error {
+	var text string
+	switch src := src.(type) {
+	case nil:
+		*l = StringList{}
+		return nil
+	case string:
+		text = src
+	case []byte:
+		text = string(src)
+	default:
+		return fmt.Errorf("cannot scan %T into StringList", src)
+	}
+	list := StringList{}
+	for _, item := range strings.Split(text, ",") {
+		if item != "" {
+			list = append(list, item)
+		}
+	}
+	*l = list
+	return nil
+}
+
+// Has reports whether the list contains value
+func (l StringList) Has(value string) bool {
+	for _, item := range l {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook is an HTTP endpoint a user registered to receive events about their tasks and account.
+// After repeated failed deliveries its circuit opens and deliveries wait until CircuitOpenUntil.
+type Webhook struct {
+	ID                  uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID              uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	URL                 string     `gorm:"size:2048;not null" json:"url"`
+	Description         string     `gorm:"size:255" json:"description,omitempty"`
+	Events              StringList `gorm:"type:text;not null" json:"events"`
+	Secret              string     `gorm:"size:128;not null" json:"-"`
+	Active              bool       `gorm:"not null;default:true" json:"active"`
+	ConsecutiveFailures int        `gorm:"not null;default:0" json:"consecutive_failures"`
+	CircuitOpenUntil    *time.Time `json:"circuit_open_until,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+func (w *Webhook) BeforeCreate(tx *gorm.DB) (err error) {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return
+}
+
+// WebhookDelivery is one event queued for, or delivered to, a webhook. A redelivery is a new
+// delivery of the same event, so receivers can deduplicate by EventID. Only the response status is
+// kept: storing the body would let a webhook read back whatever its URL returns.
+type WebhookDelivery struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	WebhookID      uuid.UUID  `gorm:"type:uuid;not null" json:"webhook_id"`
+	EventID        uuid.UUID  `gorm:"type:uuid;not null" json:"event_id"`
+	EventType      string     `gorm:"size:50;not null" json:"event_type"`
+	Payload        string     `gorm:"type:text;not null" json:"payload"`
+	Status         string     `gorm:"size:20;not null;default:pending" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"not null" json:"next_attempt_at"`
+	ResponseStatus *int       `json:"response_status,omitempty"`
+	LastError      string     `gorm:"type:text" json:"last_error,omitempty"`
+	DurationMs     *int       `json:"duration_ms,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Webhook        *Webhook   `gorm:"foreignKey:WebhookID" json:"-"`
+}
+
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) (err error) {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return
+}
+
+// WebhookEvent is the JSON body POSTed to webhooks
+type WebhookEvent struct {
+	ID         uuid.UUID              `json:"id"`
+	Type       string                 `json:"type"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	ActorID    *uuid.UUID             `json:"actor_id,omitempty"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" binding:"required,url,max=2048"`
+	Description string   `json:"description" binding:"max=255"`
+	Events      []string `json:"events" binding:"required,min=1"`
+}
+
+// UpdateWebhookRequest changes only the fields that are sent
+type UpdateWebhookRequest struct {
+	URL         *string   `json:"url" binding:"omitempty,url,max=2048"`
+	Description *string   `json:"description" binding:"omitempty,max=255"`
+	Events      *[]string `json:"events" binding:"omitempty,min=1"`
+	Active      *bool     `json:"active"`
+}
+
+// WebhookWithSecret is returned when a webhook is created or its secret rotated, the only
+// times the signing secret is shown
+type WebhookWithSecret struct {
+	*Webhook
+	Secret string `json:"secret"`
+}
+
+type WebhookDeliveryQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending succeeded failed"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Roles a user can have in a workspace, from most to least privileged
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleAdmin  = "admin"
+	WorkspaceRoleMember = "member"
+	WorkspaceRoleGuest  = "guest"
+)
+
+// WorkspaceRoleRank orders workspace roles by privilege, unknown values rank lowest
+func WorkspaceRoleRank(role string) int {
+	switch role {
+	case WorkspaceRoleOwner:
+		return 4
+	case WorkspaceRoleAdmin:
+		return 3
+	case WorkspaceRoleMember:
+		return 2
+	case WorkspaceRoleGuest:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Workspace is the tenant that tasks and projects belong to
+type Workspace struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Name      string     `gorm:"size:100;not null" json:"name"`
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (w *Workspace) BeforeCreate(tx *gorm.DB) (err error) {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return
+}
+
+// WorkspaceMember gives a user a role in a workspace
+type WorkspaceMember struct {
+	WorkspaceID uuid.UUID `gorm:"type:uuid;primaryKey" json:"workspace_id"`
+	UserID      uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Role        string    `gorm:"size:20;not null" json:"role"`
+	JoinedAt    time.Time `gorm:"not null;default:CURRENT_TIMESTAMP" json:"joined_at"`
+
+	User      *User      `json:"user,omitempty"`
+	Workspace *Workspace `json:"workspace,omitempty"`
+}
+
+type CreateWorkspaceRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+type UpdateWorkspaceRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+// AddWorkspaceMemberRequest invites an existing user by email
+type AddWorkspaceMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=owner admin member guest"`
+}
+
+type UpdateWorkspaceMemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner admin member guest"`
+}
+
+// WorkspaceSwitchResult carries the access token issued for the newly active workspace
+type WorkspaceSwitchResult struct {
+	AccessToken string    `json:"access_token"`
+	Workspace   Workspace `json:"workspace"`
+	Role        string    `json:"role"`
+}
@@ -0,0 +1,63 @@
+// Package rbac defines the system-wide user roles and the permissions each of them grants.
+// It is the single place to look up who may do what; the gin middleware and the services
+// both check against it.
+package rbac
+
+// Roles stored in users.role
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Permission names an action that is not open to every user
+type Permission string
+
+const (
+	PermissionUsersRead   Permission = "users:read"
+	PermissionUsersManage Permission = "users:manage"
+	PermissionAuditRead   Permission = "audit:read"
+)
+
+// rolePermissions maps each role to the permissions it grants
+var rolePermissions = map[string][]Permission{
+	RoleUser: {},
+	RoleAdmin: {
+		PermissionUsersRead,
+		PermissionUsersManage,
+		PermissionAuditRead,
+	},
+}
+
+// Roles lists the known roles
+func Roles() []string {
+	return []string{RoleUser, RoleAdmin}
+}
+
+// IsValidRole reports whether role is one of the known roles
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// Permissions returns the permissions granted to role; unknown roles get none
+func Permissions(role string) []Permission {
+	return append([]Permission{}, rolePermissions[role]...)
+}
+
+// HasPermission reports whether role grants every one of the given permissions
+func HasPermission(role string, permissions ...Permission) bool {
+	granted := rolePermissions[role]
+	for _, permission := range permissions {
+		found := false
+		for _, g := range granted {
+			if g == permission {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
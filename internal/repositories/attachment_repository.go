@@ -0,0 +1,172 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AttachmentRepository defines the data access methods for task attachments. Stored objects
+// are shared between attachments with the same checksum, so creating and deleting rows is
+// serialized per checksum and the callbacks that write or remove the object run under that lock.
+// The audit callbacks build the event stored in the same transaction as the change.
+type AttachmentRepository interface {
+	CreateAttachment(attachment *models.Attachment, ensureObject func() error, audit func(*models.Attachment) *models.AuditEvent) (*models.Attachment, bool, error)
+	GetAttachment(attachmentID, taskID uuid.UUID) (*models.Attachment, error)
+	ListAttachments(taskID uuid.UUID) ([]models.Attachment, error)
+	DeleteAttachment(attachmentID, taskID uuid.UUID, removeObject func(storageKey string) error, audit func(*models.Attachment) *models.AuditEvent) (bool, error)
+	RemoveUnusedObjects(objects []models.Attachment, removeObject func(storageKey string) error) error
+}
+
+type AttachmentRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &AttachmentRepositoryImpl{
+		DB: db,
+	}
+}
+
+func lockChecksum(tx *gorm.DB, checksum string) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "attachment:"+checksum).Error
+}
+
+// attachmentObjects returns the distinct stored objects of the attachments matching the condition.
+// Purges call it before deleting rows that take their attachments along through the cascade.
+func attachmentObjects(tx *gorm.DB, query string, args ...interface{}) ([]models.Attachment, error) {
+	var objects []models.Attachment
+	err := tx.Model(&models.Attachment{}).Distinct("checksum", "storage_key").Where(query, args...).Find(&objects).Error
+	return objects, err
+}
+
+// createAttachmentEvent stores the audit event for an attachment change, if any
+func createAttachmentEvent(tx *gorm.DB, attachment *models.Attachment, audit func(*models.Attachment) *models.AuditEvent) error {
+	if event := audit(attachment); event != nil {
+		return tx.Create(event).Error
+	}
+	return nil
+}
+
+// CreateAttachment stores the attachment unless the task already has one with the same content,
+// in which case that one is returned and the boolean is false
+func (repo *AttachmentRepositoryImpl) CreateAttachment(attachment *models.Attachment, ensureObject func() error, audit func(*models.Attachment) *models.AuditEvent) (*models.Attachment, bool, error) {
+	created := true
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockChecksum(tx, attachment.Checksum); err != nil {
+			return err
+		}
+
+		var existing models.Attachment
+		err := tx.Where("task_id = ? AND checksum = ?", attachment.TaskID, attachment.Checksum).First(&existing).Error
+		if err == nil {
+			*attachment = existing
+			created = false
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if err := ensureObject(); err != nil {
+			return err
+		}
+		if err := tx.Omit("Task").Create(attachment).Error; err != nil {
+			return err
+		}
+		return createAttachmentEvent(tx, attachment, audit)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return attachment, created, nil
+}
+
+// GetAttachment returns the attachment only if it belongs to the given task
+func (repo *AttachmentRepositoryImpl) GetAttachment(attachmentID, taskID uuid.UUID) (*models.Attachment, error) {
+	var attachment models.Attachment
+	if err := repo.DB.Where("id = ? AND task_id = ?", attachmentID, taskID).First(&attachment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// ListAttachments returns a task's attachments, newest first
+func (repo *AttachmentRepositoryImpl) ListAttachments(taskID uuid.UUID) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	if err := repo.DB.Where("task_id = ?", taskID).Order("created_at DESC, id").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// DeleteAttachment removes the row and, when no other attachment shares its content, the stored object
+func (repo *AttachmentRepositoryImpl) DeleteAttachment(attachmentID, taskID uuid.UUID, removeObject func(storageKey string) error, audit func(*models.Attachment) *models.AuditEvent) (bool, error) {
+	deleted := false
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		var attachment models.Attachment
+		if err := tx.Where("id = ? AND task_id = ?", attachmentID, taskID).First(&attachment).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		if err := lockChecksum(tx, attachment.Checksum); err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", attachment.ID).Delete(&models.Attachment{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		deleted = true
+		if err := createAttachmentEvent(tx, &attachment, audit); err != nil {
+			return err
+		}
+
+		var remaining int64
+		if err := tx.Model(&models.Attachment{}).Where("checksum = ?", attachment.Checksum).Count(&remaining).Error; err != nil {
+			return err
+		}
+		if remaining == 0 {
+			return removeObject(attachment.StorageKey)
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+// RemoveUnusedObjects removes each of the stored objects that no attachment references any more.
+// Every object is checked under its checksum lock, so an upload of the same content either finds
+// the row still there or writes the object again. It goes on past failures and returns them all.
+func (repo *AttachmentRepositoryImpl) RemoveUnusedObjects(objects []models.Attachment, removeObject func(storageKey string) error) error {
+	var errs []error
+	for _, object := range objects {
+		err := repo.DB.Transaction(func(tx *gorm.DB) error {
+			if err := lockChecksum(tx, object.Checksum); err != nil {
+				return err
+			}
+			var remaining int64
+			if err := tx.Model(&models.Attachment{}).Where("checksum = ?", object.Checksum).Count(&remaining).Error; err != nil {
+				return err
+			}
+			if remaining == 0 {
+				return removeObject(object.StorageKey)
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", object.StorageKey, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditFilter selects audit events; zero values are ignored
+type AuditFilter struct {
+	ActorID    *uuid.UUID
+	Actions    []string
+	EntityType string
+	EntityID   string
+	From       *time.Time
+	To         *time.Time
+	BeforeID   int64
+	Limit      int
+}
+
+// AuditRepository defines the data access methods for the audit log. There is deliberately
+// no update or delete: the table is append-only and a trigger rejects both.
+type AuditRepository interface {
+	CreateEvent(event *models.AuditEvent) error
+	ListEvents(filter AuditFilter) ([]models.AuditEvent, error)
+	ExportEvents(filter AuditFilter, fn func(*models.AuditEvent) error) error
+	LatestEventID() (int64, error)
+}
+
+type AuditRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &AuditRepositoryImpl{
+		DB: db,
+	}
+}
+
+// CreateEvent appends an event to the audit log
+func (repo *AuditRepositoryImpl) CreateEvent(event *models.AuditEvent) error {
+	return repo.DB.Create(event).Error
+}
+
+// LatestEventID returns the ID of the newest audit event, 0 when the log is empty
+func (repo *AuditRepositoryImpl) LatestEventID() (int64, error) {
+	var id int64
+	err := repo.DB.Model(&models.AuditEvent{}).Select("COALESCE(MAX(id), 0)").Scan(&id).Error
+	return id, err
+}
+
+// applyAuditFilter adds the WHERE clauses shared by listing and exporting
+func applyAuditFilter(query *gorm.DB, f AuditFilter) *gorm.DB {
+	if f.ActorID != nil {
+		query = query.Where("actor_id = ?", *f.ActorID)
+	}
+	if len(f.Actions) > 0 {
+		query = query.Where("action IN ?", f.Actions)
+	}
+	if f.EntityType != "" {
+		query = query.Where("entity_type = ?", f.EntityType)
+	}
+	if f.EntityID != "" {
+		query = query.Where("entity_id = ?", f.EntityID)
+	}
+	if f.From != nil {
+		query = query.Where("created_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		query = query.Where("created_at < ?", *f.To)
+	}
+	return query
+}
+
+// ListEvents returns up to Limit events older than BeforeID, newest first
+func (repo *AuditRepositoryImpl) ListEvents(filter AuditFilter) ([]models.AuditEvent, error) {
+	query := applyAuditFilter(repo.DB.Model(&models.AuditEvent{}), filter)
+	if filter.BeforeID > 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+
+	var events []models.AuditEvent
+	if err := query.Order("id DESC").Limit(filter.Limit).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// ExportEvents streams every matching event to fn, oldest first, without loading them all into memory
+func (repo *AuditRepositoryImpl) ExportEvents(filter AuditFilter, fn func(*models.AuditEvent) error) error {
+	rows, err := applyAuditFilter(repo.DB.Model(&models.AuditEvent{}), filter).Order("id").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event models.AuditEvent
+		if err := repo.DB.ScanRows(rows, &event); err != nil {
+			return err
+		}
+		if err := fn(&event); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
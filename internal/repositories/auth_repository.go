@@ -13,11 +13,17 @@ import (
 type AuthRepository interface {
 	CreateUser(user *models.User) (*models.User, error)
 	GetUserByEmail(email string) (*models.User, error)
+	GetUserByID(userID uuid.UUID) (*models.User, error)
 	UpdateUser(user *models.User) (*models.User, error)
-	DeleteUser(user *models.User) (*models.User, error)
+	DeleteUser(userID uuid.UUID, version int) (bool, error)
 	SaveRefreshToken(userID uuid.UUID, refreshToken string, expiresAt time.Time) (*models.RefreshToken, error)
 	GetRefreshTokenByToken(refreshToken string) (*models.RefreshToken, error)
 	DeleteRefreshToken(userID uuid.UUID) error
+	ListUsers(role string, limit, offset int) ([]models.User, int64, error)
+	UpdateUserRole(userID uuid.UUID, version int, role string) (bool, error)
+	ListDeletedUsers() ([]models.User, error)
+	RestoreUser(userID uuid.UUID) (bool, error)
+	PurgeDeletedUsers(before time.Time) (int64, []models.Attachment, error)
 }
 
 type AuthRepositoryImpl struct {
@@ -49,22 +55,69 @@ func (repo *AuthRepositoryImpl) GetUserByEmail(email string) (*models.User, erro
 	return &user, nil
 }
 
-// Updateuser
-func (repo *AuthRepositoryImpl) UpdateUser(user *models.User) (*models.User, error) {
-	if err := repo.DB.Save(user).Error; err != nil {
+// GetUserByID
+func (repo *AuthRepositoryImpl) GetUserByID(userID uuid.UUID) (*models.User, error) {
+	var user models.User
+	if err := repo.DB.Where("id = ?", userID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
 		return nil, err
 	}
-	return user, nil
+	return &user, nil
 }
 
-// Deleteuser
-func (repo *AuthRepositoryImpl) DeleteUser(user *models.User) (*models.User, error) {
-	if err := repo.DB.Delete(user).Error; err != nil {
-		return nil, err
+// UpdateUser saves the user's profile fields if the user is still at the version it was read at.
+// Unlike Save it never writes the role or active workspace, which have their own conditional updates.
+// It returns nil when the user is gone and ErrVersionConflict when someone else changed it first.
+func (repo *AuthRepositoryImpl) UpdateUser(user *models.User) (*models.User, error) {
+	result := repo.DB.Model(&models.User{}).
+		Where("id = ? AND version = ?", user.ID, user.Version).
+		Updates(map[string]interface{}{
+			"name":             user.Name,
+			"email":            user.Email,
+			"password_hash":    user.PasswordHash,
+			"is_verified":      user.IsVerified,
+			"timezone":         user.Timezone,
+			"reminder_offsets": user.ReminderOffsets,
+			"updated_at":       time.Now(),
+			"version":          bumpVersion,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, userVersionConflict(repo.DB, user.ID)
 	}
+	user.Version++
 	return user, nil
 }
 
+// DeleteUser moves the user to the trash and signs them out everywhere by dropping their refresh token.
+// A non-zero version must match the user's current one, otherwise ErrVersionConflict is returned.
+func (repo *AuthRepositoryImpl) DeleteUser(userID uuid.UUID, version int) (bool, error) {
+	deleted := false
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&models.User{}).Where("id = ?", userID)
+		if version != 0 {
+			query = query.Where("version = ?", version)
+		}
+		result := query.Updates(map[string]interface{}{"deleted_at": time.Now(), "version": bumpVersion})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return userVersionConflict(tx, userID)
+		}
+		deleted = true
+		return tx.Where("user_id = ?", userID).Delete(&models.RefreshToken{}).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return deleted, nil
+}
+
 // saveRefreshToken
 func (repo *AuthRepositoryImpl) SaveRefreshToken(userID uuid.UUID, refreshToken string, expiresAt time.Time) (*models.RefreshToken, error) {
 
@@ -0,0 +1,389 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/pkg/utils"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrCardNotInColumn is returned when a neighbour card of a move is not in the target column
+var ErrCardNotInColumn = errors.New("neighbour card is not in the target column")
+
+// ErrInvalidCardOrder is returned when the "after" card does not sort before the "before" card
+var ErrInvalidCardOrder = errors.New("after card must come before the before card")
+
+// ErrStatusChanged is returned when a task's status changed while its card was being moved
+var ErrStatusChanged = errors.New("task status changed concurrently")
+
+// CardPlacement describes where a card goes and the status change that comes with it
+type CardPlacement struct {
+	BoardID      uuid.UUID
+	TaskID       uuid.UUID
+	ColumnID     uuid.UUID
+	AfterTaskID  *uuid.UUID
+	BeforeTaskID *uuid.UUID
+	Transition   *models.TaskStatusTransition
+}
+
+// BoardRepository defines the data access methods for kanban boards
+type BoardRepository interface {
+	CreateBoard(board *models.Board) (*models.Board, error)
+	GetBoardByID(boardID, ownerID uuid.UUID) (*models.Board, error)
+	GetBoardWithCards(boardID, ownerID uuid.UUID) (*models.Board, error)
+	ListBoards(ownerID, workspaceID uuid.UUID) ([]models.Board, error)
+	UpdateBoard(board *models.Board) (*models.Board, error)
+	DeleteBoard(boardID, ownerID uuid.UUID) (bool, error)
+	CreateColumn(column *models.BoardColumn) (*models.BoardColumn, error)
+	GetColumn(columnID, boardID uuid.UUID) (*models.BoardColumn, error)
+	UpdateColumn(column *models.BoardColumn) (*models.BoardColumn, error)
+	DeleteColumn(columnID, boardID uuid.UUID) (bool, error)
+	CountColumnCards(columnID uuid.UUID) (int64, error)
+	GetCard(boardID, taskID uuid.UUID) (*models.BoardCard, error)
+	RemoveCard(boardID, taskID uuid.UUID) (bool, error)
+	PlaceCard(placement CardPlacement) (*models.BoardCard, error)
+	FindStatusPlacements(taskID uuid.UUID, status string) ([]CardPlacement, error)
+}
+
+// boardMemberScope limits board lookups to workspaces the user (bound to ?) is still a member of
+const boardMemberScope = "EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = boards.workspace_id AND wm.user_id = ?)"
+
+type BoardRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewBoardRepository(db *gorm.DB) BoardRepository {
+	return &BoardRepositoryImpl{
+		DB: db,
+	}
+}
+
+// CreateBoard stores a board together with its columns
+func (repo *BoardRepositoryImpl) CreateBoard(board *models.Board) (*models.Board, error) {
+	if err := repo.DB.Create(board).Error; err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// GetBoardByID returns the board only if the user owns it and is a member of its workspace
+func (repo *BoardRepositoryImpl) GetBoardByID(boardID, ownerID uuid.UUID) (*models.Board, error) {
+	var board models.Board
+	err := repo.DB.Where("id = ? AND owner_id = ?", boardID, ownerID).
+		Where(boardMemberScope, ownerID).
+		First(&board).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &board, nil
+}
+
+// GetBoardWithCards loads a board with its ordered columns and each column's ordered cards and tasks
+func (repo *BoardRepositoryImpl) GetBoardWithCards(boardID, ownerID uuid.UUID) (*models.Board, error) {
+	var board models.Board
+	err := repo.DB.
+		Preload("Columns", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position, created_at")
+		}).
+		Preload("Columns.Cards", func(db *gorm.DB) *gorm.DB {
+			// cards of trashed tasks stay in place so a restore puts them back where they were
+			return db.Where("EXISTS (SELECT 1 FROM tasks WHERE tasks.id = board_cards.task_id AND tasks.deleted_at IS NULL)").
+				Order("rank, task_id")
+		}).
+		Preload("Columns.Cards.Task").
+		Where("id = ? AND owner_id = ?", boardID, ownerID).
+		Where(boardMemberScope, ownerID).
+		First(&board).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &board, nil
+}
+
+// ListBoards returns the user's boards in a workspace without columns
+func (repo *BoardRepositoryImpl) ListBoards(ownerID, workspaceID uuid.UUID) ([]models.Board, error) {
+	var boards []models.Board
+	err := repo.DB.Where("owner_id = ? AND workspace_id = ?", ownerID, workspaceID).
+		Order("lower(name), id").
+		Find(&boards).Error
+	if err != nil {
+		return nil, err
+	}
+	return boards, nil
+}
+
+// UpdateBoard renames a board
+func (repo *BoardRepositoryImpl) UpdateBoard(board *models.Board) (*models.Board, error) {
+	err := repo.DB.Model(&models.Board{}).
+		Where("id = ? AND owner_id = ?", board.ID, board.OwnerID).
+		Select("name", "updated_at").
+		Updates(board).Error
+	if err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// DeleteBoard removes a board with its columns and cards; the tasks themselves are kept
+func (repo *BoardRepositoryImpl) DeleteBoard(boardID, ownerID uuid.UUID) (bool, error) {
+	result := repo.DB.Where("id = ? AND owner_id = ?", boardID, ownerID).Delete(&models.Board{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CreateColumn
+func (repo *BoardRepositoryImpl) CreateColumn(column *models.BoardColumn) (*models.BoardColumn, error) {
+	if err := repo.DB.Create(column).Error; err != nil {
+		return nil, err
+	}
+	return column, nil
+}
+
+// GetColumn returns a column of the given board
+func (repo *BoardRepositoryImpl) GetColumn(columnID, boardID uuid.UUID) (*models.BoardColumn, error) {
+	var column models.BoardColumn
+	if err := repo.DB.Where("id = ? AND board_id = ?", columnID, boardID).First(&column).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &column, nil
+}
+
+// UpdateColumn
+func (repo *BoardRepositoryImpl) UpdateColumn(column *models.BoardColumn) (*models.BoardColumn, error) {
+	err := repo.DB.Model(&models.BoardColumn{}).
+		Where("id = ? AND board_id = ?", column.ID, column.BoardID).
+		Select("name", "status", "position").
+		Updates(column).Error
+	if err != nil {
+		return nil, err
+	}
+	return column, nil
+}
+
+// DeleteColumn
+func (repo *BoardRepositoryImpl) DeleteColumn(columnID, boardID uuid.UUID) (bool, error) {
+	result := repo.DB.Where("id = ? AND board_id = ?", columnID, boardID).Delete(&models.BoardColumn{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CountColumnCards
+func (repo *BoardRepositoryImpl) CountColumnCards(columnID uuid.UUID) (int64, error) {
+	var count int64
+	err := repo.DB.Model(&models.BoardCard{}).Where("column_id = ?", columnID).Count(&count).Error
+	return count, err
+}
+
+// GetCard returns the card of a task on a board
+func (repo *BoardRepositoryImpl) GetCard(boardID, taskID uuid.UUID) (*models.BoardCard, error) {
+	var card models.BoardCard
+	if err := repo.DB.Where("board_id = ? AND task_id = ?", boardID, taskID).First(&card).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &card, nil
+}
+
+// RemoveCard takes a task off a board
+func (repo *BoardRepositoryImpl) RemoveCard(boardID, taskID uuid.UUID) (bool, error) {
+	result := repo.DB.Where("board_id = ? AND task_id = ?", boardID, taskID).Delete(&models.BoardCard{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// PlaceCard inserts or moves a card and applies its status change in one transaction.
+// Normally only the moved card is written; when its new rank key gets too long the whole
+// target column is rebalanced.
+func (repo *BoardRepositoryImpl) PlaceCard(placement CardPlacement) (*models.BoardCard, error) {
+	card := &models.BoardCard{BoardID: placement.BoardID, TaskID: placement.TaskID, ColumnID: placement.ColumnID}
+
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		// lock the target column so concurrent moves into it compute their ranks one at a time
+		var column models.BoardColumn
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND board_id = ?", placement.ColumnID, placement.BoardID).
+			First(&column).Error; err != nil {
+			return err
+		}
+
+		after, before, err := cardBounds(tx, placement)
+		if err != nil {
+			return err
+		}
+
+		rank, err := utils.RankBetween(after, before)
+		if err != nil {
+			return ErrInvalidCardOrder
+		}
+		if len(rank) > utils.MaxRankLength {
+			if rank, err = rebalanceColumn(tx, placement, after); err != nil {
+				return err
+			}
+		}
+		card.Rank = rank
+
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "board_id"}, {Name: "task_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"column_id", "rank"}),
+		}).Create(card).Error; err != nil {
+			return err
+		}
+
+		if placement.Transition != nil {
+			// only apply the status change if nobody changed the status in the meantime
+			result := tx.Model(&models.Task{}).
+				Where("id = ? AND status = ?", placement.TaskID, placement.Transition.FromStatus).
+				Updates(map[string]interface{}{"status": placement.Transition.ToStatus, "updated_at": time.Now(), "version": bumpVersion})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return ErrStatusChanged
+			}
+			if err := tx.Create(placement.Transition).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return card, nil
+}
+
+// FindStatusPlacements returns where the task's cards go after its status changed to status: on every
+// board where the card sits in a column of another status, the end of the board's first column
+// mapping to status. Boards without such a column are left out and keep the card where it is.
+func (repo *BoardRepositoryImpl) FindStatusPlacements(taskID uuid.UUID, status string) ([]CardPlacement, error) {
+	var rows []struct {
+		BoardID  uuid.UUID
+		ColumnID uuid.UUID
+	}
+	err := repo.DB.Raw(`
+		SELECT bc.board_id, target.id AS column_id
+		FROM board_cards bc
+		JOIN board_columns placed ON placed.id = bc.column_id
+		JOIN LATERAL (
+			SELECT c.id FROM board_columns c
+			WHERE c.board_id = bc.board_id AND c.status = ?
+			ORDER BY c.position, c.created_at
+			LIMIT 1
+		) target ON true
+		WHERE bc.task_id = ? AND placed.status <> ?`, status, taskID, status).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	placements := make([]CardPlacement, 0, len(rows))
+	for _, row := range rows {
+		placements = append(placements, CardPlacement{BoardID: row.BoardID, TaskID: taskID, ColumnID: row.ColumnID})
+	}
+	return placements, nil
+}
+
+// cardRank returns the rank of a neighbour card, which must be in the target column
+func cardRank(tx *gorm.DB, placement CardPlacement, taskID uuid.UUID) (string, error) {
+	if taskID == placement.TaskID {
+		return "", ErrInvalidCardOrder
+	}
+	var card models.BoardCard
+	err := tx.Where("board_id = ? AND task_id = ? AND column_id = ?", placement.BoardID, taskID, placement.ColumnID).
+		First(&card).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", ErrCardNotInColumn
+	}
+	return card.Rank, err
+}
+
+// cardBounds returns the ranks the moved card has to fit between ("" means open ended)
+func cardBounds(tx *gorm.DB, placement CardPlacement) (string, string, error) {
+	others := tx.Model(&models.BoardCard{}).
+		Where("column_id = ? AND task_id <> ?", placement.ColumnID, placement.TaskID)
+
+	var after, before string
+	var err error
+	if placement.AfterTaskID != nil {
+		if after, err = cardRank(tx, placement, *placement.AfterTaskID); err != nil {
+			return "", "", err
+		}
+	}
+	if placement.BeforeTaskID != nil {
+		if before, err = cardRank(tx, placement, *placement.BeforeTaskID); err != nil {
+			return "", "", err
+		}
+	}
+
+	var ranks []string
+	switch {
+	case placement.AfterTaskID != nil && placement.BeforeTaskID == nil:
+		err = others.Session(&gorm.Session{}).Where("rank > ?", after).Order("rank").Limit(1).Pluck("rank", &ranks).Error
+		if len(ranks) > 0 {
+			before = ranks[0]
+		}
+	case placement.AfterTaskID == nil && placement.BeforeTaskID != nil:
+		err = others.Session(&gorm.Session{}).Where("rank < ?", before).Order("rank DESC").Limit(1).Pluck("rank", &ranks).Error
+		if len(ranks) > 0 {
+			after = ranks[0]
+		}
+	case placement.AfterTaskID == nil && placement.BeforeTaskID == nil:
+		// append to the end of the column
+		err = others.Session(&gorm.Session{}).Order("rank DESC").Limit(1).Pluck("rank", &ranks).Error
+		if len(ranks) > 0 {
+			after = ranks[0]
+		}
+	}
+	return after, before, err
+}
+
+// rebalanceColumn gives every card of the column (plus the moved card, right after the card
+// ranked `after`) a fresh, evenly spaced rank and returns the moved card's new rank
+func rebalanceColumn(tx *gorm.DB, placement CardPlacement, after string) (string, error) {
+	var cards []models.BoardCard
+	if err := tx.Where("column_id = ? AND task_id <> ?", placement.ColumnID, placement.TaskID).
+		Order("rank, task_id").Find(&cards).Error; err != nil {
+		return "", err
+	}
+
+	index := 0
+	for index < len(cards) && after != "" && cards[index].Rank <= after {
+		index++
+	}
+
+	ranks := utils.EvenRanks(len(cards) + 1)
+	movedRank := ranks[index]
+	for i, card := range cards {
+		rank := ranks[i]
+		if i >= index {
+			rank = ranks[i+1]
+		}
+		if err := tx.Model(&models.BoardCard{}).
+			Where("board_id = ? AND task_id = ?", card.BoardID, card.TaskID).
+			Update("rank", rank).Error; err != nil {
+			return "", err
+		}
+	}
+	return movedRank, nil
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CalendarRepository defines the data access methods for calendar feeds
+type CalendarRepository interface {
+	GetFeed(userID uuid.UUID) (*models.CalendarFeed, error)
+	GetFeedByTokenHash(tokenHash string) (*models.CalendarFeed, error)
+	SaveFeed(feed *models.CalendarFeed) error
+	DeleteFeed(userID uuid.UUID) (bool, error)
+	TouchFeed(userID uuid.UUID, at time.Time) error
+	ListDueTasks(userID uuid.UUID, dueFrom time.Time, limit int) ([]models.Task, error)
+}
+
+type CalendarRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewCalendarRepository(db *gorm.DB) CalendarRepository {
+	return &CalendarRepositoryImpl{
+		DB: db,
+	}
+}
+
+// GetFeed returns the user's feed, or nil if they have none
+func (repo *CalendarRepositoryImpl) GetFeed(userID uuid.UUID) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	if err := repo.DB.Where("user_id = ?", userID).First(&feed).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &feed, nil
+}
+
+// GetFeedByTokenHash returns the feed with the given token, unless its user has been deleted
+func (repo *CalendarRepositoryImpl) GetFeedByTokenHash(tokenHash string) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	err := repo.DB.
+		Joins("JOIN users u ON u.id = calendar_feeds.user_id AND u.deleted_at IS NULL").
+		Where("calendar_feeds.token_hash = ?", tokenHash).
+		First(&feed).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &feed, nil
+}
+
+// SaveFeed creates the user's feed or replaces its token, which invalidates the old URL
+func (repo *CalendarRepositoryImpl) SaveFeed(feed *models.CalendarFeed) error {
+	return repo.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token_hash", "created_at", "last_accessed_at"}),
+	}).Create(feed).Error
+}
+
+// DeleteFeed turns the user's feed off and reports whether there was one
+func (repo *CalendarRepositoryImpl) DeleteFeed(userID uuid.UUID) (bool, error) {
+	result := repo.DB.Where("user_id = ?", userID).Delete(&models.CalendarFeed{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// TouchFeed records when a calendar client last fetched the feed
+func (repo *CalendarRepositoryImpl) TouchFeed(userID uuid.UUID, at time.Time) error {
+	return repo.DB.Model(&models.CalendarFeed{}).Where("user_id = ?", userID).Update("last_accessed_at", at).Error
+}
+
+// ListDueTasks returns up to limit tasks due from dueFrom on that the user owns or is assigned to,
+// in workspaces they are still a member of, soonest first
+func (repo *CalendarRepositoryImpl) ListDueTasks(userID uuid.UUID, dueFrom time.Time, limit int) ([]models.Task, error) {
+	var tasks []models.Task
+	err := repo.DB.Preload("Tags").
+		Where("due_date IS NOT NULL AND due_date >= ?", dueFrom).
+		Where("user_id = ? OR EXISTS (SELECT 1 FROM task_assignees a WHERE a.task_id = tasks.id AND a.user_id = ?)", userID, userID).
+		Where(taskMemberScope, userID).
+		Order("due_date, id").
+		Limit(limit).
+		Find(&tasks).Error
+	return tasks, err
+}
@@ -0,0 +1,203 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CommentRepository defines the data access methods for task comments
+type CommentRepository interface {
+	CreateComment(comment *models.Comment, mentionIDs []uuid.UUID) (*models.Comment, error)
+	GetCommentByID(commentID, taskID uuid.UUID) (*models.Comment, error)
+	ListComments(taskID uuid.UUID) ([]*models.Comment, error)
+	UpdateComment(commentID, editorID uuid.UUID, body string, mentionIDs []uuid.UUID) (*models.Comment, []uuid.UUID, error)
+	DeleteComment(commentID uuid.UUID) (bool, error)
+	GetRevisions(commentID uuid.UUID) ([]models.CommentRevision, error)
+	FindMentionedUsers(taskID, workspaceID uuid.UUID, emails, names []string) ([]models.User, error)
+}
+
+type CommentRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &CommentRepositoryImpl{
+		DB: db,
+	}
+}
+
+// insertMentions links users to a comment and returns the ones that were not linked before
+func insertMentions(tx *gorm.DB, commentID uuid.UUID, mentionIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var added []uuid.UUID
+	for _, userID := range mentionIDs {
+		result := tx.Exec(
+			"INSERT INTO comment_mentions (comment_id, user_id) VALUES (?, ?) ON CONFLICT DO NOTHING", commentID, userID,
+		)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected > 0 {
+			added = append(added, userID)
+		}
+	}
+	return added, nil
+}
+
+// loadComment reads a comment with its author and mentioned users
+func loadComment(db *gorm.DB, commentID uuid.UUID) (*models.Comment, error) {
+	var comment models.Comment
+	if err := db.Preload("Author").Preload("Mentions").Where("id = ?", commentID).First(&comment).Error; err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// CreateComment saves a comment together with its mentions
+func (repo *CommentRepositoryImpl) CreateComment(comment *models.Comment, mentionIDs []uuid.UUID) (*models.Comment, error) {
+	var created *models.Comment
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(comment).Error; err != nil {
+			return err
+		}
+		if _, err := insertMentions(tx, comment.ID, mentionIDs); err != nil {
+			return err
+		}
+		var err error
+		created, err = loadComment(tx, comment.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetCommentByID returns a comment that is not deleted and belongs to the given task
+func (repo *CommentRepositoryImpl) GetCommentByID(commentID, taskID uuid.UUID) (*models.Comment, error) {
+	var comment models.Comment
+	if err := repo.DB.Where("id = ? AND task_id = ?", commentID, taskID).First(&comment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// ListComments returns every comment of a task in creation order, including deleted ones
+// so that their replies still have a place in the thread
+func (repo *CommentRepositoryImpl) ListComments(taskID uuid.UUID) ([]*models.Comment, error) {
+	var comments []*models.Comment
+	err := repo.DB.Unscoped().
+		Preload("Author").
+		Preload("Mentions").
+		Where("task_id = ?", taskID).
+		Order("created_at, id").
+		Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// UpdateComment stores the current body as a revision, replaces it and re-links mentions.
+// It returns the updated comment and the users mentioned for the first time; a nil comment
+// means it no longer exists.
+func (repo *CommentRepositoryImpl) UpdateComment(commentID, editorID uuid.UUID, body string, mentionIDs []uuid.UUID) (*models.Comment, []uuid.UUID, error) {
+	var updated *models.Comment
+	var added []uuid.UUID
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		// lock the row so concurrent edits each archive the body they replaced
+		var current models.Comment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", commentID).First(&current).Error; err != nil {
+			return err
+		}
+		if current.Body == body {
+			var err error
+			updated, err = loadComment(tx, commentID)
+			return err
+		}
+
+		revision := models.CommentRevision{CommentID: commentID, EditorID: editorID, Body: current.Body}
+		if err := tx.Create(&revision).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := tx.Model(&models.Comment{}).Where("id = ?", commentID).
+			Updates(map[string]interface{}{"body": body, "edited_at": now, "updated_at": now}).Error; err != nil {
+			return err
+		}
+
+		if len(mentionIDs) == 0 {
+			if err := tx.Exec("DELETE FROM comment_mentions WHERE comment_id = ?", commentID).Error; err != nil {
+				return err
+			}
+		} else if err := tx.Exec(
+			"DELETE FROM comment_mentions WHERE comment_id = ? AND user_id NOT IN ?", commentID, mentionIDs,
+		).Error; err != nil {
+			return err
+		}
+		var err error
+		if added, err = insertMentions(tx, commentID, mentionIDs); err != nil {
+			return err
+		}
+		updated, err = loadComment(tx, commentID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	return updated, added, nil
+}
+
+// DeleteComment soft deletes a comment; its revisions and replies are kept
+func (repo *CommentRepositoryImpl) DeleteComment(commentID uuid.UUID) (bool, error) {
+	result := repo.DB.Where("id = ?", commentID).Delete(&models.Comment{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetRevisions returns the earlier bodies of a comment, oldest first
+func (repo *CommentRepositoryImpl) GetRevisions(commentID uuid.UUID) ([]models.CommentRevision, error) {
+	var revisions []models.CommentRevision
+	if err := repo.DB.Where("comment_id = ?", commentID).Order("created_at, id").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// FindMentionedUsers returns the users who can read the task, its owner and its assignees while they
+// are members of the workspace, whose email or name matches one of the lower-cased values
+func (repo *CommentRepositoryImpl) FindMentionedUsers(taskID, workspaceID uuid.UUID, emails, names []string) ([]models.User, error) {
+	var users []models.User
+	if len(emails) == 0 && len(names) == 0 {
+		return users, nil
+	}
+
+	query := repo.DB.Model(&models.User{}).
+		Where("EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = ? AND wm.user_id = users.id)", workspaceID).
+		Where("(EXISTS (SELECT 1 FROM tasks t WHERE t.id = ? AND t.user_id = users.id) OR EXISTS (SELECT 1 FROM task_assignees a WHERE a.task_id = ? AND a.user_id = users.id))", taskID, taskID)
+	switch {
+	case len(emails) > 0 && len(names) > 0:
+		query = query.Where("(lower(email) IN ? OR lower(name) IN ?)", emails, names)
+	case len(emails) > 0:
+		query = query.Where("lower(email) IN ?", emails)
+	default:
+		query = query.Where("lower(name) IN ?", names)
+	}
+	if err := query.Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
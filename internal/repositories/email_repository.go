@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EmailRepository defines the data access methods for the email outbox
+type EmailRepository interface {
+	EnqueueEmails(emails []models.OutboundEmail) error
+	ClaimDueEmails(now time.Time, lease time.Duration, limit int) ([]models.OutboundEmail, error)
+	MarkEmailSent(emailID uuid.UUID, at time.Time) error
+	MarkEmailFailed(emailID uuid.UUID, status string, nextAttemptAt time.Time, lastError string) error
+}
+
+type EmailRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewEmailRepository(db *gorm.DB) EmailRepository {
+	return &EmailRepositoryImpl{
+		DB: db,
+	}
+}
+
+// EnqueueEmails adds emails to the outbox
+func (repo *EmailRepositoryImpl) EnqueueEmails(emails []models.OutboundEmail) error {
+	if len(emails) == 0 {
+		return nil
+	}
+	return repo.DB.Create(&emails).Error
+}
+
+// ClaimDueEmails picks up to limit pending emails whose next attempt is due, counts the attempt
+// and pushes their next attempt back by lease, so that no other server sends them meanwhile.
+// An email whose sender dies mid-attempt is picked up again once the lease runs out.
+func (repo *EmailRepositoryImpl) ClaimDueEmails(now time.Time, lease time.Duration, limit int) ([]models.OutboundEmail, error) {
+	var emails []models.OutboundEmail
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.EmailStatusPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&emails).Error; err != nil {
+			return err
+		}
+		if len(emails) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(emails))
+		for i := range emails {
+			ids[i] = emails[i].ID
+			emails[i].Attempts++
+		}
+		return tx.Model(&models.OutboundEmail{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": now.Add(lease),
+		}).Error
+	})
+	return emails, err
+}
+
+// MarkEmailSent
+func (repo *EmailRepositoryImpl) MarkEmailSent(emailID uuid.UUID, at time.Time) error {
+	return repo.DB.Model(&models.OutboundEmail{}).Where("id = ?", emailID).Updates(map[string]interface{}{
+		"status":     models.EmailStatusSent,
+		"sent_at":    at,
+		"last_error": nil,
+	}).Error
+}
+
+// MarkEmailFailed records a failed attempt; status stays pending for another try at nextAttemptAt
+func (repo *EmailRepositoryImpl) MarkEmailFailed(emailID uuid.UUID, status string, nextAttemptAt time.Time, lastError string) error {
+	return repo.DB.Model(&models.OutboundEmail{}).Where("id = ?", emailID).Updates(map[string]interface{}{
+		"status":          status,
+		"next_attempt_at": nextAttemptAt,
+		"last_error":      lastError,
+	}).Error
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NotificationRepository defines the data access methods for user notifications
+type NotificationRepository interface {
+	CreateNotifications(notifications []models.Notification) error
+	ListNotifications(userID uuid.UUID, unreadOnly bool, limit int) ([]models.Notification, error)
+	CountUnread(userID uuid.UUID) (int64, error)
+	MarkRead(notificationID, userID uuid.UUID) (bool, error)
+	MarkAllRead(userID uuid.UUID) (int64, error)
+}
+
+type NotificationRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &NotificationRepositoryImpl{
+		DB: db,
+	}
+}
+
+// CreateNotifications
+func (repo *NotificationRepositoryImpl) CreateNotifications(notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return repo.DB.Create(&notifications).Error
+}
+
+// ListNotifications returns the user's newest notifications first
+func (repo *NotificationRepositoryImpl) ListNotifications(userID uuid.UUID, unreadOnly bool, limit int) ([]models.Notification, error) {
+	var notifications []models.Notification
+	query := repo.DB.Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// CountUnread
+func (repo *NotificationRepositoryImpl) CountUnread(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := repo.DB.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+// MarkRead marks one notification as read; it reports false if the user has no such notification
+func (repo *NotificationRepositoryImpl) MarkRead(notificationID, userID uuid.UUID) (bool, error) {
+	var count int64
+	if err := repo.DB.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", notificationID, userID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count == 0 {
+		return false, nil
+	}
+	err := repo.DB.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", notificationID, userID).
+		Update("read_at", time.Now()).Error
+	return err == nil, err
+}
+
+// MarkAllRead marks every unread notification of the user as read and returns how many changed
+func (repo *NotificationRepositoryImpl) MarkAllRead(userID uuid.UUID) (int64, error) {
+	result := repo.DB.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now())
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrTasksNotOwned is returned when some of the tasks in a move do not belong to the user or workspace
+var ErrTasksNotOwned = errors.New("some tasks were not found")
+
+// ProjectRepository defines the data access methods for projects
+type ProjectRepository interface {
+	CreateProject(project *models.Project) (*models.Project, error)
+	GetProjectByID(projectID, ownerID uuid.UUID) (*models.Project, error)
+	ListProjects(ownerID, workspaceID uuid.UUID, includeArchived bool) ([]models.Project, error)
+	UpdateProject(project *models.Project) (*models.Project, error)
+	DeleteProject(projectID, ownerID uuid.UUID) (bool, error)
+	MoveTasks(ownerID, workspaceID uuid.UUID, projectID *uuid.UUID, taskIDs []uuid.UUID) error
+	CountTasksByStatus(projectID uuid.UUID) (map[string]int64, error)
+}
+
+type ProjectRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewProjectRepository(db *gorm.DB) ProjectRepository {
+	return &ProjectRepositoryImpl{
+		DB: db,
+	}
+}
+
+// CreateProject
+func (repo *ProjectRepositoryImpl) CreateProject(project *models.Project) (*models.Project, error) {
+	if err := repo.DB.Create(project).Error; err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// GetProjectByID returns the project only if the user owns it and is a member of its workspace
+func (repo *ProjectRepositoryImpl) GetProjectByID(projectID, ownerID uuid.UUID) (*models.Project, error) {
+	var project models.Project
+	err := repo.DB.Where("id = ? AND owner_id = ?", projectID, ownerID).
+		Where("EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = projects.workspace_id AND wm.user_id = ?)", ownerID).
+		First(&project).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &project, nil
+}
+
+// ListProjects returns the user's projects in a workspace ordered by name
+func (repo *ProjectRepositoryImpl) ListProjects(ownerID, workspaceID uuid.UUID, includeArchived bool) ([]models.Project, error) {
+	var projects []models.Project
+	query := repo.DB.Where("owner_id = ? AND workspace_id = ?", ownerID, workspaceID)
+	if !includeArchived {
+		query = query.Where("archived = ?", false)
+	}
+	if err := query.Order("lower(name), id").Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// UpdateProject
+func (repo *ProjectRepositoryImpl) UpdateProject(project *models.Project) (*models.Project, error) {
+	err := repo.DB.Model(&models.Project{}).
+		Where("id = ? AND owner_id = ?", project.ID, project.OwnerID).
+		Select("name", "description", "color", "archived", "updated_at").
+		Updates(project).Error
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// DeleteProject removes a project; its tasks are kept and lose their project
+func (repo *ProjectRepositoryImpl) DeleteProject(projectID, ownerID uuid.UUID) (bool, error) {
+	result := repo.DB.Where("id = ? AND owner_id = ?", projectID, ownerID).Delete(&models.Project{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// MoveTasks sets the project of the given tasks. Either every task moves or, if any of them is not
+// the user's or lies in another workspace, none does.
+func (repo *ProjectRepositoryImpl) MoveTasks(ownerID, workspaceID uuid.UUID, projectID *uuid.UUID, taskIDs []uuid.UUID) error {
+	return repo.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Task{}).
+			Where("id IN ? AND user_id = ? AND workspace_id = ?", taskIDs, ownerID, workspaceID).
+			Updates(map[string]interface{}{"project_id": projectID, "version": bumpVersion})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(taskIDs)) {
+			return ErrTasksNotOwned
+		}
+		return nil
+	})
+}
+
+// CountTasksByStatus groups the project's tasks by status
+func (repo *ProjectRepositoryImpl) CountTasksByStatus(projectID uuid.UUID) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	err := repo.DB.Model(&models.Task{}).
+		Select("status, COUNT(*) AS count").
+		Where("project_id = ?", projectID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReminderRepository defines the data access methods for due-date reminders
+type ReminderRepository interface {
+	ListReminderCandidates(dueFrom, dueTo time.Time, after *models.ReminderCandidate, limit int) ([]models.ReminderCandidate, error)
+	RecordReminders(candidate *models.ReminderCandidate, skipped []int, notify *models.Notification, email *models.OutboundEmail, offset int) (bool, error)
+}
+
+type ReminderRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewReminderRepository(db *gorm.DB) ReminderRepository {
+	return &ReminderRepositoryImpl{
+		DB: db,
+	}
+}
+
+// reminderCandidatesQuery pairs every open task due in the window with its owner and assignees who
+// are still workspace members, and lists the offsets already handled for the task's current due date
+const reminderCandidatesQuery = `
+SELECT t.id AS task_id, t.title AS task_title, r.user_id, u.name AS user_name, u.email AS user_email,
+	u.timezone AS user_timezone, t.due_date, u.reminder_offsets,
+	(SELECT string_agg(tr.offset_minutes::text, ',') FROM task_reminders tr
+		WHERE tr.task_id = t.id AND tr.user_id = r.user_id AND tr.due_date = t.due_date) AS sent_offsets
+FROM tasks t
+CROSS JOIN LATERAL (
+	SELECT t.user_id
+	UNION
+	SELECT a.user_id FROM task_assignees a WHERE a.task_id = t.id
+) r (user_id)
+JOIN users u ON u.id = r.user_id AND u.deleted_at IS NULL
+WHERE t.deleted_at IS NULL
+	AND t.due_date >= ? AND t.due_date <= ?
+	AND t.status NOT IN ?
+	AND EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = t.workspace_id AND wm.user_id = r.user_id)
+	AND (t.due_date, t.id, r.user_id) > (?, ?, ?)
+ORDER BY t.due_date, t.id, r.user_id
+LIMIT ?`
+
+// ListReminderCandidates returns up to limit task and recipient pairs with a due date in the window,
+// continuing after the given candidate when paging
+func (repo *ReminderRepositoryImpl) ListReminderCandidates(dueFrom, dueTo time.Time, after *models.ReminderCandidate, limit int) ([]models.ReminderCandidate, error) {
+	afterDue, afterTask, afterUser := dueFrom.Add(-time.Nanosecond), uuid.Nil, uuid.Nil
+	if after != nil {
+		afterDue, afterTask, afterUser = after.DueDate, after.TaskID, after.UserID
+	}
+
+	var candidates []models.ReminderCandidate
+	err := repo.DB.Raw(reminderCandidatesQuery,
+		dueFrom, dueTo,
+		models.ClosedTaskStatuses,
+		afterDue, afterTask, afterUser,
+		limit,
+	).Scan(&candidates).Error
+	return candidates, err
+}
+
+// RecordReminders marks the candidate's offsets as handled and stores notify and email, the
+// reminder for offset, in one transaction. The reminder's primary key makes it fire once even when
+// several servers race for it: a reminder someone else recorded first is not notified again and
+// false is returned. skipped offsets are recorded without a notification; email may be nil.
+func (repo *ReminderRepositoryImpl) RecordReminders(candidate *models.ReminderCandidate, skipped []int, notify *models.Notification, email *models.OutboundEmail, offset int) (bool, error) {
+	sent := false
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if notify != nil {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.TaskReminder{
+				TaskID:        candidate.TaskID,
+				UserID:        candidate.UserID,
+				OffsetMinutes: offset,
+				DueDate:       candidate.DueDate,
+				Notified:      true,
+			})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				if err := tx.Create(notify).Error; err != nil {
+					return err
+				}
+				if email != nil {
+					if err := tx.Create(email).Error; err != nil {
+						return err
+					}
+				}
+				sent = true
+			}
+		}
+
+		if len(skipped) == 0 {
+			return nil
+		}
+		rows := make([]models.TaskReminder, len(skipped))
+		for i, skippedOffset := range skipped {
+			rows[i] = models.TaskReminder{
+				TaskID:        candidate.TaskID,
+				UserID:        candidate.UserID,
+				OffsetMinutes: skippedOffset,
+				DueDate:       candidate.DueDate,
+			}
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+	})
+	return sent, err
+}
@@ -0,0 +1,215 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// ErrTagNameTaken is returned when a user already has a tag with the same name (ignoring case)
+var ErrTagNameTaken = errors.New("tag name already exists")
+
+// TagRepository defines the data access methods for tags
+type TagRepository interface {
+	CreateTag(tag *models.Tag) (*models.Tag, error)
+	GetTagByID(tagID, userID uuid.UUID) (*models.Tag, error)
+	ListTags(userID uuid.UUID) ([]models.Tag, error)
+	UpdateTag(tag *models.Tag) (*models.Tag, error)
+	DeleteTag(tagID, userID uuid.UUID, audit func(taskID uuid.UUID) models.AuditEvent) (bool, []models.AuditEvent, error)
+	MergeTags(sourceID, targetID, userID uuid.UUID, audit func(taskID uuid.UUID, gainedTarget bool) models.AuditEvent) ([]models.AuditEvent, error)
+	CountOwnedTags(tagIDs []uuid.UUID, userID uuid.UUID) (int64, error)
+	UpdateTaskTags(taskID uuid.UUID, attach, detach []uuid.UUID, event *models.AuditEvent) error
+	GetTaskTags(taskID uuid.UUID) ([]models.Tag, error)
+}
+
+type TagRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &TagRepositoryImpl{
+		DB: db,
+	}
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique_violation
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// CreateTag
+func (repo *TagRepositoryImpl) CreateTag(tag *models.Tag) (*models.Tag, error) {
+	if err := repo.DB.Create(tag).Error; err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrTagNameTaken
+		}
+		return nil, err
+	}
+	return tag, nil
+}
+
+// GetTagByID returns the tag only if it belongs to the given user
+func (repo *TagRepositoryImpl) GetTagByID(tagID, userID uuid.UUID) (*models.Tag, error) {
+	var tag models.Tag
+	if err := repo.DB.Where("id = ? AND user_id = ?", tagID, userID).First(&tag).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// ListTags returns all of the user's tags ordered by name
+func (repo *TagRepositoryImpl) ListTags(userID uuid.UUID) ([]models.Tag, error) {
+	var tags []models.Tag
+	if err := repo.DB.Where("user_id = ?", userID).Order("lower(name)").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// UpdateTag saves a renamed or recolored tag
+func (repo *TagRepositoryImpl) UpdateTag(tag *models.Tag) (*models.Tag, error) {
+	err := repo.DB.Model(&models.Tag{}).
+		Where("id = ? AND user_id = ?", tag.ID, tag.UserID).
+		Select("name", "color", "updated_at").
+		Updates(tag).Error
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrTagNameTaken
+		}
+		return nil, err
+	}
+	return tag, nil
+}
+
+// DeleteTag removes a tag (and, through the cascade, its task links). Every task that loses the
+// tag gets a new version and the audit event built by audit, written in the same transaction.
+func (repo *TagRepositoryImpl) DeleteTag(tagID, userID uuid.UUID, audit func(taskID uuid.UUID) models.AuditEvent) (bool, []models.AuditEvent, error) {
+	deleted := false
+	var events []models.AuditEvent
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		var taskIDs []uuid.UUID
+		if err := tx.Raw("SELECT task_id FROM task_tags WHERE tag_id = ?", tagID).Scan(&taskIDs).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id = ? AND user_id = ?", tagID, userID).Delete(&models.Tag{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		deleted = true
+
+		events = make([]models.AuditEvent, len(taskIDs))
+		for i, taskID := range taskIDs {
+			events[i] = audit(taskID)
+		}
+		return touchTaggedTasks(tx, taskIDs, events)
+	})
+	if err != nil || !deleted {
+		return deleted, nil, err
+	}
+	return deleted, events, nil
+}
+
+// touchTaggedTasks bumps the version of tasks whose tags changed and writes their audit events
+func touchTaggedTasks(tx *gorm.DB, taskIDs []uuid.UUID, events []models.AuditEvent) error {
+	if len(taskIDs) == 0 {
+		return nil
+	}
+	if err := tx.Model(&models.Task{}).Where("id IN ?", taskIDs).Update("version", bumpVersion).Error; err != nil {
+		return err
+	}
+	return tx.Create(&events).Error
+}
+
+// MergeTags moves every task link from source to target and deletes source.
+// Both tags must already be known to belong to the user.
+// Every re-tagged task gets a new version and the audit event built by audit, which is told whether
+// the task gained the target or already had it.
+func (repo *TagRepositoryImpl) MergeTags(sourceID, targetID, userID uuid.UUID, audit func(taskID uuid.UUID, gainedTarget bool) models.AuditEvent) ([]models.AuditEvent, error) {
+	var events []models.AuditEvent
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		var links []struct {
+			TaskID    uuid.UUID
+			HasTarget bool
+		}
+		if err := tx.Raw(`
+			SELECT s.task_id, EXISTS (SELECT 1 FROM task_tags t WHERE t.task_id = s.task_id AND t.tag_id = ?) AS has_target
+			FROM task_tags s WHERE s.tag_id = ?`, targetID, sourceID).Scan(&links).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`
+			INSERT INTO task_tags (task_id, tag_id)
+			SELECT task_id, ? FROM task_tags WHERE tag_id = ?
+			ON CONFLICT DO NOTHING`, targetID, sourceID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id = ? AND user_id = ?", sourceID, userID).Delete(&models.Tag{}).Error; err != nil {
+			return err
+		}
+
+		taskIDs := make([]uuid.UUID, len(links))
+		events = make([]models.AuditEvent, len(links))
+		for i, link := range links {
+			taskIDs[i] = link.TaskID
+			events[i] = audit(link.TaskID, !link.HasTarget)
+		}
+		return touchTaggedTasks(tx, taskIDs, events)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// CountOwnedTags counts how many of the given tags belong to the user
+func (repo *TagRepositoryImpl) CountOwnedTags(tagIDs []uuid.UUID, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := repo.DB.Model(&models.Tag{}).Where("id IN ? AND user_id = ?", tagIDs, userID).Count(&count).Error
+	return count, err
+}
+
+// UpdateTaskTags attaches and detaches tags on a task and writes event, if any, in one transaction
+func (repo *TagRepositoryImpl) UpdateTaskTags(taskID uuid.UUID, attach, detach []uuid.UUID, event *models.AuditEvent) error {
+	return repo.DB.Transaction(func(tx *gorm.DB) error {
+		if len(detach) > 0 {
+			if err := tx.Exec("DELETE FROM task_tags WHERE task_id = ? AND tag_id IN ?", taskID, detach).Error; err != nil {
+				return err
+			}
+		}
+		for _, tagID := range attach {
+			if err := tx.Exec(
+				"INSERT INTO task_tags (task_id, tag_id) VALUES (?, ?) ON CONFLICT DO NOTHING", taskID, tagID,
+			).Error; err != nil {
+				return err
+			}
+		}
+		// tags are part of the task's representation, so its ETag has to change
+		if err := tx.Model(&models.Task{}).Where("id = ?", taskID).Update("version", bumpVersion).Error; err != nil {
+			return err
+		}
+		if event != nil {
+			return tx.Create(event).Error
+		}
+		return nil
+	})
+}
+
+// GetTaskTags returns the tags attached to a task
+func (repo *TagRepositoryImpl) GetTaskTags(taskID uuid.UUID) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := repo.DB.
+		Joins("JOIN task_tags tt ON tt.tag_id = tags.id").
+		Where("tt.task_id = ?", taskID).
+		Order("lower(tags.name)").
+		Find(&tags).Error
+	return tags, err
+}
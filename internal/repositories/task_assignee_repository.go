@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetAccessibleTask returns the task if the user owns it or is one of its assignees and is still
+// a member of its workspace
+func (repo *TaskRepositoryImpl) GetAccessibleTask(taskID, userID uuid.UUID) (*models.Task, error) {
+	var task models.Task
+	err := repo.DB.Preload("Tags").Preload("Assignees").
+		Where("id = ?", taskID).
+		Where("user_id = ? OR EXISTS (SELECT 1 FROM task_assignees a WHERE a.task_id = tasks.id AND a.user_id = ?)", userID, userID).
+		Where(taskMemberScope, userID).
+		First(&task).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+// ChangeAssignees adds and removes assignees of a task owned by ownerID and records an event
+// for each actual change. It returns the users that were really added and removed; the bool is
+// false when the task does not belong to the owner. A non-zero version must match the task's
+// current one, otherwise ErrVersionConflict is returned.
+func (repo *TaskRepositoryImpl) ChangeAssignees(taskID, ownerID uuid.UUID, version int, add, remove []uuid.UUID) (bool, []uuid.UUID, []uuid.UUID, error) {
+	found := false
+	var added, removed []uuid.UUID
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		// lock the task row so concurrent assignment changes are applied one after another
+		var task models.Task
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "version").Where("id = ? AND user_id = ?", taskID, ownerID).First(&task).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		found = true
+		if version != 0 && task.Version != version {
+			return ErrVersionConflict
+		}
+
+		for _, userID := range remove {
+			result := tx.Where("task_id = ? AND user_id = ?", taskID, userID).Delete(&models.TaskAssignee{})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				removed = append(removed, userID)
+			}
+		}
+		for _, userID := range add {
+			result := tx.Exec(
+				"INSERT INTO task_assignees (task_id, user_id, assigned_by) VALUES (?, ?, ?) ON CONFLICT DO NOTHING",
+				taskID, userID, ownerID,
+			)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				added = append(added, userID)
+			}
+		}
+
+		events := make([]models.TaskAssignmentEvent, 0, len(added)+len(removed))
+		for _, userID := range added {
+			events = append(events, models.TaskAssignmentEvent{TaskID: taskID, ActorID: ownerID, UserID: userID, Action: models.AssignmentActionAssigned})
+		}
+		for _, userID := range removed {
+			events = append(events, models.TaskAssignmentEvent{TaskID: taskID, ActorID: ownerID, UserID: userID, Action: models.AssignmentActionUnassigned})
+		}
+		if len(events) > 0 {
+			// assignees are part of the task's representation, so its ETag has to change
+			if err := tx.Model(&models.Task{}).Where("id = ?", taskID).Update("version", bumpVersion).Error; err != nil {
+				return err
+			}
+			return tx.Create(&events).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return false, nil, nil, err
+	}
+	return found, added, removed, nil
+}
+
+// GetAssignees returns the assignees of a task with their user records, earliest first
+func (repo *TaskRepositoryImpl) GetAssignees(taskID uuid.UUID) ([]models.TaskAssignee, error) {
+	var assignees []models.TaskAssignee
+	if err := repo.DB.Preload("User").Where("task_id = ?", taskID).Order("assigned_at, user_id").Find(&assignees).Error; err != nil {
+		return nil, err
+	}
+	return assignees, nil
+}
+
+// GetAssignmentEvents returns the assignment history of a task, oldest first
+func (repo *TaskRepositoryImpl) GetAssignmentEvents(taskID uuid.UUID) ([]models.TaskAssignmentEvent, error) {
+	var events []models.TaskAssignmentEvent
+	if err := repo.DB.Where("task_id = ?", taskID).Order("created_at, id").Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrTaskNotFound is returned by batch writes when a task disappeared between reading and writing it
+var ErrTaskNotFound = errors.New("task not found")
+
+// BulkTaskChange is one task's part of a bulk operation. Task carries the new field values and
+// the version they were computed from; Delete trashes the task instead of updating it.
+type BulkTaskChange struct {
+	Task       *models.Task
+	Transition *models.TaskStatusTransition
+	AttachTags []uuid.UUID
+	DetachTags []uuid.UUID
+	Delete     bool
+	Cascade    bool
+}
+
+// GetAccessibleTasks loads the tasks among taskIDs that the user owns or is assigned to, in a
+// workspace they are still a member of. Tasks the user cannot see are simply left out.
+func (repo *TaskRepositoryImpl) GetAccessibleTasks(taskIDs []uuid.UUID, userID uuid.UUID) ([]models.Task, error) {
+	var tasks []models.Task
+	err := repo.DB.Preload("Tags").
+		Where("id IN ?", taskIDs).
+		Where("user_id = ? OR EXISTS (SELECT 1 FROM task_assignees a WHERE a.task_id = tasks.id AND a.user_id = ?)", userID, userID).
+		Where(taskMemberScope, userID).
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// ListTaskIDs returns the IDs of up to limit tasks matching the filter, oldest first.
+// Sort, cursor and page size of the filter are ignored.
+func (repo *TaskRepositoryImpl) ListTaskIDs(filter TaskListFilter, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := applyTaskFilters(repo.DB.Model(&models.Task{}), filter).
+		Order("created_at, id").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// ApplyBulkChanges writes a batch of task changes. When atomic, everything runs in one transaction
+// that is rolled back at the first failure, which is returned along with the per-change errors;
+// otherwise every change gets its own transaction and only the per-change errors are filled in.
+func (repo *TaskRepositoryImpl) ApplyBulkChanges(changes []BulkTaskChange, atomic bool) ([]error, error) {
+	errs := make([]error, len(changes))
+	if !atomic {
+		for i := range changes {
+			errs[i] = repo.DB.Transaction(func(tx *gorm.DB) error {
+				return applyBulkChange(tx, &changes[i])
+			})
+		}
+		return errs, nil
+	}
+
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		for i := range changes {
+			if err := applyBulkChange(tx, &changes[i]); err != nil {
+				errs[i] = err
+				return err
+			}
+		}
+		return nil
+	})
+	return errs, err
+}
+
+func applyBulkChange(tx *gorm.DB, change *BulkTaskChange) error {
+	repo := &TaskRepositoryImpl{DB: tx}
+	task := change.Task
+
+	if change.Delete {
+		// deletes do not depend on the task's fields, so there is no version to hold them to
+		deleted, err := repo.DeleteTask(task.ID, task.UserID, 0, change.Cascade)
+		if err != nil || deleted {
+			return err
+		}
+		// an earlier cascade in the same batch may already have trashed it
+		var trashed int64
+		if err := tx.Unscoped().Model(&models.Task{}).
+			Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", task.ID, task.UserID).
+			Count(&trashed).Error; err != nil {
+			return err
+		}
+		if trashed == 0 {
+			return ErrTaskNotFound
+		}
+		return nil
+	}
+
+	// also for tag-only changes, so that the version check guards them and the ETag changes once
+	updated, err := repo.UpdateTask(task)
+	if err != nil {
+		return err
+	}
+	if updated == nil {
+		return ErrTaskNotFound
+	}
+
+	if len(change.DetachTags) > 0 {
+		if err := tx.Exec("DELETE FROM task_tags WHERE task_id = ? AND tag_id IN ?", task.ID, change.DetachTags).Error; err != nil {
+			return err
+		}
+	}
+	for _, tagID := range change.AttachTags {
+		if err := tx.Exec(
+			"INSERT INTO task_tags (task_id, tag_id) VALUES (?, ?) ON CONFLICT DO NOTHING", task.ID, tagID,
+		).Error; err != nil {
+			return err
+		}
+	}
+	if change.Transition != nil {
+		return tx.Create(change.Transition).Error
+	}
+	return nil
+}
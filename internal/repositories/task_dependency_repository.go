@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrDependencyCycle is returned when a new dependency would close a loop in the graph
+var ErrDependencyCycle = errors.New("dependency would create a cycle")
+
+// AddDependency stores "blockerID blocks taskID". It returns false when either task does not
+// belong to the user or the two tasks are in different workspaces, and ErrDependencyCycle when taskID already (transitively) blocks blockerID.
+func (repo *TaskRepositoryImpl) AddDependency(taskID, blockerID, userID uuid.UUID) (bool, error) {
+	added := false
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		// serialize graph changes per user so two concurrent inserts cannot build a cycle together
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "task-deps:"+userID.String()).Error; err != nil {
+			return err
+		}
+
+		var workspaceIDs []uuid.UUID
+		if err := tx.Model(&models.Task{}).
+			Where("id IN ? AND user_id = ?", []uuid.UUID{taskID, blockerID}, userID).
+			Where(taskMemberScope, userID).
+			Pluck("workspace_id", &workspaceIDs).Error; err != nil {
+			return err
+		}
+		if len(workspaceIDs) != 2 || workspaceIDs[0] != workspaceIDs[1] {
+			return nil
+		}
+
+		// walk everything blockerID waits on; if taskID is in there the new edge closes a loop
+		var cycle bool
+		err := tx.Raw(`
+			WITH RECURSIVE upstream AS (
+				SELECT blocker_id FROM task_dependencies WHERE task_id = ?
+				UNION
+				SELECT d.blocker_id FROM task_dependencies d JOIN upstream u ON d.task_id = u.blocker_id
+			)
+			SELECT EXISTS (SELECT 1 FROM upstream WHERE blocker_id = ?)`, blockerID, taskID).Scan(&cycle).Error
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return ErrDependencyCycle
+		}
+
+		dependency := models.TaskDependency{TaskID: taskID, BlockerID: blockerID}
+		if err := tx.Where(dependency).FirstOrCreate(&dependency).Error; err != nil {
+			return err
+		}
+		added = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return added, nil
+}
+
+// RemoveDependency deletes an edge between two of the user's tasks and reports whether it existed.
+// A non-zero version must match the task's current one, otherwise ErrVersionConflict is returned.
+func (repo *TaskRepositoryImpl) RemoveDependency(taskID, blockerID, userID uuid.UUID, version int) (bool, error) {
+	removed := false
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		var task models.Task
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "version").Where("id = ? AND user_id = ?", taskID, userID).First(&task).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		if version != 0 && task.Version != version {
+			return ErrVersionConflict
+		}
+
+		result := tx.Where("task_id = ? AND blocker_id = ?", taskID, blockerID).Delete(&models.TaskDependency{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		removed = true
+		// the removal was checked against the task's version, so it has to move the version on
+		return tx.Model(&models.Task{}).Where("id = ?", taskID).Update("version", bumpVersion).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return removed, nil
+}
+
+// GetDependencies lists the direct blockers of a task and the tasks it directly blocks
+func (repo *TaskRepositoryImpl) GetDependencies(taskID, userID uuid.UUID) (*models.TaskDependencies, error) {
+	deps := &models.TaskDependencies{BlockedBy: []models.Task{}, Blocks: []models.Task{}}
+	if err := repo.DB.
+		Joins("JOIN task_dependencies d ON d.blocker_id = tasks.id").
+		Where("d.task_id = ? AND tasks.user_id = ?", taskID, userID).
+		Order("tasks.created_at").
+		Find(&deps.BlockedBy).Error; err != nil {
+		return nil, err
+	}
+	if err := repo.DB.
+		Joins("JOIN task_dependencies d ON d.task_id = tasks.id").
+		Where("d.blocker_id = ? AND tasks.user_id = ?", taskID, userID).
+		Order("tasks.created_at").
+		Find(&deps.Blocks).Error; err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+// CountOpenBlockers returns how many direct blockers of the task are neither done nor cancelled
+func (repo *TaskRepositoryImpl) CountOpenBlockers(taskID uuid.UUID) (int64, error) {
+	var count int64
+	err := repo.DB.Model(&models.Task{}).
+		Joins("JOIN task_dependencies d ON d.blocker_id = tasks.id").
+		Where("d.task_id = ? AND tasks.status NOT IN ?", taskID, models.ClosedTaskStatuses).
+		Count(&count).Error
+	return count, err
+}
+
+// GetOpenTaskGraph loads the user's open tasks in a workspace and the dependency edges between them
+func (repo *TaskRepositoryImpl) GetOpenTaskGraph(userID, workspaceID uuid.UUID) ([]models.Task, []models.TaskDependency, error) {
+	var tasks []models.Task
+	err := repo.DB.Where("user_id = ? AND workspace_id = ? AND status NOT IN ?", userID, workspaceID, models.ClosedTaskStatuses).Find(&tasks).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var edges []models.TaskDependency
+	err = repo.DB.Raw(`
+		SELECT d.* FROM task_dependencies d
+		JOIN tasks t ON t.id = d.task_id
+		JOIN tasks b ON b.id = d.blocker_id
+		WHERE t.user_id = ? AND t.workspace_id = ? AND t.status NOT IN ? AND b.status NOT IN ?
+			AND t.deleted_at IS NULL AND b.deleted_at IS NULL`,
+		userID, workspaceID, models.ClosedTaskStatuses, models.ClosedTaskStatuses).Scan(&edges).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	return tasks, edges, nil
+}
@@ -0,0 +1,257 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type sortKind int
+
+const (
+	sortKindTime sortKind = iota
+	sortKindInt
+	sortKindString
+)
+
+type taskSortField struct {
+	expr string
+	kind sortKind
+}
+
+// noDueDate stands in for a NULL due date so that keyset comparisons never see NULL
+var noDueDate = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// priorityRankExpr orders priorities by importance instead of alphabetically, matching models.PriorityRank
+const priorityRankExpr = "CASE priority WHEN 'low' THEN 1 WHEN 'medium' THEN 2 WHEN 'high' THEN 3 WHEN 'urgent' THEN 4 ELSE 0 END"
+
+var taskSortFields = map[string]taskSortField{
+	"created_at": {expr: "created_at", kind: sortKindTime},
+	"updated_at": {expr: "updated_at", kind: sortKindTime},
+	"due_date":   {expr: "COALESCE(due_date, '9999-12-31T00:00:00Z'::timestamptz)", kind: sortKindTime},
+	"priority":   {expr: priorityRankExpr, kind: sortKindInt},
+	"status":     {expr: "status", kind: sortKindString},
+	"title":      {expr: "title", kind: sortKindString},
+	"id":         {expr: "id", kind: sortKindString},
+}
+
+// IsTaskSortField reports whether the field can be used in a task sort
+func IsTaskSortField(field string) bool {
+	_, ok := taskSortFields[field]
+	return ok
+}
+
+// TaskSortKey is a single ORDER BY entry
+type TaskSortKey struct {
+	Field string
+	Desc  bool
+}
+
+// TaskListFilter describes a filtered, sorted page of a user's tasks
+type TaskListFilter struct {
+	UserID       uuid.UUID
+	WorkspaceID  uuid.UUID
+	AssignedOnly bool
+	Statuses     []string
+	Priorities   []string
+	DueFrom      *time.Time
+	DueTo        *time.Time
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	UpdatedFrom  *time.Time
+	UpdatedTo    *time.Time
+	ProjectID    *uuid.UUID
+	NoProject    bool
+	TagIDs       []uuid.UUID
+	MatchAllTags bool
+	Sort         []TaskSortKey
+	Cursor       string
+	Limit        int
+}
+
+// normalizedSort appends created_at and id as tie breakers so the order is total
+func (f TaskListFilter) normalizedSort() []TaskSortKey {
+	keys := make([]TaskSortKey, 0, len(f.Sort)+2)
+	seen := map[string]bool{}
+	for _, key := range f.Sort {
+		if seen[key.Field] {
+			continue
+		}
+		seen[key.Field] = true
+		keys = append(keys, key)
+	}
+	if !seen["created_at"] {
+		keys = append(keys, TaskSortKey{Field: "created_at", Desc: true})
+	}
+	if !seen["id"] {
+		keys = append(keys, TaskSortKey{Field: "id", Desc: true})
+	}
+	return keys
+}
+
+// applyTaskFilters adds the WHERE clauses shared by the page and count queries
+func applyTaskFilters(query *gorm.DB, f TaskListFilter) *gorm.DB {
+	if f.AssignedOnly {
+		query = query.Where("id IN (SELECT task_id FROM task_assignees WHERE user_id = ?)", f.UserID)
+	} else {
+		query = query.Where("user_id = ?", f.UserID)
+	}
+	query = query.Where("workspace_id = ?", f.WorkspaceID)
+	if len(f.Statuses) > 0 {
+		query = query.Where("status IN ?", f.Statuses)
+	}
+	if len(f.Priorities) > 0 {
+		query = query.Where("priority IN ?", f.Priorities)
+	}
+	if f.DueFrom != nil {
+		query = query.Where("due_date >= ?", *f.DueFrom)
+	}
+	if f.DueTo != nil {
+		query = query.Where("due_date < ?", *f.DueTo)
+	}
+	if f.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *f.CreatedFrom)
+	}
+	if f.CreatedTo != nil {
+		query = query.Where("created_at < ?", *f.CreatedTo)
+	}
+	if f.UpdatedFrom != nil {
+		query = query.Where("updated_at >= ?", *f.UpdatedFrom)
+	}
+	if f.UpdatedTo != nil {
+		query = query.Where("updated_at < ?", *f.UpdatedTo)
+	}
+	if f.ProjectID != nil {
+		query = query.Where("project_id = ?", *f.ProjectID)
+	}
+	if f.NoProject {
+		query = query.Where("project_id IS NULL")
+	}
+	if len(f.TagIDs) > 0 {
+		if f.MatchAllTags {
+			query = query.Where(
+				"(SELECT COUNT(DISTINCT tt.tag_id) FROM task_tags tt WHERE tt.task_id = tasks.id AND tt.tag_id IN ?) = ?",
+				f.TagIDs, len(f.TagIDs))
+		} else {
+			query = query.Where("EXISTS (SELECT 1 FROM task_tags tt WHERE tt.task_id = tasks.id AND tt.tag_id IN ?)", f.TagIDs)
+		}
+	}
+	return query
+}
+
+// applyTaskKeyset adds the "after cursor" condition and the ORDER BY clause
+func applyTaskKeyset(query *gorm.DB, keys []TaskSortKey, after []interface{}) *gorm.DB {
+	if after != nil {
+		// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ...
+		var clauses []string
+		var args []interface{}
+		for i, key := range keys {
+			var parts []string
+			for j := 0; j < i; j++ {
+				parts = append(parts, taskSortFields[keys[j].Field].expr+" = ?")
+				args = append(args, after[j])
+			}
+			op := ">"
+			if key.Desc {
+				op = "<"
+			}
+			parts = append(parts, fmt.Sprintf("%s %s ?", taskSortFields[key.Field].expr, op))
+			args = append(args, after[i])
+			clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+		}
+		query = query.Where(strings.Join(clauses, " OR "), args...)
+	}
+
+	for _, key := range keys {
+		direction := "ASC"
+		if key.Desc {
+			direction = "DESC"
+		}
+		query = query.Order(taskSortFields[key.Field].expr + " " + direction)
+	}
+	return query
+}
+
+// taskSortValue returns the value of a sort field for a loaded task
+func taskSortValue(task *models.Task, field string) interface{} {
+	switch field {
+	case "created_at":
+		return task.CreatedAt
+	case "updated_at":
+		return task.UpdatedAt
+	case "due_date":
+		if task.DueDate == nil {
+			return noDueDate
+		}
+		return *task.DueDate
+	case "priority":
+		return models.PriorityRank(task.Priority)
+	case "status":
+		return task.Status
+	case "title":
+		return task.Title
+	default:
+		return task.ID.String()
+	}
+}
+
+// encodeTaskCursor turns the sort values of the last task of a page into an opaque token
+func encodeTaskCursor(task *models.Task, keys []TaskSortKey) (string, error) {
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		values[i] = taskSortValue(task, key.Field)
+	}
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeTaskCursor restores typed sort values from a cursor created with the same sort
+func decodeTaskCursor(cursor string, keys []TaskSortKey) ([]interface{}, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var values []interface{}
+	if err := json.Unmarshal(raw, &values); err != nil || len(values) != len(keys) {
+		return nil, ErrInvalidCursor
+	}
+
+	for i, key := range keys {
+		switch taskSortFields[key.Field].kind {
+		case sortKindTime:
+			s, ok := values[i].(string)
+			if !ok {
+				return nil, ErrInvalidCursor
+			}
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return nil, ErrInvalidCursor
+			}
+			values[i] = t
+		case sortKindInt:
+			n, ok := values[i].(float64)
+			if !ok {
+				return nil, ErrInvalidCursor
+			}
+			values[i] = int(n)
+		default:
+			if _, ok := values[i].(string); !ok {
+				return nil, ErrInvalidCursor
+			}
+		}
+	}
+	return values, nil
+}
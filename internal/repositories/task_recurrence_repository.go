@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateRecurringTask stores a new series and its first occurrence together
+func (repo *TaskRepositoryImpl) CreateRecurringTask(series *models.RecurrenceSeries, task *models.Task) (*models.Task, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(series).Error; err != nil {
+			return err
+		}
+		task.SeriesID = &series.ID
+		task.OccurrenceIndex = 0
+		return tx.Create(task).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// GetSeries returns a recurrence series owned by the user
+func (repo *TaskRepositoryImpl) GetSeries(seriesID, userID uuid.UUID) (*models.RecurrenceSeries, error) {
+	var series models.RecurrenceSeries
+	if err := repo.DB.Where("id = ? AND user_id = ?", seriesID, userID).First(&series).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &series, nil
+}
+
+// CreateOccurrence inserts the next occurrence of a series. It returns false when that occurrence
+// already exists, which makes completing the same occurrence twice harmless.
+func (repo *TaskRepositoryImpl) CreateOccurrence(task *models.Task) (bool, error) {
+	result := repo.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(task)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
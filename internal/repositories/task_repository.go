@@ -1,21 +1,405 @@
 package repositories
 
-import "gorm.io/gorm"
+import (
+	"TaskManagmentApis/internal/models"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TaskRepository defines the data access methods for tasks
 type TaskRepository interface {
+	CreateTask(task *models.Task) (*models.Task, error)
+	GetTaskByID(taskID, userID uuid.UUID) (*models.Task, error)
+	UpdateTask(task *models.Task) (*models.Task, error)
+	DeleteTask(taskID, userID uuid.UUID, version int, cascadeSubtasks bool) (bool, error)
+	ListTasks(filter TaskListFilter) ([]models.Task, string, int64, error)
+	SearchTasks(userID, workspaceID uuid.UUID, tsQuery string, limit int) ([]models.TaskSearchHit, error)
+	GetSubtree(rootID, userID uuid.UUID) ([]models.TaskTreeNode, error)
+	MoveTask(taskID, userID uuid.UUID, parentID *uuid.UUID) (bool, error)
+	AddDependency(taskID, blockerID, userID uuid.UUID) (bool, error)
+	RemoveDependency(taskID, blockerID, userID uuid.UUID, version int) (bool, error)
+	GetDependencies(taskID, userID uuid.UUID) (*models.TaskDependencies, error)
+	CountOpenBlockers(taskID uuid.UUID) (int64, error)
+	GetOpenTaskGraph(userID, workspaceID uuid.UUID) ([]models.Task, []models.TaskDependency, error)
+	CreateRecurringTask(series *models.RecurrenceSeries, task *models.Task) (*models.Task, error)
+	GetSeries(seriesID, userID uuid.UUID) (*models.RecurrenceSeries, error)
+	SaveTaskChanges(task *models.Task, series *models.RecurrenceSeries, transition *models.TaskStatusTransition) (*models.Task, error)
+	GetStatusTransitions(taskID uuid.UUID) ([]models.TaskStatusTransition, error)
+	CreateOccurrence(task *models.Task) (bool, error)
+	GetAccessibleTask(taskID, userID uuid.UUID) (*models.Task, error)
+	ChangeAssignees(taskID, ownerID uuid.UUID, version int, add, remove []uuid.UUID) (bool, []uuid.UUID, []uuid.UUID, error)
+	GetAssignees(taskID uuid.UUID) ([]models.TaskAssignee, error)
+	GetAssignmentEvents(taskID uuid.UUID) ([]models.TaskAssignmentEvent, error)
+	ListDeletedTasks(userID, workspaceID uuid.UUID) ([]models.Task, error)
+	RestoreTask(taskID, userID uuid.UUID) (bool, error)
+	PurgeTask(taskID, userID uuid.UUID) (*models.Task, []models.Attachment, error)
+	PurgeDeletedTasks(before time.Time) (int64, []models.Attachment, error)
+	GetAccessibleTasks(taskIDs []uuid.UUID, userID uuid.UUID) ([]models.Task, error)
+	ListTaskIDs(filter TaskListFilter, limit int) ([]uuid.UUID, error)
+	ApplyBulkChanges(changes []BulkTaskChange, atomic bool) ([]error, error)
+	ExportTasks(filter TaskListFilter, fn func(task *models.Task, tagNames []string) error) error
+	GetTaskByExternalID(userID, workspaceID uuid.UUID, externalID string) (*models.Task, error)
+	ImportTask(task *models.Task, tagIDs []uuid.UUID) (bool, error)
+	GetTaskSubscribers(taskID uuid.UUID) (*models.Task, []uuid.UUID, error)
 }
 
 type TaskRepositoryImpl struct {
 	DB *gorm.DB
 }
 
+// ErrTaskCycle is returned when a move would make a task its own ancestor
+var ErrTaskCycle = errors.New("task cannot be moved under itself or one of its subtasks")
+
+// ErrVersionConflict is returned by conditional writes when the row changed since it was read
+var ErrVersionConflict = errors.New("record was modified concurrently")
+
+// bumpVersion is assigned to the version column by every write that changes what clients see
+var bumpVersion = gorm.Expr("version + 1")
+
+// taskMemberScope limits task lookups by ID to workspaces the user (bound to ?) is still a member of
+const taskMemberScope = "EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = tasks.workspace_id AND wm.user_id = ?)"
+
 func NewTaskRepository(db *gorm.DB) TaskRepository {
 	return &TaskRepositoryImpl{
 		DB: db,
 	}
 }
 
-// create
-// update
-// retrieve
-// delete
+// CreateTask
+func (repo *TaskRepositoryImpl) CreateTask(task *models.Task) (*models.Task, error) {
+	if err := repo.DB.Create(task).Error; err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// GetTaskByID returns the task only if it belongs to the given user and a workspace they are in
+func (repo *TaskRepositoryImpl) GetTaskByID(taskID, userID uuid.UUID) (*models.Task, error) {
+	var task models.Task
+	err := repo.DB.Preload("Tags").Preload("Assignees").
+		Where("id = ? AND user_id = ?", taskID, userID).
+		Where(taskMemberScope, userID).
+		First(&task).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+// UpdateTask saves the task, scoped to its owner, if its version is still the one it was read at.
+// It returns nil when the task is gone and ErrVersionConflict when someone else changed it first.
+func (repo *TaskRepositoryImpl) UpdateTask(task *models.Task) (*models.Task, error) {
+	result := repo.DB.Model(&models.Task{}).
+		Where("id = ? AND user_id = ? AND version = ?", task.ID, task.UserID, task.Version).
+		Select("title", "description", "status", "priority", "due_date", "series_id", "occurrence_index", "updated_at", "version").
+		Updates(map[string]interface{}{
+			"title":            task.Title,
+			"description":      task.Description,
+			"status":           task.Status,
+			"priority":         task.Priority,
+			"due_date":         task.DueDate,
+			"series_id":        task.SeriesID,
+			"occurrence_index": task.OccurrenceIndex,
+			"updated_at":       time.Now(),
+			"version":          bumpVersion,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		var count int64
+		if err := repo.DB.Model(&models.Task{}).Where("id = ? AND user_id = ?", task.ID, task.UserID).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, ErrVersionConflict
+		}
+		return nil, nil
+	}
+	task.Version++
+	return task, nil
+}
+
+// SaveTaskChanges updates the task together with an optional series template and status
+// transition record in one transaction. Nil parts are skipped.
+func (repo *TaskRepositoryImpl) SaveTaskChanges(task *models.Task, series *models.RecurrenceSeries, transition *models.TaskStatusTransition) (*models.Task, error) {
+	var updated *models.Task
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if series != nil {
+			if err := tx.Save(series).Error; err != nil {
+				return err
+			}
+		}
+
+		var err error
+		updated, err = (&TaskRepositoryImpl{DB: tx}).UpdateTask(task)
+		if err != nil || updated == nil {
+			return err
+		}
+
+		if transition != nil {
+			if err := tx.Create(transition).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// GetStatusTransitions returns the status history of a task, oldest first
+func (repo *TaskRepositoryImpl) GetStatusTransitions(taskID uuid.UUID) ([]models.TaskStatusTransition, error) {
+	var transitions []models.TaskStatusTransition
+	if err := repo.DB.Where("task_id = ?", taskID).Order("created_at, id").Find(&transitions).Error; err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
+
+// DeleteTask moves the task to the trash if it belongs to the given user and reports whether it did.
+// With cascadeSubtasks the whole subtree goes with it, stamped with the same deleted_at so that it
+// can be restored together; otherwise direct children move up to the task's parent. A non-zero
+// version must match the task's current one, otherwise ErrVersionConflict is returned.
+func (repo *TaskRepositoryImpl) DeleteTask(taskID, userID uuid.UUID, version int, cascadeSubtasks bool) (bool, error) {
+	deleted := false
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		var task models.Task
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", taskID, userID).Where(taskMemberScope, userID).First(&task).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		if version != 0 && task.Version != version {
+			return ErrVersionConflict
+		}
+
+		now := time.Now()
+		if cascadeSubtasks {
+			// subtasks already in the trash keep their own deleted_at
+			result := tx.Exec(`
+				WITH RECURSIVE subtree AS (
+					SELECT id FROM tasks WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+					UNION ALL
+					SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+				)
+				UPDATE tasks SET deleted_at = ?, version = version + 1 WHERE id IN (SELECT id FROM subtree)`, taskID, userID, now)
+			if result.Error != nil {
+				return result.Error
+			}
+			deleted = result.RowsAffected > 0
+			return nil
+		}
+
+		if err := tx.Model(&models.Task{}).
+			Where("parent_id = ? AND user_id = ?", taskID, userID).
+			Updates(map[string]interface{}{"parent_id": task.ParentID, "version": bumpVersion}).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&models.Task{}).Where("id = ? AND user_id = ?", taskID, userID).
+			Updates(map[string]interface{}{"deleted_at": now, "version": bumpVersion})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted = result.RowsAffected > 0
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return deleted, nil
+}
+
+// ListTasks returns one keyset page of tasks, the cursor for the next page and the total number of matches
+func (repo *TaskRepositoryImpl) ListTasks(filter TaskListFilter) ([]models.Task, string, int64, error) {
+	keys := filter.normalizedSort()
+
+	var after []interface{}
+	if filter.Cursor != "" {
+		values, err := decodeTaskCursor(filter.Cursor, keys)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		after = values
+	}
+
+	var total int64
+	if err := applyTaskFilters(repo.DB.Model(&models.Task{}), filter).Count(&total).Error; err != nil {
+		return nil, "", 0, err
+	}
+
+	// fetch one extra row to know whether there is a next page
+	var tasks []models.Task
+	query := applyTaskKeyset(applyTaskFilters(repo.DB.Model(&models.Task{}).Preload("Tags"), filter), keys, after)
+	if err := query.Limit(filter.Limit + 1).Find(&tasks).Error; err != nil {
+		return nil, "", 0, err
+	}
+
+	nextCursor := ""
+	if len(tasks) > filter.Limit {
+		tasks = tasks[:filter.Limit]
+		cursor, err := encodeTaskCursor(&tasks[len(tasks)-1], keys)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		nextCursor = cursor
+	}
+	return tasks, nextCursor, total, nil
+}
+
+// taskSearchRow is the raw row returned by the full-text search query
+type taskSearchRow struct {
+	models.Task
+	Rank               float64
+	TitleHighlight     string
+	DescriptionSnippet string
+}
+
+// SearchTasks runs a ranked full-text search over the titles and descriptions of the user's tasks
+// in one workspace. tsQuery must already be in to_tsquery syntax.
+func (repo *TaskRepositoryImpl) SearchTasks(userID, workspaceID uuid.UUID, tsQuery string, limit int) ([]models.TaskSearchHit, error) {
+	var rows []taskSearchRow
+	err := repo.DB.Raw(`
+		SELECT tasks.*,
+			ts_rank_cd(tasks.search_vector, query) AS rank,
+			ts_headline('english', tasks.title, query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS title_highlight,
+			ts_headline('english', coalesce(tasks.description, ''), query, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5') AS description_snippet
+		FROM tasks, to_tsquery('english', ?) AS query
+		WHERE tasks.user_id = ? AND tasks.workspace_id = ? AND tasks.deleted_at IS NULL AND tasks.search_vector @@ query
+		ORDER BY rank DESC, tasks.created_at DESC, tasks.id DESC
+		LIMIT ?`, tsQuery, userID, workspaceID, limit).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]models.TaskSearchHit, len(rows))
+	for i, row := range rows {
+		hits[i] = models.TaskSearchHit{
+			Task:               row.Task,
+			Rank:               row.Rank,
+			TitleHighlight:     row.TitleHighlight,
+			DescriptionSnippet: row.DescriptionSnippet,
+		}
+	}
+	return hits, nil
+}
+
+// taskTreeRow is a task together with its depth below the subtree root
+type taskTreeRow struct {
+	models.Task
+	Depth int
+}
+
+// GetSubtree loads a task and all of its descendants in one recursive query, ordered by depth
+func (repo *TaskRepositoryImpl) GetSubtree(rootID, userID uuid.UUID) ([]models.TaskTreeNode, error) {
+	var rows []taskTreeRow
+	err := repo.DB.Raw(`
+		WITH RECURSIVE subtree AS (
+			SELECT tasks.*, 0 AS depth FROM tasks WHERE id = ? AND user_id = ? AND deleted_at IS NULL AND `+taskMemberScope+`
+			UNION ALL
+			SELECT t.*, s.depth + 1 FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+		)
+		SELECT * FROM subtree ORDER BY depth, created_at, id`, rootID, userID, userID).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]models.TaskTreeNode, len(rows))
+	for i, row := range rows {
+		nodes[i] = models.TaskTreeNode{Task: row.Task, Depth: row.Depth}
+	}
+	return nodes, nil
+}
+
+// MoveTask re-parents a task and its subtree. A nil parentID moves it to the top level.
+// It returns false when the task (or the new parent) does not belong to the user, or the parent lies
+// in another workspace, and ErrTaskCycle
+// when the new parent lies inside the moved subtree.
+func (repo *TaskRepositoryImpl) MoveTask(taskID, userID uuid.UUID, parentID *uuid.UUID) (bool, error) {
+	moved := false
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		// serialize hierarchy changes per user so two concurrent moves cannot build a cycle together
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "task-tree:"+userID.String()).Error; err != nil {
+			return err
+		}
+
+		var task models.Task
+		err := tx.Select("id", "workspace_id").Where("id = ? AND user_id = ?", taskID, userID).Where(taskMemberScope, userID).First(&task).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		if parentID != nil {
+			var count int64
+			if err := tx.Model(&models.Task{}).
+				Where("id = ? AND user_id = ? AND workspace_id = ?", *parentID, userID, task.WorkspaceID).
+				Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return nil
+			}
+
+			var inSubtree bool
+			err := tx.Raw(`
+				WITH RECURSIVE subtree AS (
+					SELECT id FROM tasks WHERE id = ?
+					UNION ALL
+					SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id
+				)
+				SELECT EXISTS (SELECT 1 FROM subtree WHERE id = ?)`, taskID, *parentID).Scan(&inSubtree).Error
+			if err != nil {
+				return err
+			}
+			if inSubtree {
+				return ErrTaskCycle
+			}
+		}
+
+		if err := tx.Model(&models.Task{}).
+			Where("id = ? AND user_id = ?", taskID, userID).
+			Updates(map[string]interface{}{"parent_id": parentID, "version": bumpVersion}).Error; err != nil {
+			return fmt.Errorf("failed to move task: %w", err)
+		}
+		moved = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return moved, nil
+}
+
+// GetTaskSubscribers returns a task, trashed or not, with the users who follow its changes:
+// its owner and assignees who are still members of its workspace. The task is nil once it has been purged.
+func (repo *TaskRepositoryImpl) GetTaskSubscribers(taskID uuid.UUID) (*models.Task, []uuid.UUID, error) {
+	var task models.Task
+	if err := repo.DB.Unscoped().Preload("Tags").Where("id = ?", taskID).First(&task).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	var userIDs []uuid.UUID
+	err := repo.DB.Raw(`SELECT r.user_id FROM (
+		SELECT CAST(? AS uuid) AS user_id UNION SELECT user_id FROM task_assignees WHERE task_id = ?
+	) r WHERE EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspace_id = ? AND wm.user_id = r.user_id)`,
+		task.UserID, taskID, task.WorkspaceID,
+	).Scan(&userIDs).Error
+	return &task, userIDs, err
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// tagNameSeparator joins tag names in the export query; it cannot be typed into a tag name
+const tagNameSeparator = "\x1f"
+
+// taskExportRow is a task with the names of its tags joined by tagNameSeparator
+type taskExportRow struct {
+	models.Task
+	TagNames string
+}
+
+// ExportTasks streams every task matching the filter to fn, oldest first, together with the names
+// of its tags, without loading them all into memory. Sort, cursor and page size are ignored.
+func (repo *TaskRepositoryImpl) ExportTasks(filter TaskListFilter, fn func(task *models.Task, tagNames []string) error) error {
+	rows, err := applyTaskFilters(repo.DB.Model(&models.Task{}), filter).
+		Select(`tasks.*, (SELECT string_agg(tg.name, chr(31) ORDER BY lower(tg.name))
+			FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = tasks.id) AS tag_names`).
+		Order("created_at, id").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row taskExportRow
+		if err := repo.DB.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		var tagNames []string
+		if row.TagNames != "" {
+			tagNames = strings.Split(row.TagNames, tagNameSeparator)
+		}
+		if err := fn(&row.Task, tagNames); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// GetTaskByExternalID finds the user's task in a workspace by its import ID, including trashed tasks
+func (repo *TaskRepositoryImpl) GetTaskByExternalID(userID, workspaceID uuid.UUID, externalID string) (*models.Task, error) {
+	var task models.Task
+	err := repo.DB.Unscoped().
+		Where("user_id = ? AND workspace_id = ? AND external_id = ?", userID, workspaceID, externalID).
+		First(&task).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+// ImportTask creates an imported task with its tags and reports whether it did. It creates nothing
+// when the user already has a task with the same external ID in the workspace.
+func (repo *TaskRepositoryImpl) ImportTask(task *models.Task, tagIDs []uuid.UUID) (bool, error) {
+	created := false
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "user_id"}, {Name: "workspace_id"}, {Name: "external_id"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "external_id IS NOT NULL"}}},
+			DoNothing:   true,
+		}).Create(task)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		created = true
+
+		for _, tagID := range tagIDs {
+			if err := tx.Exec(
+				"INSERT INTO task_tags (task_id, tag_id) VALUES (?, ?) ON CONFLICT DO NOTHING", task.ID, tagID,
+			).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return created, nil
+}
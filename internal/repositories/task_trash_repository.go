@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ListDeletedTasks returns the user's trashed tasks in a workspace, most recently deleted first
+func (repo *TaskRepositoryImpl) ListDeletedTasks(userID, workspaceID uuid.UUID) ([]models.Task, error) {
+	var tasks []models.Task
+	err := repo.DB.Unscoped().
+		Where("user_id = ? AND workspace_id = ? AND deleted_at IS NOT NULL", userID, workspaceID).
+		Where(taskMemberScope, userID).
+		Order("deleted_at DESC, id").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// getDeletedTask loads one of the user's trashed tasks, or nil when there is none
+func getDeletedTask(tx *gorm.DB, taskID, userID uuid.UUID) (*models.Task, error) {
+	var task models.Task
+	err := tx.Unscoped().
+		Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", taskID, userID).
+		Where(taskMemberScope, userID).
+		First(&task).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+// RestoreTask takes a task out of the trash together with the subtasks that were deleted with it.
+// If its parent is still in the trash the task comes back at the top level. It returns false when
+// the user has no such task in the trash.
+func (repo *TaskRepositoryImpl) RestoreTask(taskID, userID uuid.UUID) (bool, error) {
+	restored := false
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		// same lock as MoveTask: restoring changes the visible hierarchy
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "task-tree:"+userID.String()).Error; err != nil {
+			return err
+		}
+
+		task, err := getDeletedTask(tx, taskID, userID)
+		if err != nil || task == nil {
+			return err
+		}
+
+		if err := tx.Exec(`
+			WITH RECURSIVE batch AS (
+				SELECT id FROM tasks WHERE id = ?
+				UNION ALL
+				SELECT t.id FROM tasks t JOIN batch b ON t.parent_id = b.id WHERE t.deleted_at = ?
+			)
+			UPDATE tasks SET deleted_at = NULL, version = version + 1 WHERE id IN (SELECT id FROM batch)`, taskID, task.DeletedAt.Time).Error; err != nil {
+			return err
+		}
+
+		if task.ParentID != nil {
+			var parentActive int64
+			if err := tx.Model(&models.Task{}).Where("id = ?", *task.ParentID).Count(&parentActive).Error; err != nil {
+				return err
+			}
+			if parentActive == 0 {
+				if err := tx.Model(&models.Task{}).Where("id = ?", taskID).
+					Updates(map[string]interface{}{"parent_id": nil, "version": bumpVersion}).Error; err != nil {
+					return err
+				}
+			}
+		}
+		restored = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return restored, nil
+}
+
+// PurgeTask permanently deletes one of the user's trashed tasks with its trashed subtree and
+// returns the task as it was, or nil when the user has no such task in the trash, along with the
+// stored objects of the attachments that went with it
+func (repo *TaskRepositoryImpl) PurgeTask(taskID, userID uuid.UUID) (*models.Task, []models.Attachment, error) {
+	var purged *models.Task
+	var objects []models.Attachment
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		task, err := getDeletedTask(tx, taskID, userID)
+		if err != nil || task == nil {
+			return err
+		}
+
+		// a trashed task only ever has trashed subtasks: deleting moves active ones up or takes
+		// them along, and restoring a subtask of a trashed task detaches it
+		var subtree []uuid.UUID
+		if err := tx.Raw(`
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM tasks WHERE id = ?
+				UNION ALL
+				SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id
+			)
+			SELECT id FROM subtree`, taskID).Scan(&subtree).Error; err != nil {
+			return err
+		}
+		if objects, err = attachmentObjects(tx, "task_id IN ?", subtree); err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM tasks WHERE id IN ?", subtree).Error; err != nil {
+			return err
+		}
+		purged = task
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return purged, objects, nil
+}
+
+// PurgeDeletedTasks permanently deletes every task that went to the trash before the cutoff and
+// returns how many rows were removed and the stored objects of the attachments that went with them
+func (repo *TaskRepositoryImpl) PurgeDeletedTasks(before time.Time) (int64, []models.Attachment, error) {
+	var purged int64
+	var objects []models.Attachment
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		// subtasks trashed later than their parent are kept; they lose the parent instead
+		if err := tx.Exec(`
+			UPDATE tasks SET parent_id = NULL
+			WHERE parent_id IN (SELECT id FROM tasks WHERE deleted_at < ?)
+				AND (deleted_at IS NULL OR deleted_at >= ?)`, before, before).Error; err != nil {
+			return err
+		}
+		var err error
+		if objects, err = attachmentObjects(tx, "task_id IN (SELECT id FROM tasks WHERE deleted_at < ?)", before); err != nil {
+			return err
+		}
+		result := tx.Exec("DELETE FROM tasks WHERE deleted_at < ?", before)
+		if result.Error != nil {
+			return result.Error
+		}
+		purged = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return purged, objects, nil
+}
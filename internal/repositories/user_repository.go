@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrEmailInUse is returned when restoring a user whose email now belongs to another account
+var ErrEmailInUse = errors.New("email is already used by another account")
+
+// ListUsers returns one page of users ordered by sign-up date, and the total number of matches
+func (repo *AuthRepositoryImpl) ListUsers(role string, limit, offset int) ([]models.User, int64, error) {
+	query := repo.DB.Model(&models.User{})
+	if role != "" {
+		query = query.Where("role = ?", role)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []models.User
+	if err := query.Order("created_at, id").Limit(limit).Offset(offset).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// UpdateUserRole sets the system role of a user and reports whether the user exists. A non-zero
+// version must match the user's current one, otherwise ErrVersionConflict is returned.
+func (repo *AuthRepositoryImpl) UpdateUserRole(userID uuid.UUID, version int, role string) (bool, error) {
+	query := repo.DB.Model(&models.User{}).Where("id = ?", userID)
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+	result := query.Updates(map[string]interface{}{"role": role, "version": bumpVersion})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		if err := userVersionConflict(repo.DB, userID); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// userVersionConflict tells apart why a conditional write to a user matched no row: ErrVersionConflict
+// if the user still exists, nil if it is gone
+func userVersionConflict(db *gorm.DB, userID uuid.UUID) error {
+	var count int64
+	if err := db.Model(&models.User{}).Where("id = ?", userID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// ListDeletedUsers returns the users in the trash, most recently deleted first
+func (repo *AuthRepositoryImpl) ListDeletedUsers() ([]models.User, error) {
+	var users []models.User
+	if err := repo.DB.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC, id").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// RestoreUser takes a user out of the trash. It returns false when the user is not in the trash and
+// ErrEmailInUse when someone has signed up with the same email in the meantime.
+func (repo *AuthRepositoryImpl) RestoreUser(userID uuid.UUID) (bool, error) {
+	restored := false
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NOT NULL", userID).First(&user).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		var taken int64
+		if err := tx.Model(&models.User{}).Where("email = ?", user.Email).Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			return ErrEmailInUse
+		}
+
+		if err := tx.Unscoped().Model(&models.User{}).Where("id = ?", userID).
+			Updates(map[string]interface{}{"deleted_at": nil, "version": bumpVersion}).Error; err != nil {
+			return err
+		}
+		restored = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return restored, nil
+}
+
+// PurgeDeletedUsers permanently deletes every user that went to the trash before the cutoff,
+// together with everything they own through the ON DELETE CASCADE foreign keys. It also returns
+// the stored objects of the attachments the cascade removes: those they uploaded and those on their tasks.
+func (repo *AuthRepositoryImpl) PurgeDeletedUsers(before time.Time) (int64, []models.Attachment, error) {
+	var purged int64
+	var objects []models.Attachment
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		purgedUsers := tx.Unscoped().Model(&models.User{}).Select("id").Where("deleted_at < ?", before)
+		var err error
+		objects, err = attachmentObjects(tx, "uploader_id IN (?) OR task_id IN (?)",
+			purgedUsers, tx.Unscoped().Model(&models.Task{}).Select("id").Where("user_id IN (?)", purgedUsers))
+		if err != nil {
+			return err
+		}
+		result := tx.Unscoped().Where("deleted_at < ?", before).Delete(&models.User{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return purged, objects, nil
+}
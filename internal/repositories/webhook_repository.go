@@ -0,0 +1,232 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WebhookRepository defines the data access methods for webhooks and their deliveries
+type WebhookRepository interface {
+	CreateWebhook(webhook *models.Webhook) error
+	GetWebhook(webhookID, userID uuid.UUID) (*models.Webhook, error)
+	ListWebhooks(userID uuid.UUID) ([]models.Webhook, error)
+	UpdateWebhook(webhook *models.Webhook) error
+	DeleteWebhook(webhookID, userID uuid.UUID) (bool, error)
+	ListSubscribedWebhooks(userIDs []uuid.UUID, event string) ([]models.Webhook, error)
+	EnqueueDeliveries(deliveries []models.WebhookDelivery) error
+	ListDeliveries(webhookID uuid.UUID, status string, limit int) ([]models.WebhookDelivery, error)
+	GetDelivery(webhookID, deliveryID uuid.UUID) (*models.WebhookDelivery, error)
+	Redeliver(original *models.WebhookDelivery) (*models.WebhookDelivery, error)
+	ClaimDueDeliveries(now time.Time, lease time.Duration, limit, breakerThreshold int) ([]models.WebhookDelivery, error)
+	SaveDeliveryAttempt(delivery *models.WebhookDelivery) error
+	RecordWebhookSuccess(webhookID uuid.UUID) error
+	RecordWebhookFailure(webhookID uuid.UUID) (int, error)
+	OpenCircuit(webhookID uuid.UUID, until time.Time) error
+}
+
+type WebhookRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &WebhookRepositoryImpl{
+		DB: db,
+	}
+}
+
+// CreateWebhook
+func (repo *WebhookRepositoryImpl) CreateWebhook(webhook *models.Webhook) error {
+	return repo.DB.Create(webhook).Error
+}
+
+// GetWebhook returns one of the user's webhooks, nil if they have no such webhook
+func (repo *WebhookRepositoryImpl) GetWebhook(webhookID, userID uuid.UUID) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := repo.DB.Where("id = ? AND user_id = ?", webhookID, userID).First(&webhook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// ListWebhooks returns the user's webhooks, oldest first
+func (repo *WebhookRepositoryImpl) ListWebhooks(userID uuid.UUID) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := repo.DB.Where("user_id = ?", userID).Order("created_at, id").Find(&webhooks).Error
+	return webhooks, err
+}
+
+// UpdateWebhook saves the fields a user can change, including the secret
+func (repo *WebhookRepositoryImpl) UpdateWebhook(webhook *models.Webhook) error {
+	return repo.DB.Model(webhook).Select("url", "description", "events", "secret", "active", "updated_at").Updates(webhook).Error
+}
+
+// DeleteWebhook removes the webhook and its delivery log
+func (repo *WebhookRepositoryImpl) DeleteWebhook(webhookID, userID uuid.UUID) (bool, error) {
+	result := repo.DB.Where("id = ? AND user_id = ?", webhookID, userID).Delete(&models.Webhook{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListSubscribedWebhooks returns the active webhooks of any of the users that subscribe to event
+func (repo *WebhookRepositoryImpl) ListSubscribedWebhooks(userIDs []uuid.UUID, event string) ([]models.Webhook, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	var webhooks []models.Webhook
+	err := repo.DB.
+		Where("user_id IN ? AND active", userIDs).
+		Where("',' || events || ',' LIKE ?", "%,"+event+",%").
+		Find(&webhooks).Error
+	return webhooks, err
+}
+
+// EnqueueDeliveries
+func (repo *WebhookRepositoryImpl) EnqueueDeliveries(deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return repo.DB.Create(&deliveries).Error
+}
+
+// ListDeliveries returns the webhook's newest deliveries first, optionally only those with status
+func (repo *WebhookRepositoryImpl) ListDeliveries(webhookID uuid.UUID, status string, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	query := repo.DB.Where("webhook_id = ?", webhookID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("created_at DESC, id DESC").Limit(limit).Find(&deliveries).Error
+	return deliveries, err
+}
+
+// GetDelivery
+func (repo *WebhookRepositoryImpl) GetDelivery(webhookID, deliveryID uuid.UUID) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := repo.DB.Where("id = ? AND webhook_id = ?", deliveryID, webhookID).First(&delivery).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// Redeliver queues the original's event again as a new delivery. Asking for one means the user
+// believes the endpoint works again, so an open circuit is closed to let it through right away.
+func (repo *WebhookRepositoryImpl) Redeliver(original *models.WebhookDelivery) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{
+		WebhookID:     original.WebhookID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: time.Now(),
+	}
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(delivery).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Webhook{}).Where("id = ?", original.WebhookID).Update("circuit_open_until", nil).Error
+	})
+	return delivery, err
+}
+
+// ClaimDueDeliveries picks up to limit pending deliveries that are due, for active webhooks whose
+// circuit is closed, counts the attempt and pushes their next attempt back by lease. A webhook
+// with at least breakerThreshold failures in a row is half-open: it gets a single trial delivery.
+func (repo *WebhookRepositoryImpl) ClaimDueDeliveries(now time.Time, lease time.Duration, limit, breakerThreshold int) ([]models.WebhookDelivery, error) {
+	var claimed []models.WebhookDelivery
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		var due []models.WebhookDelivery
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+			Where(`EXISTS (SELECT 1 FROM webhooks w WHERE w.id = webhook_deliveries.webhook_id
+				AND w.active AND (w.circuit_open_until IS NULL OR w.circuit_open_until <= ?))`, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&due).Error; err != nil {
+			return err
+		}
+		if len(due) == 0 {
+			return nil
+		}
+
+		webhookIDs := make([]uuid.UUID, len(due))
+		for i := range due {
+			webhookIDs[i] = due[i].WebhookID
+		}
+		var webhooks []models.Webhook
+		if err := tx.Where("id IN ?", webhookIDs).Find(&webhooks).Error; err != nil {
+			return err
+		}
+		byID := make(map[uuid.UUID]*models.Webhook, len(webhooks))
+		for i := range webhooks {
+			byID[webhooks[i].ID] = &webhooks[i]
+		}
+
+		trials := make(map[uuid.UUID]bool)
+		var ids []uuid.UUID
+		for _, delivery := range due {
+			delivery.Webhook = byID[delivery.WebhookID]
+			if delivery.Webhook.ConsecutiveFailures >= breakerThreshold {
+				if trials[delivery.WebhookID] {
+					continue
+				}
+				trials[delivery.WebhookID] = true
+			}
+			delivery.Attempts++
+			claimed = append(claimed, delivery)
+			ids = append(ids, delivery.ID)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": now.Add(lease),
+		}).Error
+	})
+	return claimed, err
+}
+
+// SaveDeliveryAttempt stores the outcome of the delivery's latest attempt
+func (repo *WebhookRepositoryImpl) SaveDeliveryAttempt(delivery *models.WebhookDelivery) error {
+	return repo.DB.Model(delivery).Updates(map[string]interface{}{
+		"status":          delivery.Status,
+		"next_attempt_at": delivery.NextAttemptAt,
+		"response_status": delivery.ResponseStatus,
+		"last_error":      delivery.LastError,
+		"duration_ms":     delivery.DurationMs,
+		"delivered_at":    delivery.DeliveredAt,
+	}).Error
+}
+
+// RecordWebhookSuccess closes the webhook's circuit
+func (repo *WebhookRepositoryImpl) RecordWebhookSuccess(webhookID uuid.UUID) error {
+	return repo.DB.Model(&models.Webhook{}).Where("id = ?", webhookID).Updates(map[string]interface{}{
+		"consecutive_failures": 0,
+		"circuit_open_until":   nil,
+	}).Error
+}
+
+// RecordWebhookFailure counts a failed delivery and returns the webhook's failures in a row
+func (repo *WebhookRepositoryImpl) RecordWebhookFailure(webhookID uuid.UUID) (int, error) {
+	var failures int
+	err := repo.DB.Raw(
+		"UPDATE webhooks SET consecutive_failures = consecutive_failures + 1 WHERE id = ? RETURNING consecutive_failures",
+		webhookID,
+	).Scan(&failures).Error
+	return failures, err
+}
+
+// OpenCircuit holds back the webhook's deliveries until the given time
+func (repo *WebhookRepositoryImpl) OpenCircuit(webhookID uuid.UUID, until time.Time) error {
+	return repo.DB.Model(&models.Webhook{}).Where("id = ?", webhookID).Update("circuit_open_until", until).Error
+}
@@ -0,0 +1,229 @@
+package repositories
+
+import (
+	"TaskManagmentApis/internal/models"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrLastWorkspaceOwner is returned when a change would leave a workspace without an owner
+var ErrLastWorkspaceOwner = errors.New("workspace must keep at least one owner")
+
+// activeMemberScope hides memberships of users who are in the trash
+const activeMemberScope = "EXISTS (SELECT 1 FROM users u WHERE u.id = workspace_members.user_id AND u.deleted_at IS NULL)"
+
+// WorkspaceRepository defines the data access methods for workspaces and their members
+type WorkspaceRepository interface {
+	CreateWorkspace(workspace *models.Workspace, ownerID uuid.UUID) (*models.Workspace, error)
+	GetWorkspace(workspaceID uuid.UUID) (*models.Workspace, error)
+	UpdateWorkspace(workspace *models.Workspace) (*models.Workspace, error)
+	DeleteWorkspace(workspaceID uuid.UUID) (bool, []models.Attachment, error)
+	GetMembership(workspaceID, userID uuid.UUID) (*models.WorkspaceMember, error)
+	ListMemberships(userID uuid.UUID) ([]models.WorkspaceMember, error)
+	ListMembers(workspaceID uuid.UUID) ([]models.WorkspaceMember, error)
+	AddMember(member *models.WorkspaceMember) (bool, error)
+	SetMemberRole(workspaceID, userID uuid.UUID, role string) (bool, error)
+	RemoveMember(workspaceID, userID uuid.UUID) (bool, error)
+	CountMembers(workspaceID uuid.UUID, userIDs []uuid.UUID) (int64, error)
+	SetActiveWorkspace(userID uuid.UUID, workspaceID *uuid.UUID) error
+}
+
+type WorkspaceRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func NewWorkspaceRepository(db *gorm.DB) WorkspaceRepository {
+	return &WorkspaceRepositoryImpl{
+		DB: db,
+	}
+}
+
+// CreateWorkspace creates the workspace with ownerID as its first owner
+func (repo *WorkspaceRepositoryImpl) CreateWorkspace(workspace *models.Workspace, ownerID uuid.UUID) (*models.Workspace, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(workspace).Error; err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(&models.WorkspaceMember{
+			WorkspaceID: workspace.ID,
+			UserID:      ownerID,
+			Role:        models.WorkspaceRoleOwner,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// GetWorkspace
+func (repo *WorkspaceRepositoryImpl) GetWorkspace(workspaceID uuid.UUID) (*models.Workspace, error) {
+	var workspace models.Workspace
+	if err := repo.DB.Where("id = ?", workspaceID).First(&workspace).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// UpdateWorkspace
+func (repo *WorkspaceRepositoryImpl) UpdateWorkspace(workspace *models.Workspace) (*models.Workspace, error) {
+	err := repo.DB.Model(&models.Workspace{}).
+		Where("id = ?", workspace.ID).
+		Select("name", "updated_at").
+		Updates(workspace).Error
+	if err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// DeleteWorkspace removes a workspace with all of its tasks and projects and returns the stored
+// objects of the attachments that went with them
+func (repo *WorkspaceRepositoryImpl) DeleteWorkspace(workspaceID uuid.UUID) (bool, []models.Attachment, error) {
+	deleted := false
+	var objects []models.Attachment
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		objects, err = attachmentObjects(tx, "task_id IN (SELECT id FROM tasks WHERE workspace_id = ?)", workspaceID)
+		if err != nil {
+			return err
+		}
+		result := tx.Where("id = ?", workspaceID).Delete(&models.Workspace{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted = result.RowsAffected > 0
+		return nil
+	})
+	if err != nil || !deleted {
+		return false, nil, err
+	}
+	return true, objects, nil
+}
+
+// GetMembership returns the user's membership in the workspace, or nil if they are not a member
+func (repo *WorkspaceRepositoryImpl) GetMembership(workspaceID, userID uuid.UUID) (*models.WorkspaceMember, error) {
+	var member models.WorkspaceMember
+	err := repo.DB.Preload("Workspace").
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+// ListMemberships returns every workspace the user belongs to, oldest membership first
+func (repo *WorkspaceRepositoryImpl) ListMemberships(userID uuid.UUID) ([]models.WorkspaceMember, error) {
+	var members []models.WorkspaceMember
+	if err := repo.DB.Preload("Workspace").Where("user_id = ?", userID).Order("joined_at, workspace_id").Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// ListMembers returns the members of a workspace with their user records
+func (repo *WorkspaceRepositoryImpl) ListMembers(workspaceID uuid.UUID) ([]models.WorkspaceMember, error) {
+	var members []models.WorkspaceMember
+	if err := repo.DB.Preload("User").Where("workspace_id = ?", workspaceID).Where(activeMemberScope).
+		Order("joined_at, user_id").Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// AddMember adds a membership; it returns false if the user already belongs to the workspace
+func (repo *WorkspaceRepositoryImpl) AddMember(member *models.WorkspaceMember) (bool, error) {
+	result := repo.DB.Exec(
+		"INSERT INTO workspace_members (workspace_id, user_id, role) VALUES (?, ?, ?) ON CONFLICT DO NOTHING",
+		member.WorkspaceID, member.UserID, member.Role,
+	)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// lockOwners locks the workspace's owner rows and reports whether userID is its only owner
+func lockOwners(tx *gorm.DB, workspaceID, userID uuid.UUID) (bool, error) {
+	var owners []models.WorkspaceMember
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("workspace_id = ? AND role = ?", workspaceID, models.WorkspaceRoleOwner).
+		Find(&owners).Error
+	if err != nil {
+		return false, err
+	}
+	return len(owners) == 1 && owners[0].UserID == userID, nil
+}
+
+// SetMemberRole changes a member's role; demoting the only owner fails with ErrLastWorkspaceOwner
+func (repo *WorkspaceRepositoryImpl) SetMemberRole(workspaceID, userID uuid.UUID, role string) (bool, error) {
+	updated := false
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if role != models.WorkspaceRoleOwner {
+			lastOwner, err := lockOwners(tx, workspaceID, userID)
+			if err != nil {
+				return err
+			}
+			if lastOwner {
+				return ErrLastWorkspaceOwner
+			}
+		}
+		result := tx.Model(&models.WorkspaceMember{}).
+			Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+			Update("role", role)
+		if result.Error != nil {
+			return result.Error
+		}
+		updated = result.RowsAffected > 0
+		return nil
+	})
+	return updated, err
+}
+
+// RemoveMember removes a membership; removing the only owner fails with ErrLastWorkspaceOwner.
+// Users whose active workspace it was fall back to another one on their next token refresh.
+func (repo *WorkspaceRepositoryImpl) RemoveMember(workspaceID, userID uuid.UUID) (bool, error) {
+	removed := false
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		lastOwner, err := lockOwners(tx, workspaceID, userID)
+		if err != nil {
+			return err
+		}
+		if lastOwner {
+			return ErrLastWorkspaceOwner
+		}
+		result := tx.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Delete(&models.WorkspaceMember{})
+		if result.Error != nil {
+			return result.Error
+		}
+		removed = result.RowsAffected > 0
+		return nil
+	})
+	return removed, err
+}
+
+// CountMembers counts how many of the given users belong to the workspace
+func (repo *WorkspaceRepositoryImpl) CountMembers(workspaceID uuid.UUID, userIDs []uuid.UUID) (int64, error) {
+	var count int64
+	err := repo.DB.Model(&models.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id IN ?", workspaceID, userIDs).
+		Where(activeMemberScope).
+		Count(&count).Error
+	return count, err
+}
+
+// SetActiveWorkspace remembers which workspace new access tokens of the user are issued for
+func (repo *WorkspaceRepositoryImpl) SetActiveWorkspace(userID uuid.UUID, workspaceID *uuid.UUID) error {
+	return repo.DB.Model(&models.User{}).Where("id = ?", userID).
+		Updates(map[string]interface{}{"active_workspace_id": workspaceID, "version": bumpVersion}).Error
+}
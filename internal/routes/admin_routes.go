@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"TaskManagmentApis/internal/handlers"
+	"TaskManagmentApis/internal/middleware"
+	"TaskManagmentApis/internal/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAdminRoutes(router *gin.Engine, adminHandler *handlers.AdminHandler) {
+	// every admin route needs a permission on top of a logged in user
+	adminRoutes := router.Group("/admin")
+	adminRoutes.Use(middleware.AuthMiddleware())
+	{
+		adminRoutes.GET("/roles", middleware.RequirePermission(rbac.PermissionUsersRead), adminHandler.ListRoles)
+		adminRoutes.GET("/users", middleware.RequirePermission(rbac.PermissionUsersRead), adminHandler.ListUsers)
+		adminRoutes.PATCH("/users/:userId/role", middleware.RequirePermission(rbac.PermissionUsersManage), adminHandler.UpdateUserRole)
+		adminRoutes.DELETE("/users/:userId", middleware.RequirePermission(rbac.PermissionUsersManage), adminHandler.DeleteUser)
+		adminRoutes.GET("/trash/users", middleware.RequirePermission(rbac.PermissionUsersRead), adminHandler.ListDeletedUsers)
+		adminRoutes.POST("/trash/users/:userId/restore", middleware.RequirePermission(rbac.PermissionUsersManage), adminHandler.RestoreUser)
+	}
+}
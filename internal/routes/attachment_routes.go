@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"TaskManagmentApis/internal/handlers"
+	"TaskManagmentApis/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAttachmentRoutes(router *gin.Engine, attachmentHandler *handlers.AttachmentHandler) {
+	attachmentRoutes := router.Group("/tasks/:id/attachments")
+	attachmentRoutes.Use(middleware.AuthMiddleware())
+	{
+		attachmentRoutes.GET("", attachmentHandler.ListAttachments)
+		attachmentRoutes.POST("", attachmentHandler.UploadAttachment)
+		attachmentRoutes.GET("/:attachmentId/download", attachmentHandler.GetDownloadURL)
+		attachmentRoutes.DELETE("/:attachmentId", attachmentHandler.DeleteAttachment)
+	}
+
+	// signed download links carry their own authorization, so no AuthMiddleware here
+	router.GET("/files/*key", attachmentHandler.ServeSignedFile)
+}
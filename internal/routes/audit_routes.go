@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"TaskManagmentApis/internal/handlers"
+	"TaskManagmentApis/internal/middleware"
+	"TaskManagmentApis/internal/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAuditRoutes(router *gin.Engine, auditHandler *handlers.AuditHandler) {
+	auditRoutes := router.Group("/admin/audit")
+	auditRoutes.Use(middleware.AuthMiddleware(), middleware.RequirePermission(rbac.PermissionAuditRead))
+	{
+		auditRoutes.GET("", auditHandler.ListEvents)
+		auditRoutes.GET("/export", auditHandler.ExportEvents)
+	}
+}
@@ -21,5 +21,8 @@ func SetupAuthRoutes(router *gin.Engine, authHandler *handlers.AuthHandler) {
 		// // Protected route for logout (using middleware)
 		authRoutes.Use(middleware.AuthMiddleware()) // Add your middleware for authentication
 		authRoutes.POST("/logout", authHandler.Logout)
+		authRoutes.GET("/me", authHandler.Me)
+		authRoutes.PUT("/timezone", authHandler.UpdateTimezone)
+		authRoutes.PUT("/reminders", authHandler.UpdateReminderOffsets)
 	}
 }
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"TaskManagmentApis/internal/handlers"
+	"TaskManagmentApis/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupBoardRoutes(router *gin.Engine, boardHandler *handlers.BoardHandler) {
+	boardRoutes := router.Group("/boards")
+	boardRoutes.Use(middleware.AuthMiddleware())
+	{
+		boardRoutes.GET("", boardHandler.ListBoards)
+		boardRoutes.POST("", boardHandler.CreateBoard)
+		boardRoutes.GET("/:boardId", boardHandler.GetBoard)
+		boardRoutes.PATCH("/:boardId", boardHandler.RenameBoard)
+		boardRoutes.DELETE("/:boardId", boardHandler.DeleteBoard)
+
+		boardRoutes.POST("/:boardId/columns", boardHandler.AddColumn)
+		boardRoutes.PATCH("/:boardId/columns/:columnId", boardHandler.UpdateColumn)
+		boardRoutes.DELETE("/:boardId/columns/:columnId", boardHandler.DeleteColumn)
+
+		boardRoutes.POST("/:boardId/cards", boardHandler.AddCard)
+		boardRoutes.POST("/:boardId/cards/:taskId/move", boardHandler.MoveCard)
+		boardRoutes.DELETE("/:boardId/cards/:taskId", boardHandler.RemoveCard)
+	}
+}
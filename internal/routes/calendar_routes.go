@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"TaskManagmentApis/internal/handlers"
+	"TaskManagmentApis/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupCalendarRoutes(router *gin.Engine, calendarHandler *handlers.CalendarHandler) {
+	feedRoutes := router.Group("/calendar/feed")
+	feedRoutes.Use(middleware.AuthMiddleware())
+	{
+		feedRoutes.GET("", calendarHandler.GetFeed)
+		feedRoutes.POST("/token", calendarHandler.RegenerateToken)
+		feedRoutes.DELETE("", calendarHandler.DeleteFeed)
+	}
+
+	// calendar clients cannot send a Bearer header, so the secret token in the URL is the authorization
+	router.GET("/calendar/:token", calendarHandler.ServeFeed)
+}
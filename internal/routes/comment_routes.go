@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"TaskManagmentApis/internal/handlers"
+	"TaskManagmentApis/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupCommentRoutes(router *gin.Engine, commentHandler *handlers.CommentHandler) {
+	commentRoutes := router.Group("/tasks/:id/comments")
+	commentRoutes.Use(middleware.AuthMiddleware())
+	{
+		commentRoutes.GET("", commentHandler.ListComments)
+		commentRoutes.POST("", commentHandler.CreateComment)
+		commentRoutes.PATCH("/:commentId", commentHandler.UpdateComment)
+		commentRoutes.DELETE("/:commentId", commentHandler.DeleteComment)
+		commentRoutes.GET("/:commentId/revisions", commentHandler.GetRevisions)
+	}
+}
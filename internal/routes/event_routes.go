@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"TaskManagmentApis/internal/handlers"
+	"TaskManagmentApis/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupEventRoutes(router *gin.Engine, eventHandler *handlers.EventHandler) {
+	eventRoutes := router.Group("/events")
+	{
+		eventRoutes.GET("", middleware.StreamAuthMiddleware(), eventHandler.Stream)
+		eventRoutes.GET("/token", middleware.AuthMiddleware(), eventHandler.IssueStreamToken)
+	}
+}
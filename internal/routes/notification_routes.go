@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"TaskManagmentApis/internal/handlers"
+	"TaskManagmentApis/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupNotificationRoutes(router *gin.Engine, notificationHandler *handlers.NotificationHandler) {
+	notificationRoutes := router.Group("/notifications")
+	notificationRoutes.Use(middleware.AuthMiddleware())
+	{
+		notificationRoutes.GET("", notificationHandler.ListNotifications)
+		notificationRoutes.POST("/read-all", notificationHandler.MarkAllRead)
+		notificationRoutes.POST("/:notificationId/read", notificationHandler.MarkRead)
+	}
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"TaskManagmentApis/internal/handlers"
+	"TaskManagmentApis/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupProjectRoutes(router *gin.Engine, projectHandler *handlers.ProjectHandler) {
+	projectRoutes := router.Group("/projects")
+	projectRoutes.Use(middleware.AuthMiddleware())
+	{
+		projectRoutes.GET("", projectHandler.ListProjects)
+		projectRoutes.POST("", projectHandler.CreateProject)
+		projectRoutes.POST("/move-tasks", projectHandler.MoveTasks)
+		projectRoutes.GET("/:projectId", projectHandler.GetProject)
+		projectRoutes.PATCH("/:projectId", projectHandler.UpdateProject)
+		projectRoutes.DELETE("/:projectId", projectHandler.DeleteProject)
+		projectRoutes.GET("/:projectId/summary", projectHandler.GetSummary)
+	}
+}
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"TaskManagmentApis/internal/handlers"
+	"TaskManagmentApis/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupTagRoutes(router *gin.Engine, tagHandler *handlers.TagHandler) {
+	tagRoutes := router.Group("/tags")
+	tagRoutes.Use(middleware.AuthMiddleware())
+	{
+		tagRoutes.GET("", tagHandler.ListTags)
+		tagRoutes.POST("", tagHandler.CreateTag)
+		tagRoutes.PATCH("/:tagId", tagHandler.UpdateTag)
+		tagRoutes.DELETE("/:tagId", tagHandler.DeleteTag)
+		tagRoutes.POST("/:tagId/merge", tagHandler.MergeTag)
+	}
+
+	// attaching and detaching tags lives under the task it changes
+	taskTagRoutes := router.Group("/tasks/:id/tags")
+	taskTagRoutes.Use(middleware.AuthMiddleware())
+	{
+		taskTagRoutes.POST("", tagHandler.UpdateTaskTags)
+	}
+}
@@ -1 +1,39 @@
 package routes
+
+import (
+	"TaskManagmentApis/internal/handlers"
+	"TaskManagmentApis/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupTaskRoutes(router *gin.Engine, taskHandler *handlers.TaskHandler) {
+	// every task route requires a logged in user
+	taskRoutes := router.Group("/tasks")
+	taskRoutes.Use(middleware.AuthMiddleware())
+	{
+		taskRoutes.POST("", taskHandler.CreateTask)
+		taskRoutes.GET("", taskHandler.ListTasks)
+		taskRoutes.POST("/bulk", taskHandler.BulkUpdateTasks)
+		taskRoutes.GET("/export", taskHandler.ExportTasks)
+		taskRoutes.POST("/import", taskHandler.ImportTasks)
+		taskRoutes.GET("/search", taskHandler.SearchTasks)
+		taskRoutes.GET("/plan", taskHandler.GetExecutionPlan)
+		taskRoutes.GET("/recurrence/preview", taskHandler.PreviewRecurrence)
+		taskRoutes.GET("/assigned", taskHandler.ListAssignedTasks)
+		taskRoutes.GET("/:id", taskHandler.GetTask)
+		taskRoutes.PUT("/:id", taskHandler.UpdateTask)
+		taskRoutes.DELETE("/:id", taskHandler.DeleteTask)
+		taskRoutes.GET("/:id/subtree", taskHandler.GetSubtree)
+		taskRoutes.POST("/:id/move", taskHandler.MoveTask)
+		taskRoutes.GET("/:id/transitions", taskHandler.GetStatusTransitions)
+		taskRoutes.GET("/:id/dependencies", taskHandler.GetDependencies)
+		taskRoutes.POST("/:id/dependencies", taskHandler.AddDependency)
+		taskRoutes.DELETE("/:id/dependencies/:blockerId", taskHandler.RemoveDependency)
+		taskRoutes.GET("/:id/assignees", taskHandler.GetAssignees)
+		taskRoutes.POST("/:id/assignees", taskHandler.AssignTask)
+		taskRoutes.PUT("/:id/assignees", taskHandler.ReplaceAssignees)
+		taskRoutes.DELETE("/:id/assignees/:userId", taskHandler.UnassignTask)
+		taskRoutes.GET("/:id/assignees/history", taskHandler.GetAssignmentHistory)
+	}
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"TaskManagmentApis/internal/handlers"
+	"TaskManagmentApis/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupTrashRoutes(router *gin.Engine, taskHandler *handlers.TaskHandler) {
+	// deleted tasks stay here until restored, purged or removed by the retention job
+	trashRoutes := router.Group("/trash")
+	trashRoutes.Use(middleware.AuthMiddleware())
+	{
+		trashRoutes.GET("", taskHandler.ListTrash)
+		trashRoutes.POST("/tasks/:id/restore", taskHandler.RestoreTask)
+		trashRoutes.DELETE("/tasks/:id", taskHandler.PurgeTask)
+	}
+}
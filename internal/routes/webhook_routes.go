@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"TaskManagmentApis/internal/handlers"
+	"TaskManagmentApis/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupWebhookRoutes(router *gin.Engine, webhookHandler *handlers.WebhookHandler) {
+	webhookRoutes := router.Group("/webhooks")
+	webhookRoutes.Use(middleware.AuthMiddleware())
+	{
+		webhookRoutes.GET("", webhookHandler.ListWebhooks)
+		webhookRoutes.POST("", webhookHandler.CreateWebhook)
+		webhookRoutes.GET("/:webhookId", webhookHandler.GetWebhook)
+		webhookRoutes.PATCH("/:webhookId", webhookHandler.UpdateWebhook)
+		webhookRoutes.DELETE("/:webhookId", webhookHandler.DeleteWebhook)
+		webhookRoutes.POST("/:webhookId/secret", webhookHandler.RotateSecret)
+		webhookRoutes.GET("/:webhookId/deliveries", webhookHandler.ListDeliveries)
+		webhookRoutes.POST("/:webhookId/deliveries/:deliveryId/redeliver", webhookHandler.Redeliver)
+	}
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"TaskManagmentApis/internal/handlers"
+	"TaskManagmentApis/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupWorkspaceRoutes(router *gin.Engine, workspaceHandler *handlers.WorkspaceHandler) {
+	workspaceRoutes := router.Group("/workspaces")
+	workspaceRoutes.Use(middleware.AuthMiddleware())
+	{
+		workspaceRoutes.POST("", workspaceHandler.CreateWorkspace)
+		workspaceRoutes.GET("", workspaceHandler.ListWorkspaces)
+		workspaceRoutes.GET("/:workspaceId", workspaceHandler.GetWorkspace)
+		workspaceRoutes.PATCH("/:workspaceId", workspaceHandler.UpdateWorkspace)
+		workspaceRoutes.DELETE("/:workspaceId", workspaceHandler.DeleteWorkspace)
+		workspaceRoutes.POST("/:workspaceId/switch", workspaceHandler.SwitchWorkspace)
+		workspaceRoutes.GET("/:workspaceId/members", workspaceHandler.ListMembers)
+		workspaceRoutes.POST("/:workspaceId/members", workspaceHandler.AddMember)
+		workspaceRoutes.PATCH("/:workspaceId/members/:userId", workspaceHandler.UpdateMember)
+		workspaceRoutes.DELETE("/:workspaceId/members/:userId", workspaceHandler.RemoveMember)
+	}
+}
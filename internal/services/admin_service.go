@@ -0,0 +1,268 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/rbac"
+	"TaskManagmentApis/internal/repositories"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// ErrPermissionDenied is returned when the user's role does not grant a required permission
+var ErrPermissionDenied = errors.New("you do not have permission to perform this action")
+
+// ErrUserNotFound is returned when a user does not exist
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrInvalidRole is returned for role names that are not defined in rbac
+var ErrInvalidRole = errors.New("invalid role")
+
+// ErrOwnRoleChange is returned when admins try to change their own role, which could lock everyone out
+var ErrOwnRoleChange = errors.New("you cannot change your own role")
+
+// ErrOwnAccountDelete is returned when admins try to delete their own account
+var ErrOwnAccountDelete = errors.New("you cannot delete your own account")
+
+// ErrEmailInUse is returned when restoring a user whose email has been taken by a newer account
+var ErrEmailInUse = errors.New("email is already used by another account")
+
+const (
+	defaultUserPageSize = 50
+	maxUserPageSize     = 200
+)
+
+// AdminService defines the interface for system administration operations
+type AdminService interface {
+	ListRoles(actorID uuid.UUID) (map[string][]rbac.Permission, error)
+	ListUsers(actorID uuid.UUID, query models.UserListQuery) ([]models.User, int64, error)
+	UpdateUserRole(actorID, userID uuid.UUID, version int, input models.UpdateUserRoleRequest, meta models.RequestMeta) (*models.User, error)
+	DeleteUser(actorID, userID uuid.UUID, version int, meta models.RequestMeta) error
+	ListDeletedUsers(actorID uuid.UUID) ([]models.User, error)
+	RestoreUser(actorID, userID uuid.UUID, meta models.RequestMeta) (*models.User, error)
+}
+
+// AdminServiceImpl is the concrete implementation of AdminService
+type AdminServiceImpl struct {
+	UserRepo repositories.AuthRepository
+	Audit    AuditService
+}
+
+// NewAdminService creates a new AdminService instance
+func NewAdminService(userRepo repositories.AuthRepository, auditService AuditService) AdminService {
+	return &AdminServiceImpl{
+		UserRepo: userRepo,
+		Audit:    auditService,
+	}
+}
+
+// requirePermission checks the actor's stored role rather than the one in their token, so a
+// demoted admin loses access immediately and no route can skip the check by forgetting middleware
+func requirePermission(userRepo repositories.AuthRepository, actorID uuid.UUID, permissions ...rbac.Permission) (*models.User, error) {
+	actor, err := userRepo.GetUserByID(actorID)
+	if err != nil {
+		log.Printf("Error fetching user %s for permission check: %v", actorID, err)
+		return nil, fmt.Errorf("failed to check permissions: %v", err)
+	}
+	if actor == nil || !rbac.HasPermission(actor.Role, permissions...) {
+		return nil, ErrPermissionDenied
+	}
+	return actor, nil
+}
+
+// ListRoles returns every role with the permissions it grants
+func (s *AdminServiceImpl) ListRoles(actorID uuid.UUID) (map[string][]rbac.Permission, error) {
+	if _, err := requirePermission(s.UserRepo, actorID, rbac.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+
+	roles := make(map[string][]rbac.Permission)
+	for _, role := range rbac.Roles() {
+		roles[role] = rbac.Permissions(role)
+	}
+	return roles, nil
+}
+
+// ListUsers returns a page of users, optionally only those with one role
+func (s *AdminServiceImpl) ListUsers(actorID uuid.UUID, query models.UserListQuery) ([]models.User, int64, error) {
+	if _, err := requirePermission(s.UserRepo, actorID, rbac.PermissionUsersRead); err != nil {
+		return nil, 0, err
+	}
+	if query.Role != "" && !rbac.IsValidRole(query.Role) {
+		return nil, 0, fmt.Errorf("%w: %q", ErrInvalidRole, query.Role)
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultUserPageSize
+	}
+	if limit > maxUserPageSize {
+		limit = maxUserPageSize
+	}
+	offset := query.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	users, total, err := s.UserRepo.ListUsers(query.Role, limit, offset)
+	if err != nil {
+		log.Printf("Error listing users for admin %s: %v", actorID, err)
+		return nil, 0, fmt.Errorf("failed to list users: %v", err)
+	}
+	if users == nil {
+		users = []models.User{}
+	}
+	return users, total, nil
+}
+
+// UpdateUserRole changes the system role of another user. version is the one the client last saw; 0 accepts any.
+func (s *AdminServiceImpl) UpdateUserRole(actorID, userID uuid.UUID, version int, input models.UpdateUserRoleRequest, meta models.RequestMeta) (*models.User, error) {
+	if _, err := requirePermission(s.UserRepo, actorID, rbac.PermissionUsersManage); err != nil {
+		return nil, err
+	}
+	if !rbac.IsValidRole(input.Role) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRole, input.Role)
+	}
+	if actorID == userID {
+		return nil, ErrOwnRoleChange
+	}
+
+	previous, err := s.UserRepo.GetUserByID(userID)
+	if err != nil {
+		log.Printf("Error fetching user %s before role change: %v", userID, err)
+		return nil, fmt.Errorf("failed to fetch user: %v", err)
+	}
+	if previous == nil {
+		return nil, ErrUserNotFound
+	}
+	if err := checkVersion(version, previous.Version); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.UserRepo.UpdateUserRole(userID, previous.Version, input.Role)
+	if err != nil {
+		if isVersionConflict(err) {
+			return nil, ErrPreconditionFailed
+		}
+		log.Printf("Error changing role of user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to update role: %v", err)
+	}
+	if !updated {
+		return nil, ErrUserNotFound
+	}
+
+	user, err := s.UserRepo.GetUserByID(userID)
+	if err != nil || user == nil {
+		log.Printf("Error fetching user %s after role change: %v", userID, err)
+		return nil, fmt.Errorf("failed to fetch user: %v", err)
+	}
+
+	log.Printf("Role of user %s set to %s by admin %s", userID, input.Role, actorID)
+	s.Audit.Record(meta, models.AuditEvent{
+		ActorID:    &actorID,
+		Action:     models.AuditActionRoleChange,
+		EntityType: models.AuditEntityUser,
+		EntityID:   userID.String(),
+		Before:     models.JSONMap{"role": previous.Role},
+		After:      models.JSONMap{"role": user.Role},
+	})
+	return user, nil
+}
+
+// DeleteUser moves another user to the trash; they can be restored until the purge job removes them.
+// version is the one the client last saw; 0 accepts any.
+func (s *AdminServiceImpl) DeleteUser(actorID, userID uuid.UUID, version int, meta models.RequestMeta) error {
+	if _, err := requirePermission(s.UserRepo, actorID, rbac.PermissionUsersManage); err != nil {
+		return err
+	}
+	if actorID == userID {
+		return ErrOwnAccountDelete
+	}
+
+	user, err := s.UserRepo.GetUserByID(userID)
+	if err != nil {
+		log.Printf("Error fetching user %s before deletion: %v", userID, err)
+		return fmt.Errorf("failed to fetch user: %v", err)
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if err := checkVersion(version, user.Version); err != nil {
+		return err
+	}
+
+	deleted, err := s.UserRepo.DeleteUser(userID, user.Version)
+	if err != nil {
+		if isVersionConflict(err) {
+			return ErrPreconditionFailed
+		}
+		log.Printf("Error deleting user %s: %v", userID, err)
+		return fmt.Errorf("failed to delete user: %v", err)
+	}
+	if !deleted {
+		return ErrUserNotFound
+	}
+
+	log.Printf("User %s moved to the trash by admin %s", userID, actorID)
+	s.Audit.Record(meta, models.AuditEvent{
+		ActorID:    &actorID,
+		Action:     models.AuditActionUserDelete,
+		EntityType: models.AuditEntityUser,
+		EntityID:   userID.String(),
+		Before:     auditSnapshot(user),
+	})
+	return nil
+}
+
+// ListDeletedUsers returns the users in the trash
+func (s *AdminServiceImpl) ListDeletedUsers(actorID uuid.UUID) ([]models.User, error) {
+	if _, err := requirePermission(s.UserRepo, actorID, rbac.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+
+	users, err := s.UserRepo.ListDeletedUsers()
+	if err != nil {
+		log.Printf("Error listing deleted users for admin %s: %v", actorID, err)
+		return nil, fmt.Errorf("failed to list deleted users: %v", err)
+	}
+	if users == nil {
+		users = []models.User{}
+	}
+	return users, nil
+}
+
+// RestoreUser takes a user out of the trash. Their refresh token is gone, so they have to log in again.
+func (s *AdminServiceImpl) RestoreUser(actorID, userID uuid.UUID, meta models.RequestMeta) (*models.User, error) {
+	if _, err := requirePermission(s.UserRepo, actorID, rbac.PermissionUsersManage); err != nil {
+		return nil, err
+	}
+
+	restored, err := s.UserRepo.RestoreUser(userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrEmailInUse) {
+			return nil, ErrEmailInUse
+		}
+		log.Printf("Error restoring user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to restore user: %v", err)
+	}
+	if !restored {
+		return nil, ErrUserNotFound
+	}
+
+	user, err := s.UserRepo.GetUserByID(userID)
+	if err != nil || user == nil {
+		log.Printf("Error fetching user %s after restore: %v", userID, err)
+		return nil, fmt.Errorf("failed to fetch user: %v", err)
+	}
+
+	log.Printf("User %s restored by admin %s", userID, actorID)
+	s.Audit.Record(meta, models.AuditEvent{
+		ActorID:    &actorID,
+		Action:     models.AuditActionUserRestore,
+		EntityType: models.AuditEntityUser,
+		EntityID:   userID.String(),
+	})
+	return user, nil
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	config "TaskManagmentApis/configs"
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"TaskManagmentApis/internal/storage"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// ErrAttachmentNotFound is returned when an attachment does not exist or belongs to another task
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+// ErrAttachmentTooLarge is returned when an upload exceeds ATTACHMENT_MAX_BYTES
+var ErrAttachmentTooLarge = errors.New("attachment is too large")
+
+// ErrAttachmentTypeNotAllowed is returned when the detected content type is not in ATTACHMENT_ALLOWED_TYPES
+var ErrAttachmentTypeNotAllowed = errors.New("attachment type is not allowed")
+
+// ErrInvalidAttachment is returned for empty uploads or uploads without a file
+var ErrInvalidAttachment = errors.New("invalid attachment")
+
+// ErrAttachmentForbidden is returned when someone other than the uploader or task owner deletes an attachment
+var ErrAttachmentForbidden = errors.New("only the uploader or the task owner can delete this attachment")
+
+// ErrInvalidDownloadLink is returned when a signed download URL is tampered with or expired
+var ErrInvalidDownloadLink = errors.New("download link is invalid or has expired")
+
+// AttachmentService defines the interface for task attachment operations
+type AttachmentService interface {
+	UploadAttachment(userID, taskID uuid.UUID, fileName string, content io.Reader, meta models.RequestMeta) (*models.Attachment, bool, error)
+	ListAttachments(userID, taskID uuid.UUID) ([]models.Attachment, error)
+	GetDownloadURL(userID, taskID, attachmentID uuid.UUID) (*models.AttachmentDownload, error)
+	DeleteAttachment(userID, taskID, attachmentID uuid.UUID, meta models.RequestMeta) error
+	OpenSignedDownload(key string, query url.Values) (io.ReadCloser, storage.Download, error)
+	RemoveUnusedObjects(objects []models.Attachment)
+}
+
+// AttachmentServiceImpl is the concrete implementation of AttachmentService
+type AttachmentServiceImpl struct {
+	AttachmentRepo repositories.AttachmentRepository
+	TaskRepo       repositories.TaskRepository
+	Storage        storage.Storage
+	Audit          AuditService
+	MaxBytes       int64
+	AllowedTypes   map[string]bool
+	URLExpiry      time.Duration
+}
+
+// NewAttachmentService creates a new AttachmentService instance
+func NewAttachmentService(attachmentRepo repositories.AttachmentRepository, taskRepo repositories.TaskRepository, store storage.Storage, audit AuditService) AttachmentService {
+	allowed := make(map[string]bool)
+	for _, contentType := range strings.Split(config.Config.AttachmentAllowedTypes, ",") {
+		if contentType = strings.ToLower(strings.TrimSpace(contentType)); contentType != "" {
+			allowed[contentType] = true
+		}
+	}
+	return &AttachmentServiceImpl{
+		AttachmentRepo: attachmentRepo,
+		TaskRepo:       taskRepo,
+		Storage:        store,
+		Audit:          audit,
+		MaxBytes:       int64(config.Config.AttachmentMaxBytes),
+		AllowedTypes:   allowed,
+		URLExpiry:      time.Duration(config.Config.SignedURLExpireMinutes) * time.Minute,
+	}
+}
+
+// attachmentAuditEvent builds the task event for an attachment being added or removed
+func attachmentAuditEvent(action string, userID uuid.UUID, attachment *models.Attachment, meta models.RequestMeta) *models.AuditEvent {
+	event := taskAuditEvent(action, userID, attachment.TaskID, nil, nil)
+	event.Metadata = models.JSONMap{
+		"attachment_id": attachment.ID,
+		"file_name":     attachment.FileName,
+		"size":          attachment.Size,
+	}
+	stampAuditEvent(&event, meta)
+	return &event
+}
+
+// getTask returns the task if the user may see and add attachments: its owner or an assignee
+func (s *AttachmentServiceImpl) getTask(userID, taskID uuid.UUID) (*models.Task, error) {
+	task, err := s.TaskRepo.GetAccessibleTask(taskID, userID)
+	if err != nil {
+		log.Printf("Error fetching task %s for user %s: %v", taskID, userID, err)
+		return nil, fmt.Errorf("failed to fetch task: %v", err)
+	}
+	if task == nil {
+		return nil, ErrTaskNotFound
+	}
+	return task, nil
+}
+
+// cleanFileName keeps the base name of an uploaded file without control characters
+func cleanFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		name = "file"
+	}
+	if runes := []rune(name); len(runes) > 255 {
+		name = string(runes[:255])
+	}
+	return name
+}
+
+// storageKey spreads objects over subdirectories by the first checksum byte
+func storageKey(checksum string) string {
+	return "sha256/" + checksum[:2] + "/" + checksum
+}
+
+// UploadAttachment stores an uploaded file on the task. The file is spooled to disk while
+// its checksum is computed; the content type is sniffed from the bytes rather than trusted
+// from the client. The boolean is false when the task already had a file with this content.
+func (s *AttachmentServiceImpl) UploadAttachment(userID, taskID uuid.UUID, fileName string, content io.Reader, meta models.RequestMeta) (*models.Attachment, bool, error) {
+	if _, err := s.getTask(userID, taskID); err != nil {
+		return nil, false, err
+	}
+
+	spool, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		log.Printf("Error creating upload spool file: %v", err)
+		return nil, false, fmt.Errorf("failed to store attachment: %v", err)
+	}
+	defer func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}()
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(spool, hasher), io.LimitReader(content, s.MaxBytes+1))
+	if err != nil {
+		log.Printf("Error reading upload for task %s: %v", taskID, err)
+		return nil, false, fmt.Errorf("%w: failed to read upload", ErrInvalidAttachment)
+	}
+	if size > s.MaxBytes {
+		return nil, false, fmt.Errorf("%w: limit is %d bytes", ErrAttachmentTooLarge, s.MaxBytes)
+	}
+	if size == 0 {
+		return nil, false, fmt.Errorf("%w: file is empty", ErrInvalidAttachment)
+	}
+
+	head := make([]byte, 512)
+	n, err := spool.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, false, fmt.Errorf("failed to store attachment: %v", err)
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	if !s.AllowedTypes[contentType] {
+		return nil, false, fmt.Errorf("%w: %s", ErrAttachmentTypeNotAllowed, contentType)
+	}
+
+	checksum := hex.EncodeToString(hasher.Sum(nil))
+	attachment := &models.Attachment{
+		TaskID:      taskID,
+		UploaderID:  userID,
+		FileName:    cleanFileName(fileName),
+		ContentType: contentType,
+		Size:        size,
+		Checksum:    checksum,
+		StorageKey:  storageKey(checksum),
+	}
+
+	ensureObject := func() error {
+		ctx := context.Background()
+		exists, err := s.Storage.Exists(ctx, attachment.StorageKey)
+		if err != nil || exists {
+			return err
+		}
+		if _, err := spool.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		return s.Storage.Put(ctx, attachment.StorageKey, spool, size, contentType, checksum)
+	}
+
+	var event *models.AuditEvent
+	saved, created, err := s.AttachmentRepo.CreateAttachment(attachment, ensureObject, func(created *models.Attachment) *models.AuditEvent {
+		event = attachmentAuditEvent(models.AuditActionTaskAttachmentAdd, userID, created, meta)
+		return event
+	})
+	if err != nil {
+		log.Printf("Error saving attachment on task %s for user %s: %v", taskID, userID, err)
+		return nil, false, fmt.Errorf("failed to store attachment: %v", err)
+	}
+	if created {
+		s.Audit.Announce(event)
+	}
+	return saved, created, nil
+}
+
+// ListAttachments
+func (s *AttachmentServiceImpl) ListAttachments(userID, taskID uuid.UUID) ([]models.Attachment, error) {
+	if _, err := s.getTask(userID, taskID); err != nil {
+		return nil, err
+	}
+	attachments, err := s.AttachmentRepo.ListAttachments(taskID)
+	if err != nil {
+		log.Printf("Error listing attachments of task %s: %v", taskID, err)
+		return nil, fmt.Errorf("failed to list attachments: %v", err)
+	}
+	return attachments, nil
+}
+
+// GetDownloadURL returns a signed URL for the attachment that stays valid for SIGNED_URL_EXPIRE_MINUTES
+func (s *AttachmentServiceImpl) GetDownloadURL(userID, taskID, attachmentID uuid.UUID) (*models.AttachmentDownload, error) {
+	if _, err := s.getTask(userID, taskID); err != nil {
+		return nil, err
+	}
+	attachment, err := s.AttachmentRepo.GetAttachment(attachmentID, taskID)
+	if err != nil {
+		log.Printf("Error fetching attachment %s of task %s: %v", attachmentID, taskID, err)
+		return nil, fmt.Errorf("failed to fetch attachment: %v", err)
+	}
+	if attachment == nil {
+		return nil, ErrAttachmentNotFound
+	}
+
+	expiresAt := time.Now().Add(s.URLExpiry)
+	signedURL, err := s.Storage.SignedURL(attachment.StorageKey, storage.Download{
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+	}, s.URLExpiry)
+	if err != nil {
+		log.Printf("Error signing download URL for attachment %s: %v", attachmentID, err)
+		return nil, fmt.Errorf("failed to create download URL: %v", err)
+	}
+	return &models.AttachmentDownload{URL: signedURL, ExpiresAt: expiresAt}, nil
+}
+
+// DeleteAttachment removes an attachment; the stored file goes once no attachment uses it
+func (s *AttachmentServiceImpl) DeleteAttachment(userID, taskID, attachmentID uuid.UUID, meta models.RequestMeta) error {
+	task, err := s.getTask(userID, taskID)
+	if err != nil {
+		return err
+	}
+	if task.UserID != userID {
+		attachment, err := s.AttachmentRepo.GetAttachment(attachmentID, taskID)
+		if err != nil {
+			log.Printf("Error fetching attachment %s of task %s: %v", attachmentID, taskID, err)
+			return fmt.Errorf("failed to fetch attachment: %v", err)
+		}
+		if attachment == nil {
+			return ErrAttachmentNotFound
+		}
+		if attachment.UploaderID != userID {
+			return ErrAttachmentForbidden
+		}
+	}
+
+	var event *models.AuditEvent
+	deleted, err := s.AttachmentRepo.DeleteAttachment(attachmentID, taskID, func(key string) error {
+		return s.Storage.Delete(context.Background(), key)
+	}, func(attachment *models.Attachment) *models.AuditEvent {
+		event = attachmentAuditEvent(models.AuditActionTaskAttachmentDrop, userID, attachment, meta)
+		return event
+	})
+	if err != nil {
+		log.Printf("Error deleting attachment %s of task %s: %v", attachmentID, taskID, err)
+		return fmt.Errorf("failed to delete attachment: %v", err)
+	}
+	if !deleted {
+		return ErrAttachmentNotFound
+	}
+	s.Audit.Announce(event)
+	return nil
+}
+
+// OpenSignedDownload serves URLs signed by the local storage backend. Other backends hand
+// out URLs that point straight at the object store, so nothing is served here for them.
+func (s *AttachmentServiceImpl) OpenSignedDownload(key string, query url.Values) (io.ReadCloser, storage.Download, error) {
+	local, ok := s.Storage.(*storage.LocalStorage)
+	if !ok {
+		return nil, storage.Download{}, ErrAttachmentNotFound
+	}
+	download, err := local.Verify(key, query)
+	if err != nil {
+		return nil, storage.Download{}, ErrInvalidDownloadLink
+	}
+
+	body, err := local.Open(context.Background(), key)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, storage.Download{}, ErrAttachmentNotFound
+		}
+		log.Printf("Error opening stored object %s: %v", key, err)
+		return nil, storage.Download{}, fmt.Errorf("failed to open attachment: %v", err)
+	}
+	return body, download, nil
+}
+
+// RemoveUnusedObjects deletes the stored files of attachments that were purged along with their
+// task, user or workspace, unless other attachments still use them. It runs after the purge has
+// committed, so failures only leave files behind and are logged.
+func (s *AttachmentServiceImpl) RemoveUnusedObjects(objects []models.Attachment) {
+	if len(objects) == 0 {
+		return
+	}
+	err := s.AttachmentRepo.RemoveUnusedObjects(objects, func(key string) error {
+		return s.Storage.Delete(context.Background(), key)
+	})
+	if err != nil {
+		log.Printf("Error removing stored attachment files: %v", err)
+	}
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/rbac"
+	"TaskManagmentApis/internal/repositories"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidAuditQuery is returned for malformed audit filters
+var ErrInvalidAuditQuery = errors.New("invalid audit query")
+
+const (
+	defaultAuditPageSize = 100
+	maxAuditPageSize     = 500
+)
+
+// AuditListener is told about every event recorded in the audit log
+type AuditListener interface {
+	AuditEventRecorded(event *models.AuditEvent)
+}
+
+// AuditService defines the interface for writing and reading the audit log
+type AuditService interface {
+	Record(meta models.RequestMeta, event models.AuditEvent)
+	Announce(event *models.AuditEvent)
+	AddListener(listener AuditListener)
+	ListEvents(actorID uuid.UUID, query models.AuditQuery) (*models.AuditPage, error)
+	ExportEvents(actorID uuid.UUID, query models.AuditQuery, fn func(*models.AuditEvent) error) error
+}
+
+// AuditServiceImpl is the concrete implementation of AuditService
+type AuditServiceImpl struct {
+	AuditRepo repositories.AuditRepository
+	UserRepo  repositories.AuthRepository
+	Listeners []AuditListener
+}
+
+// NewAuditService creates a new AuditService instance
+func NewAuditService(auditRepo repositories.AuditRepository, userRepo repositories.AuthRepository) AuditService {
+	return &AuditServiceImpl{
+		AuditRepo: auditRepo,
+		UserRepo:  userRepo,
+	}
+}
+
+// Record appends an event with the request's origin and announces it. Callers have already made
+// their change, so a failed write is only logged, and the listeners still hear of the event.
+func (s *AuditServiceImpl) Record(meta models.RequestMeta, event models.AuditEvent) {
+	stampAuditEvent(&event, meta)
+	if err := s.AuditRepo.CreateEvent(&event); err != nil {
+		log.Printf("Error writing audit event %s for %s %s: %v", event.Action, event.EntityType, event.EntityID, err)
+	}
+	s.Announce(&event)
+}
+
+// Announce passes an event to the listeners. Record calls it; changes that write their audit event
+// in their own transaction call it once that transaction has committed.
+func (s *AuditServiceImpl) Announce(event *models.AuditEvent) {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	for _, listener := range s.Listeners {
+		listener.AuditEventRecorded(event)
+	}
+}
+
+// stampAuditEvent records where the request behind an event came from
+func stampAuditEvent(event *models.AuditEvent, meta models.RequestMeta) {
+	event.IP = meta.IP
+	event.UserAgent = meta.UserAgent
+}
+
+// AddListener has listener called with every event Record writes, even one the log failed to store.
+// Listeners are added while the app is wired up, before any request is served.
+func (s *AuditServiceImpl) AddListener(listener AuditListener) {
+	s.Listeners = append(s.Listeners, listener)
+}
+
+// auditFilter validates the query and turns it into a repository filter
+func auditFilter(query models.AuditQuery) (repositories.AuditFilter, error) {
+	filter := repositories.AuditFilter{
+		Actions:    splitListParam(query.Action),
+		EntityType: query.EntityType,
+		EntityID:   query.EntityID,
+		From:       query.From,
+		To:         query.To,
+		BeforeID:   query.Cursor,
+	}
+	if query.ActorID != "" {
+		actorID, err := uuid.Parse(query.ActorID)
+		if err != nil {
+			return filter, fmt.Errorf("%w: invalid actor ID %q", ErrInvalidAuditQuery, query.ActorID)
+		}
+		filter.ActorID = &actorID
+	}
+	return filter, nil
+}
+
+// ListEvents returns one page of audit events, newest first
+func (s *AuditServiceImpl) ListEvents(actorID uuid.UUID, query models.AuditQuery) (*models.AuditPage, error) {
+	if _, err := requirePermission(s.UserRepo, actorID, rbac.PermissionAuditRead); err != nil {
+		return nil, err
+	}
+	filter, err := auditFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Limit = query.Limit
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditPageSize
+	}
+	if filter.Limit > maxAuditPageSize {
+		filter.Limit = maxAuditPageSize
+	}
+
+	events, err := s.AuditRepo.ListEvents(filter)
+	if err != nil {
+		log.Printf("Error listing audit events for user %s: %v", actorID, err)
+		return nil, fmt.Errorf("failed to list audit events: %v", err)
+	}
+
+	page := &models.AuditPage{Events: events}
+	if page.Events == nil {
+		page.Events = []models.AuditEvent{}
+	}
+	if len(events) == filter.Limit {
+		page.NextCursor = events[len(events)-1].ID
+	}
+	return page, nil
+}
+
+// ExportEvents streams every matching audit event to fn, oldest first
+func (s *AuditServiceImpl) ExportEvents(actorID uuid.UUID, query models.AuditQuery, fn func(*models.AuditEvent) error) error {
+	if _, err := requirePermission(s.UserRepo, actorID, rbac.PermissionAuditRead); err != nil {
+		return err
+	}
+	filter, err := auditFilter(query)
+	if err != nil {
+		return err
+	}
+
+	if err := s.AuditRepo.ExportEvents(filter, fn); err != nil {
+		log.Printf("Error exporting audit events for user %s: %v", actorID, err)
+		return fmt.Errorf("failed to export audit events: %v", err)
+	}
+	return nil
+}
+
+// auditSnapshot converts a record into the JSON object stored in the audit log
+func auditSnapshot(v interface{}) models.JSONMap {
+	if v == nil {
+		return nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("Error building audit snapshot of %T: %v", v, err)
+		return nil
+	}
+	var snapshot models.JSONMap
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		log.Printf("Error building audit snapshot of %T: %v", v, err)
+		return nil
+	}
+	return snapshot
+}
+
+// auditDiff keeps only the fields that differ between two snapshots. updated_at and version are
+// left out because they change on every write and say nothing the event itself does not.
+func auditDiff(before, after models.JSONMap) (models.JSONMap, models.JSONMap) {
+	changedBefore, changedAfter := models.JSONMap{}, models.JSONMap{}
+	for key, value := range after {
+		if auditIgnoredField(key) {
+			continue
+		}
+		if old, ok := before[key]; !ok || !reflect.DeepEqual(old, value) {
+			changedBefore[key] = before[key]
+			changedAfter[key] = value
+		}
+	}
+	for key, old := range before {
+		if _, ok := after[key]; !ok && !auditIgnoredField(key) {
+			changedBefore[key] = old
+			changedAfter[key] = nil
+		}
+	}
+	return changedBefore, changedAfter
+}
+
+func auditIgnoredField(key string) bool {
+	return key == "updated_at" || key == "version"
+}
+
+// taskAuditEvent builds an event about a task; before and after are full task records and are
+// reduced to a diff when both are present
+func taskAuditEvent(action string, actorID, taskID uuid.UUID, before, after *models.Task) models.AuditEvent {
+	event := models.AuditEvent{
+		ActorID:    &actorID,
+		Action:     action,
+		EntityType: models.AuditEntityTask,
+		EntityID:   taskID.String(),
+		Before:     auditSnapshot(before),
+		After:      auditSnapshot(after),
+	}
+	if event.Before != nil && event.After != nil {
+		event.Before, event.After = auditDiff(event.Before, event.After)
+	}
+	return event
+}
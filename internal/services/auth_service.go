@@ -1,12 +1,15 @@
 package service
 
 import (
+	config "TaskManagmentApis/configs"
 	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/rbac"
 	"TaskManagmentApis/internal/repositories"
 	"TaskManagmentApis/pkg/utils"
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,34 +18,53 @@ import (
 
 // AuthService defines the interface for user authentication operations
 type AuthService interface {
-	RegisterUser(name, email, password string) (*models.User, string, string, error)
-	LoginUser(email, password string) (*models.User, string, string, error)
-	LogoutUser(UserID uuid.UUID) error
-	GenerateAccessTokenByRefreshToken(refreshToken string) (string, string, error)
+	RegisterUser(name, email, password string, meta models.RequestMeta) (*models.User, string, string, error)
+	LoginUser(email, password string, meta models.RequestMeta) (*models.User, string, string, error)
+	LogoutUser(UserID uuid.UUID, meta models.RequestMeta) error
+	GenerateAccessTokenByRefreshToken(refreshToken string, meta models.RequestMeta) (string, string, error)
+	UpdateTimezone(userID uuid.UUID, version int, timezone string) (*models.User, error)
+	UpdateReminderOffsets(userID uuid.UUID, version int, offsets []int) (*models.User, error)
+	GetCurrentUser(userID uuid.UUID) (*models.User, error)
 }
 
 // AuthServiceImpl is the concrete implementation of AuthService
 type AuthServiceImpl struct {
 	AuthRepo             repositories.AuthRepository
+	WorkspaceRepo        repositories.WorkspaceRepository
+	Audit                AuditService
 	ValidateEmail        func(string) bool
 	HashPassword         func(string) (string, error)
 	ComparePassword      func(string, string) bool
-	GenerateAccessToken  func(string, string) (string, error)
+	GenerateAccessToken  func(string, string, string, string) (string, error)
 	GenerateRefreshToken func(string, string) (string, time.Time, error)
+	AdminEmails          []string
 }
 
 // NewAuthService creates a new AuthService instance with default utils
-func NewAuthService(authRepo repositories.AuthRepository) AuthService {
+func NewAuthService(authRepo repositories.AuthRepository, workspaceRepo repositories.WorkspaceRepository, auditService AuditService) AuthService {
 	return &AuthServiceImpl{
 		AuthRepo:             authRepo,
+		WorkspaceRepo:        workspaceRepo,
+		Audit:                auditService,
 		ValidateEmail:        utils.ISValidateEmail,
 		HashPassword:         utils.HashPassword,
 		ComparePassword:      utils.ComparePassword,
 		GenerateAccessToken:  utils.GenerateAccessToken,
 		GenerateRefreshToken: utils.GenerateRefreshToken,
+		AdminEmails:          strings.Split(config.Config.AdminEmails, ","),
 	}
 }
 
+// isConfiguredAdmin reports whether the email is listed in ADMIN_EMAILS; those users always get the admin role
+func (s *AuthServiceImpl) isConfiguredAdmin(email string) bool {
+	for _, adminEmail := range s.AdminEmails {
+		if adminEmail = strings.TrimSpace(adminEmail); adminEmail != "" && strings.EqualFold(adminEmail, email) {
+			return true
+		}
+	}
+	return false
+}
+
 // UserExist checks whether a user with the given email exists
 func (s *AuthServiceImpl) UserExist(email string) error {
 	if email == "" {
@@ -66,7 +88,7 @@ func (s *AuthServiceImpl) UserExist(email string) error {
 }
 
 // RegisterUser handles user registration
-func (s *AuthServiceImpl) RegisterUser(name, email, password string) (*models.User, string, string, error) {
+func (s *AuthServiceImpl) RegisterUser(name, email, password string, meta models.RequestMeta) (*models.User, string, string, error) {
 	if err := s.UserExist(email); err != nil {
 		// Log the error
 		log.Printf("User registration failed for email %s: %v", email, err)
@@ -83,6 +105,10 @@ func (s *AuthServiceImpl) RegisterUser(name, email, password string) (*models.Us
 		Name:         name,
 		Email:        email,
 		PasswordHash: hashedPassword,
+		Role:         rbac.RoleUser,
+	}
+	if s.isConfiguredAdmin(email) {
+		user.Role = rbac.RoleAdmin
 	}
 
 	createdUser, err := s.AuthRepo.CreateUser(user)
@@ -91,7 +117,13 @@ func (s *AuthServiceImpl) RegisterUser(name, email, password string) (*models.Us
 		return nil, "", "", fmt.Errorf("failed to create user: %v", err)
 	}
 
-	accessToken, err := s.GenerateAccessToken(createdUser.ID.String(), createdUser.Email)
+	workspaceID, err := s.activeWorkspace(createdUser)
+	if err != nil {
+		log.Printf("Error creating workspace for email %s: %v", email, err)
+		return nil, "", "", fmt.Errorf("failed to create workspace: %v", err)
+	}
+
+	accessToken, err := s.GenerateAccessToken(createdUser.ID.String(), createdUser.Email, workspaceID.String(), createdUser.Role)
 	if err != nil {
 		log.Printf("Error generating access token for email %s: %v", email, err)
 		return nil, "", "", fmt.Errorf("failed to generate access token: %v", err)
@@ -111,24 +143,47 @@ func (s *AuthServiceImpl) RegisterUser(name, email, password string) (*models.Us
 
 	// Log successful registration
 	log.Printf("User successfully registered: %s", email)
+	s.Audit.Record(meta, models.AuditEvent{
+		ActorID:    &createdUser.ID,
+		Action:     models.AuditActionRegister,
+		EntityType: models.AuditEntityUser,
+		EntityID:   createdUser.ID.String(),
+		After:      auditSnapshot(createdUser),
+	})
 
 	return createdUser, accessToken, refreshToken, nil
 }
 
 // LoginUser handles user login
-func (s *AuthServiceImpl) LoginUser(email, password string) (*models.User, string, string, error) {
+func (s *AuthServiceImpl) LoginUser(email, password string, meta models.RequestMeta) (*models.User, string, string, error) {
 	user, err := s.AuthRepo.GetUserByEmail(email)
 	if err != nil || user == nil {
 		log.Printf("Login failed for email %s: invalid email or password", email)
+		s.recordLoginFailure(meta, nil, email, "unknown email")
 		return nil, "", "", errors.New("invalid email or password")
 	}
 
 	if !s.ComparePassword(user.PasswordHash, password) {
 		log.Printf("Login failed for email %s: invalid email or password", email)
+		s.recordLoginFailure(meta, &user.ID, email, "wrong password")
 		return nil, "", "", errors.New("invalid email or password")
 	}
 
-	accessToken, err := s.GenerateAccessToken(user.ID.String(), user.Email)
+	if user.Role != rbac.RoleAdmin && s.isConfiguredAdmin(user.Email) {
+		if _, err := s.AuthRepo.UpdateUserRole(user.ID, 0, rbac.RoleAdmin); err != nil {
+			log.Printf("Error promoting configured admin %s: %v", email, err)
+			return nil, "", "", fmt.Errorf("failed to update role: %v", err)
+		}
+		user.Role = rbac.RoleAdmin
+	}
+
+	workspaceID, err := s.activeWorkspace(user)
+	if err != nil {
+		log.Printf("Error resolving workspace for email %s: %v", email, err)
+		return nil, "", "", fmt.Errorf("failed to resolve workspace: %v", err)
+	}
+
+	accessToken, err := s.GenerateAccessToken(user.ID.String(), user.Email, workspaceID.String(), user.Role)
 	if err != nil {
 		log.Printf("Error generating access token for email %s: %v", email, err)
 		return nil, "", "", fmt.Errorf("failed to generate access token: %v", err)
@@ -148,51 +203,102 @@ func (s *AuthServiceImpl) LoginUser(email, password string) (*models.User, strin
 
 	// Log successful login
 	log.Printf("User successfully logged in: %s", email)
+	s.Audit.Record(meta, models.AuditEvent{
+		ActorID:    &user.ID,
+		Action:     models.AuditActionLogin,
+		EntityType: models.AuditEntityUser,
+		EntityID:   user.ID.String(),
+		Metadata:   models.JSONMap{"workspace_id": workspaceID},
+	})
 
 	return user, accessToken, refreshToken, nil
 }
 
+// recordLoginFailure audits a failed login; actorID is set when the email belongs to a user
+func (s *AuthServiceImpl) recordLoginFailure(meta models.RequestMeta, actorID *uuid.UUID, email, reason string) {
+	event := models.AuditEvent{
+		ActorID:    actorID,
+		Action:     models.AuditActionLoginFailed,
+		EntityType: models.AuditEntityUser,
+		Metadata:   models.JSONMap{"email": email, "reason": reason},
+	}
+	if actorID != nil {
+		event.EntityID = actorID.String()
+	}
+	s.Audit.Record(meta, event)
+}
+
 // Logout user handles user logout
-func (s *AuthServiceImpl) LogoutUser(userID uuid.UUID) error {
+func (s *AuthServiceImpl) LogoutUser(userID uuid.UUID, meta models.RequestMeta) error {
 	if err := s.AuthRepo.DeleteRefreshToken(userID); err != nil {
 		log.Printf("Error logging out user %s: %v", userID, err)
 		return err // No need to wrap again unless adding more context
 	}
 
 	log.Printf("User %s logged out successfully", userID)
+	s.Audit.Record(meta, models.AuditEvent{
+		ActorID:    &userID,
+		Action:     models.AuditActionLogout,
+		EntityType: models.AuditEntityUser,
+		EntityID:   userID.String(),
+	})
 	return nil
 }
 
 // GenerateAccessTokenByRefreshToken verifies a refresh token and issues a new access token
-func (s *AuthServiceImpl) GenerateAccessTokenByRefreshToken(refreshToken string) (string, string, error) {
+func (s *AuthServiceImpl) GenerateAccessTokenByRefreshToken(refreshToken string, meta models.RequestMeta) (string, string, error) {
 	// 1. Get refresh token from DB
 	storedToken, err := s.AuthRepo.GetRefreshTokenByToken(refreshToken)
 	if err != nil || storedToken == nil {
 		log.Printf("Invalid refresh token: %v", err)
+		s.Audit.Record(meta, models.AuditEvent{
+			Action:     models.AuditActionTokenRefreshFailed,
+			EntityType: models.AuditEntityUser,
+			Metadata:   models.JSONMap{"reason": "unknown token"},
+		})
 		return "", "", errors.New("invalid refresh token")
 	}
 
 	// 2. Check if expired
 	if time.Now().After(storedToken.ExpiresAt) {
 		log.Printf("Refresh token has expired: %v", refreshToken)
+		s.Audit.Record(meta, models.AuditEvent{
+			ActorID:    &storedToken.UserID,
+			Action:     models.AuditActionTokenRefreshFailed,
+			EntityType: models.AuditEntityUser,
+			EntityID:   storedToken.UserID.String(),
+			Metadata:   models.JSONMap{"reason": "expired token"},
+		})
 		return "", "", errors.New("refresh token has expired")
 	}
 
-	// 3. Generate new access token
-	newAccessToken, err := s.GenerateAccessToken(storedToken.UserID.String(), storedToken.User.Email)
+	// 3. Load the user and the workspace the new access token is scoped to
+	user, err := s.AuthRepo.GetUserByID(storedToken.UserID)
+	if err != nil || user == nil {
+		log.Printf("Error fetching user %s for token refresh: %v", storedToken.UserID.String(), err)
+		return "", "", errors.New("invalid refresh token")
+	}
+	workspaceID, err := s.activeWorkspace(user)
+	if err != nil {
+		log.Printf("Error resolving workspace for user %s: %v", storedToken.UserID.String(), err)
+		return "", "", fmt.Errorf("failed to resolve workspace: %v", err)
+	}
+
+	// 4. Generate new access token
+	newAccessToken, err := s.GenerateAccessToken(user.ID.String(), user.Email, workspaceID.String(), user.Role)
 	if err != nil {
 		log.Printf("Error generating access token for user %s: %v", storedToken.UserID.String(), err)
 		return "", "", fmt.Errorf("failed to generate access token: %v", err)
 	}
 
-	// 4. Generate new refresh token
-	newRefreshToken, refreshExp, err := s.GenerateRefreshToken(storedToken.UserID.String(), storedToken.User.Email)
+	// 5. Generate new refresh token
+	newRefreshToken, refreshExp, err := s.GenerateRefreshToken(user.ID.String(), user.Email)
 	if err != nil {
 		log.Printf("Error generating new refresh token for user %s: %v", storedToken.UserID.String(), err)
 		return "", "", fmt.Errorf("failed to generate new refresh token: %v", err)
 	}
 
-	// 5. Save new refresh token
+	// 6. Save new refresh token
 	_, err = s.AuthRepo.SaveRefreshToken(storedToken.UserID, newRefreshToken, refreshExp)
 	if err != nil {
 		log.Printf("Error saving new refresh token for user %s: %v", storedToken.UserID.String(), err)
@@ -201,6 +307,127 @@ func (s *AuthServiceImpl) GenerateAccessTokenByRefreshToken(refreshToken string)
 
 	// Log successful token refresh
 	log.Printf("Access token and refresh token successfully refreshed for user %s", storedToken.UserID.String())
+	s.Audit.Record(meta, models.AuditEvent{
+		ActorID:    &user.ID,
+		Action:     models.AuditActionTokenRefresh,
+		EntityType: models.AuditEntityUser,
+		EntityID:   user.ID.String(),
+		Metadata:   models.JSONMap{"workspace_id": workspaceID},
+	})
 
 	return newAccessToken, newRefreshToken, nil
 }
+
+// activeWorkspace returns the workspace the user's access tokens are scoped to.
+// It keeps the remembered workspace while the user is still a member, falls back to their
+// oldest membership, and creates a personal workspace for users who belong to none.
+func (s *AuthServiceImpl) activeWorkspace(user *models.User) (uuid.UUID, error) {
+	if user.ActiveWorkspaceID != nil {
+		member, err := s.WorkspaceRepo.GetMembership(*user.ActiveWorkspaceID, user.ID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if member != nil {
+			return member.WorkspaceID, nil
+		}
+	}
+
+	memberships, err := s.WorkspaceRepo.ListMemberships(user.ID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	var workspaceID uuid.UUID
+	if len(memberships) > 0 {
+		workspaceID = memberships[0].WorkspaceID
+	} else {
+		workspace, err := s.WorkspaceRepo.CreateWorkspace(&models.Workspace{
+			Name:      fmt.Sprintf("%s's workspace", user.Name),
+			CreatedBy: &user.ID,
+		}, user.ID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		workspaceID = workspace.ID
+	}
+
+	if err := s.WorkspaceRepo.SetActiveWorkspace(user.ID, &workspaceID); err != nil {
+		return uuid.Nil, err
+	}
+	user.ActiveWorkspaceID = &workspaceID
+	return workspaceID, nil
+}
+
+// GetCurrentUser returns the profile of the logged in user
+func (s *AuthServiceImpl) GetCurrentUser(userID uuid.UUID) (*models.User, error) {
+	user, err := s.AuthRepo.GetUserByID(userID)
+	if err != nil {
+		log.Printf("Error fetching user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to fetch user: %v", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// UpdateTimezone sets the IANA time zone used for the user's recurring tasks. version is the one
+// the client last saw; 0 accepts any.
+func (s *AuthServiceImpl) UpdateTimezone(userID uuid.UUID, version int, timezone string) (*models.User, error) {
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, fmt.Errorf("invalid timezone: %s", timezone)
+	}
+
+	user, err := s.AuthRepo.GetUserByID(userID)
+	if err != nil || user == nil {
+		log.Printf("Error fetching user %s for timezone update: %v", userID, err)
+		return nil, ErrUserNotFound
+	}
+	if err := checkVersion(version, user.Version); err != nil {
+		return nil, err
+	}
+
+	user.Timezone = timezone
+	updatedUser, err := s.AuthRepo.UpdateUser(user)
+	if err != nil {
+		if isVersionConflict(err) {
+			return nil, ErrPreconditionFailed
+		}
+		log.Printf("Error updating timezone for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to update timezone: %v", err)
+	}
+	if updatedUser == nil {
+		return nil, ErrUserNotFound
+	}
+
+	log.Printf("Timezone of user %s set to %s", userID, timezone)
+	return updatedUser, nil
+}
+
+// UpdateReminderOffsets sets the minutes before a due date at which the user is reminded of their
+// tasks; an empty list turns reminders off. version is the one the client last saw; 0 accepts any.
+func (s *AuthServiceImpl) UpdateReminderOffsets(userID uuid.UUID, version int, offsets []int) (*models.User, error) {
+	user, err := s.AuthRepo.GetUserByID(userID)
+	if err != nil || user == nil {
+		log.Printf("Error fetching user %s for reminder update: %v", userID, err)
+		return nil, ErrUserNotFound
+	}
+	if err := checkVersion(version, user.Version); err != nil {
+		return nil, err
+	}
+
+	user.ReminderOffsets = models.ReminderOffsets(offsets).Normalize()
+	updatedUser, err := s.AuthRepo.UpdateUser(user)
+	if err != nil {
+		if isVersionConflict(err) {
+			return nil, ErrPreconditionFailed
+		}
+		log.Printf("Error updating reminder offsets for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to update reminder offsets: %v", err)
+	}
+	if updatedUser == nil {
+		return nil, ErrUserNotFound
+	}
+
+	log.Printf("Reminder offsets of user %s set to %v", userID, updatedUser.ReminderOffsets)
+	return updatedUser, nil
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"math/rand"
+	"time"
+)
+
+// retryBackoff is how long to wait after the given number of failed attempts: base, doubled after
+// every further failure up to max, and spread by up to a fifth so that work which failed together
+// is not retried in lockstep
+func retryBackoff(base, max time.Duration, attempts int) time.Duration {
+	wait := base
+	for i := 1; i < attempts && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		wait = max
+	}
+	return wait + time.Duration(rand.Int63n(int64(wait)/5+1))
+}
@@ -0,0 +1,387 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// ErrBoardNotFound is returned when a board does not exist or belongs to another user
+var ErrBoardNotFound = errors.New("board not found")
+
+// ErrColumnNotFound is returned when a column is not part of the board
+var ErrColumnNotFound = errors.New("column not found")
+
+// ErrCardNotFound is returned when a task is not on the board
+var ErrCardNotFound = errors.New("card not found")
+
+// ErrColumnNotEmpty is returned when deleting a column that still holds cards
+var ErrColumnNotEmpty = errors.New("column still has cards")
+
+// ErrInvalidCardMove is returned when the neighbours of a move are not in the target column or out of order
+var ErrInvalidCardMove = errors.New("invalid card position")
+
+// defaultBoardColumns are used when a board is created without columns
+var defaultBoardColumns = []models.BoardColumnRequest{
+	{Name: "To do", Status: models.TaskStatusPending},
+	{Name: "In progress", Status: models.TaskStatusInProgress},
+	{Name: "Done", Status: models.TaskStatusDone},
+}
+
+// BoardService defines the interface for kanban board operations
+type BoardService interface {
+	CreateBoard(userID, workspaceID uuid.UUID, input models.CreateBoardRequest) (*models.Board, error)
+	GetBoard(userID, boardID uuid.UUID) (*models.Board, error)
+	ListBoards(userID, workspaceID uuid.UUID) ([]models.Board, error)
+	RenameBoard(userID, boardID uuid.UUID, name string) (*models.Board, error)
+	DeleteBoard(userID, boardID uuid.UUID) error
+	AddColumn(userID, boardID uuid.UUID, input models.BoardColumnRequest) (*models.BoardColumn, error)
+	UpdateColumn(userID, boardID, columnID uuid.UUID, input models.UpdateBoardColumnRequest) (*models.BoardColumn, error)
+	DeleteColumn(userID, boardID, columnID uuid.UUID) error
+	AddCard(userID, boardID uuid.UUID, input models.AddCardRequest, meta models.RequestMeta) (*models.BoardCard, error)
+	MoveCard(userID, boardID, taskID uuid.UUID, input models.MoveCardRequest, meta models.RequestMeta) (*models.BoardCard, error)
+	RemoveCard(userID, boardID, taskID uuid.UUID) error
+}
+
+// BoardServiceImpl is the concrete implementation of BoardService
+type BoardServiceImpl struct {
+	BoardRepo     repositories.BoardRepository
+	TaskRepo      repositories.TaskRepository
+	ProjectRepo   repositories.ProjectRepository
+	WorkspaceRepo repositories.WorkspaceRepository
+	TaskService   TaskService
+	Audit         AuditService
+}
+
+// NewBoardService creates a new BoardService instance
+func NewBoardService(boardRepo repositories.BoardRepository, taskRepo repositories.TaskRepository, projectRepo repositories.ProjectRepository, workspaceRepo repositories.WorkspaceRepository, taskService TaskService, auditService AuditService) BoardService {
+	return &BoardServiceImpl{
+		BoardRepo:     boardRepo,
+		TaskRepo:      taskRepo,
+		ProjectRepo:   projectRepo,
+		WorkspaceRepo: workspaceRepo,
+		TaskService:   taskService,
+		Audit:         auditService,
+	}
+}
+
+// CreateBoard creates a board in a workspace with the given columns, or the default ones;
+// guests cannot create boards
+func (s *BoardServiceImpl) CreateBoard(userID, workspaceID uuid.UUID, input models.CreateBoardRequest) (*models.Board, error) {
+	if _, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleMember); err != nil {
+		return nil, err
+	}
+	if input.ProjectID != nil {
+		project, err := s.ProjectRepo.GetProjectByID(*input.ProjectID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch project: %v", err)
+		}
+		if project == nil || project.WorkspaceID != workspaceID {
+			return nil, ErrProjectNotFound
+		}
+	}
+
+	columns := input.Columns
+	if len(columns) == 0 {
+		columns = defaultBoardColumns
+	}
+
+	board := &models.Board{OwnerID: userID, WorkspaceID: workspaceID, ProjectID: input.ProjectID, Name: input.Name}
+	for i, column := range columns {
+		if err := validateTaskFields(column.Status, ""); err != nil {
+			return nil, err
+		}
+		board.Columns = append(board.Columns, models.BoardColumn{Name: column.Name, Status: column.Status, Position: i})
+	}
+
+	created, err := s.BoardRepo.CreateBoard(board)
+	if err != nil {
+		log.Printf("Error creating board for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to create board: %v", err)
+	}
+
+	log.Printf("Board %s created for user %s", created.ID, userID)
+	return created, nil
+}
+
+// GetBoard returns a board with its columns and ordered cards
+func (s *BoardServiceImpl) GetBoard(userID, boardID uuid.UUID) (*models.Board, error) {
+	board, err := s.BoardRepo.GetBoardWithCards(boardID, userID)
+	if err != nil {
+		log.Printf("Error fetching board %s for user %s: %v", boardID, userID, err)
+		return nil, fmt.Errorf("failed to fetch board: %v", err)
+	}
+	if board == nil {
+		return nil, ErrBoardNotFound
+	}
+	return board, nil
+}
+
+// ListBoards returns the user's boards in a workspace
+func (s *BoardServiceImpl) ListBoards(userID, workspaceID uuid.UUID) ([]models.Board, error) {
+	if _, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleGuest); err != nil {
+		return nil, err
+	}
+
+	boards, err := s.BoardRepo.ListBoards(userID, workspaceID)
+	if err != nil {
+		log.Printf("Error listing boards for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to list boards: %v", err)
+	}
+	if boards == nil {
+		boards = []models.Board{}
+	}
+	return boards, nil
+}
+
+// getBoard returns a board owned by the user, without columns
+func (s *BoardServiceImpl) getBoard(userID, boardID uuid.UUID) (*models.Board, error) {
+	board, err := s.BoardRepo.GetBoardByID(boardID, userID)
+	if err != nil {
+		log.Printf("Error fetching board %s for user %s: %v", boardID, userID, err)
+		return nil, fmt.Errorf("failed to fetch board: %v", err)
+	}
+	if board == nil {
+		return nil, ErrBoardNotFound
+	}
+	return board, nil
+}
+
+// getColumn returns a board owned by the user together with one of its columns
+func (s *BoardServiceImpl) getColumn(userID, boardID, columnID uuid.UUID) (*models.Board, *models.BoardColumn, error) {
+	board, err := s.getBoard(userID, boardID)
+	if err != nil {
+		return nil, nil, err
+	}
+	column, err := s.BoardRepo.GetColumn(columnID, boardID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch column: %v", err)
+	}
+	if column == nil {
+		return nil, nil, ErrColumnNotFound
+	}
+	return board, column, nil
+}
+
+// RenameBoard
+func (s *BoardServiceImpl) RenameBoard(userID, boardID uuid.UUID, name string) (*models.Board, error) {
+	board, err := s.getBoard(userID, boardID)
+	if err != nil {
+		return nil, err
+	}
+	board.Name = name
+	updated, err := s.BoardRepo.UpdateBoard(board)
+	if err != nil {
+		log.Printf("Error renaming board %s for user %s: %v", boardID, userID, err)
+		return nil, fmt.Errorf("failed to update board: %v", err)
+	}
+	return updated, nil
+}
+
+// DeleteBoard deletes a board; the tasks on it are kept
+func (s *BoardServiceImpl) DeleteBoard(userID, boardID uuid.UUID) error {
+	if _, err := s.getBoard(userID, boardID); err != nil {
+		return err
+	}
+	deleted, err := s.BoardRepo.DeleteBoard(boardID, userID)
+	if err != nil {
+		log.Printf("Error deleting board %s for user %s: %v", boardID, userID, err)
+		return fmt.Errorf("failed to delete board: %v", err)
+	}
+	if !deleted {
+		return ErrBoardNotFound
+	}
+	return nil
+}
+
+// AddColumn appends a column to a board
+func (s *BoardServiceImpl) AddColumn(userID, boardID uuid.UUID, input models.BoardColumnRequest) (*models.BoardColumn, error) {
+	board, err := s.GetBoard(userID, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTaskFields(input.Status, ""); err != nil {
+		return nil, err
+	}
+
+	position := 0
+	for _, column := range board.Columns {
+		if column.Position >= position {
+			position = column.Position + 1
+		}
+	}
+
+	column, err := s.BoardRepo.CreateColumn(&models.BoardColumn{
+		BoardID:  boardID,
+		Name:     input.Name,
+		Status:   input.Status,
+		Position: position,
+	})
+	if err != nil {
+		log.Printf("Error adding column to board %s: %v", boardID, err)
+		return nil, fmt.Errorf("failed to add column: %v", err)
+	}
+	return column, nil
+}
+
+// UpdateColumn renames, re-maps or repositions a column
+func (s *BoardServiceImpl) UpdateColumn(userID, boardID, columnID uuid.UUID, input models.UpdateBoardColumnRequest) (*models.BoardColumn, error) {
+	_, column, err := s.getColumn(userID, boardID, columnID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		column.Name = *input.Name
+	}
+	if input.Status != nil {
+		if err := validateTaskFields(*input.Status, ""); err != nil {
+			return nil, err
+		}
+		column.Status = *input.Status
+	}
+	if input.Position != nil {
+		column.Position = *input.Position
+	}
+
+	updated, err := s.BoardRepo.UpdateColumn(column)
+	if err != nil {
+		log.Printf("Error updating column %s of board %s: %v", columnID, boardID, err)
+		return nil, fmt.Errorf("failed to update column: %v", err)
+	}
+	return updated, nil
+}
+
+// DeleteColumn removes an empty column
+func (s *BoardServiceImpl) DeleteColumn(userID, boardID, columnID uuid.UUID) error {
+	if _, _, err := s.getColumn(userID, boardID, columnID); err != nil {
+		return err
+	}
+
+	count, err := s.BoardRepo.CountColumnCards(columnID)
+	if err != nil {
+		return fmt.Errorf("failed to count cards: %v", err)
+	}
+	if count > 0 {
+		return ErrColumnNotEmpty
+	}
+
+	if _, err := s.BoardRepo.DeleteColumn(columnID, boardID); err != nil {
+		log.Printf("Error deleting column %s of board %s: %v", columnID, boardID, err)
+		return fmt.Errorf("failed to delete column: %v", err)
+	}
+	return nil
+}
+
+// AddCard puts one of the user's tasks on a board at the end of a column. Without a column
+// the first column matching the task's status is used, falling back to the first column.
+func (s *BoardServiceImpl) AddCard(userID, boardID uuid.UUID, input models.AddCardRequest, meta models.RequestMeta) (*models.BoardCard, error) {
+	board, err := s.GetBoard(userID, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if len(board.Columns) == 0 {
+		return nil, ErrColumnNotFound
+	}
+
+	columnID := board.Columns[0].ID
+	if input.ColumnID != nil {
+		columnID = *input.ColumnID
+	} else if task, err := s.TaskRepo.GetTaskByID(input.TaskID, userID); err == nil && task != nil {
+		for _, column := range board.Columns {
+			if column.Status == task.Status {
+				columnID = column.ID
+				break
+			}
+		}
+	}
+
+	return s.MoveCard(userID, boardID, input.TaskID, models.MoveCardRequest{ColumnID: columnID}, meta)
+}
+
+// MoveCard moves a card to a position in a column. If the column maps to a different status,
+// the task's status changes in the same transaction and must be an allowed transition. Only
+// tasks of the board's workspace can be put on it.
+func (s *BoardServiceImpl) MoveCard(userID, boardID, taskID uuid.UUID, input models.MoveCardRequest, meta models.RequestMeta) (*models.BoardCard, error) {
+	board, column, err := s.getColumn(userID, boardID, input.ColumnID)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.TaskRepo.GetTaskByID(taskID, userID)
+	if err != nil {
+		log.Printf("Error fetching task %s for user %s: %v", taskID, userID, err)
+		return nil, fmt.Errorf("failed to fetch task: %v", err)
+	}
+	if task == nil || task.WorkspaceID != board.WorkspaceID {
+		return nil, ErrTaskNotFound
+	}
+
+	var transition *models.TaskStatusTransition
+	if column.Status != task.Status {
+		if transition, err = checkTransition(task, userID, column.Status, input.Reason); err != nil {
+			return nil, err
+		}
+		if column.Status == models.TaskStatusDone {
+			open, err := s.TaskRepo.CountOpenBlockers(task.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check blockers: %v", err)
+			}
+			if open > 0 {
+				return nil, fmt.Errorf("%w (%d open)", ErrTaskBlocked, open)
+			}
+		}
+	}
+
+	card, err := s.BoardRepo.PlaceCard(repositories.CardPlacement{
+		BoardID:      boardID,
+		TaskID:       taskID,
+		ColumnID:     column.ID,
+		AfterTaskID:  input.AfterTaskID,
+		BeforeTaskID: input.BeforeTaskID,
+		Transition:   transition,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrCardNotInColumn), errors.Is(err, repositories.ErrInvalidCardOrder):
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCardMove, err)
+		case errors.Is(err, repositories.ErrStatusChanged):
+			return nil, fmt.Errorf("%w: %v", ErrInvalidStatusTransition, err)
+		}
+		log.Printf("Error moving card %s on board %s: %v", taskID, boardID, err)
+		return nil, fmt.Errorf("failed to move card: %v", err)
+	}
+
+	if transition != nil {
+		previous := task.Status
+		task.Status = column.Status
+		s.TaskService.HandleStatusChange(task, previous)
+
+		event := taskAuditEvent(models.AuditActionTaskUpdate, userID, taskID, nil, nil)
+		event.Before = models.JSONMap{"status": previous}
+		event.After = models.JSONMap{"status": task.Status}
+		event.Metadata = models.JSONMap{"board_id": boardID}
+		s.Audit.Record(meta, event)
+	}
+	return card, nil
+}
+
+// RemoveCard takes a task off a board without changing the task
+func (s *BoardServiceImpl) RemoveCard(userID, boardID, taskID uuid.UUID) error {
+	if _, err := s.getBoard(userID, boardID); err != nil {
+		return err
+	}
+	removed, err := s.BoardRepo.RemoveCard(boardID, taskID)
+	if err != nil {
+		log.Printf("Error removing card %s from board %s: %v", taskID, boardID, err)
+		return fmt.Errorf("failed to remove card: %v", err)
+	}
+	if !removed {
+		return ErrCardNotFound
+	}
+	return nil
+}
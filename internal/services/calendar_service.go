@@ -0,0 +1,247 @@
+package service
+
+import (
+	config "TaskManagmentApis/configs"
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"TaskManagmentApis/pkg/utils"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrCalendarFeedNotFound is returned for unknown or revoked feed tokens and users without a feed
+var ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+
+const (
+	// calendarFeedPastDays keeps recently due tasks in the feed, so overdue and just finished work stays visible
+	calendarFeedPastDays = 90
+	calendarFeedMaxTasks = 2000
+)
+
+// CalendarService defines the interface for the iCalendar task feed
+type CalendarService interface {
+	GetFeed(userID uuid.UUID) (*models.CalendarFeed, error)
+	RegenerateFeedToken(userID uuid.UUID) (*models.CalendarFeedToken, error)
+	DeleteFeed(userID uuid.UUID) error
+	RenderFeed(token, entryType string) (string, error)
+}
+
+// CalendarServiceImpl is the concrete implementation of CalendarService
+type CalendarServiceImpl struct {
+	CalendarRepo repositories.CalendarRepository
+	UserRepo     repositories.AuthRepository
+	BaseURL      string
+}
+
+// NewCalendarService creates a new CalendarService instance
+func NewCalendarService(calendarRepo repositories.CalendarRepository, userRepo repositories.AuthRepository) CalendarService {
+	return &CalendarServiceImpl{
+		CalendarRepo: calendarRepo,
+		UserRepo:     userRepo,
+		BaseURL:      strings.TrimRight(config.Config.PublicBaseURL, "/"),
+	}
+}
+
+func hashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetFeed returns when the user's feed was created and last fetched; the token cannot be shown again
+func (s *CalendarServiceImpl) GetFeed(userID uuid.UUID) (*models.CalendarFeed, error) {
+	feed, err := s.CalendarRepo.GetFeed(userID)
+	if err != nil {
+		log.Printf("Error fetching calendar feed of user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to fetch calendar feed: %v", err)
+	}
+	if feed == nil {
+		return nil, ErrCalendarFeedNotFound
+	}
+	return feed, nil
+}
+
+// RegenerateFeedToken gives the user a new secret feed URL; the previous one stops working
+func (s *CalendarServiceImpl) RegenerateFeedToken(userID uuid.UUID) (*models.CalendarFeedToken, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate feed token: %v", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	feed := &models.CalendarFeed{UserID: userID, TokenHash: hashCalendarToken(token), CreatedAt: time.Now()}
+	if err := s.CalendarRepo.SaveFeed(feed); err != nil {
+		log.Printf("Error saving calendar feed of user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to save calendar feed: %v", err)
+	}
+
+	log.Printf("Calendar feed token regenerated for user %s", userID)
+	return &models.CalendarFeedToken{
+		URL:       s.BaseURL + "/calendar/" + token + ".ics",
+		CreatedAt: feed.CreatedAt,
+	}, nil
+}
+
+// DeleteFeed revokes the user's feed URL
+func (s *CalendarServiceImpl) DeleteFeed(userID uuid.UUID) error {
+	deleted, err := s.CalendarRepo.DeleteFeed(userID)
+	if err != nil {
+		log.Printf("Error deleting calendar feed of user %s: %v", userID, err)
+		return fmt.Errorf("failed to delete calendar feed: %v", err)
+	}
+	if !deleted {
+		return ErrCalendarFeedNotFound
+	}
+	return nil
+}
+
+// RenderFeed builds the iCalendar document for a feed token, with every task the user owns or is
+// assigned to that has a due date, as VEVENT or VTODO entries
+func (s *CalendarServiceImpl) RenderFeed(token, entryType string) (string, error) {
+	feed, err := s.CalendarRepo.GetFeedByTokenHash(hashCalendarToken(token))
+	if err != nil {
+		log.Printf("Error looking up calendar feed: %v", err)
+		return "", fmt.Errorf("failed to fetch calendar feed: %v", err)
+	}
+	if feed == nil {
+		return "", ErrCalendarFeedNotFound
+	}
+	user, err := s.UserRepo.GetUserByID(feed.UserID)
+	if err != nil {
+		log.Printf("Error fetching user %s for calendar feed: %v", feed.UserID, err)
+		return "", fmt.Errorf("failed to fetch calendar feed: %v", err)
+	}
+	if user == nil {
+		return "", ErrCalendarFeedNotFound
+	}
+
+	now := time.Now()
+	tasks, err := s.CalendarRepo.ListDueTasks(user.ID, now.AddDate(0, 0, -calendarFeedPastDays), calendarFeedMaxTasks)
+	if err != nil {
+		log.Printf("Error listing calendar tasks of user %s: %v", user.ID, err)
+		return "", fmt.Errorf("failed to list tasks: %v", err)
+	}
+	if err := s.CalendarRepo.TouchFeed(user.ID, now); err != nil {
+		log.Printf("Error recording calendar feed access of user %s: %v", user.ID, err)
+	}
+
+	loc := userLocation(user)
+	var w utils.ICalWriter
+	w.Line("BEGIN", "VCALENDAR")
+	w.Line("VERSION", "2.0")
+	w.Text("PRODID", "-//"+config.Config.AppName+"//Tasks//EN")
+	w.Line("CALSCALE", "GREGORIAN")
+	w.Line("METHOD", "PUBLISH")
+	w.Text("X-WR-CALNAME", "Tasks of "+user.Name)
+	w.Text("X-WR-TIMEZONE", loc.String())
+	for i := range tasks {
+		writeCalendarEntry(&w, &tasks[i], entryType, loc, now)
+	}
+	w.Line("END", "VCALENDAR")
+	return w.String(), nil
+}
+
+// writeCalendarEntry renders one task. Times are written in UTC, which every client converts to
+// its own zone; a due date at midnight in the user's zone is taken to mean the whole day.
+func writeCalendarEntry(w *utils.ICalWriter, task *models.Task, entryType string, loc *time.Location, stamp time.Time) {
+	due := task.DueDate.In(loc)
+	allDay := due.Hour() == 0 && due.Minute() == 0 && due.Second() == 0 && due.Nanosecond() == 0
+
+	component := "VEVENT"
+	if entryType == models.CalendarEntryTodo {
+		component = "VTODO"
+	}
+	w.Line("BEGIN", component)
+	w.Line("UID", task.ID.String()+"@"+strings.ToLower(config.Config.AppName))
+	w.Line("DTSTAMP", utils.ICalDateTime(stamp))
+	w.Line("CREATED", utils.ICalDateTime(task.CreatedAt))
+	w.Line("LAST-MODIFIED", utils.ICalDateTime(task.UpdatedAt))
+	w.Line("SEQUENCE", strconv.Itoa(task.Version-1))
+	summary := task.Title
+	if component == "VEVENT" && task.Status == models.TaskStatusDone {
+		// an event has no completed status, so finished tasks are marked in the title
+		summary = "✓ " + summary
+	}
+	w.Text("SUMMARY", summary)
+	if task.Description != "" {
+		w.Text("DESCRIPTION", task.Description)
+	}
+	if priority := iCalPriority(task.Priority); priority > 0 {
+		w.Line("PRIORITY", strconv.Itoa(priority))
+	}
+	if len(task.Tags) > 0 {
+		names := make([]string, len(task.Tags))
+		for i, tag := range task.Tags {
+			names[i] = utils.ICalEscapeText(tag.Name)
+		}
+		w.Line("CATEGORIES", strings.Join(names, ","))
+	}
+
+	if component == "VTODO" {
+		if allDay {
+			w.Line("DUE;VALUE=DATE", utils.ICalDate(due))
+		} else {
+			w.Line("DUE", utils.ICalDateTime(due))
+		}
+		w.Line("STATUS", iCalTodoStatus(task.Status))
+		if task.Status == models.TaskStatusDone {
+			w.Line("PERCENT-COMPLETE", "100")
+			w.Line("COMPLETED", utils.ICalDateTime(task.UpdatedAt))
+		}
+	} else {
+		if allDay {
+			w.Line("DTSTART;VALUE=DATE", utils.ICalDate(due))
+			w.Line("DTEND;VALUE=DATE", utils.ICalDate(due.AddDate(0, 0, 1)))
+		} else {
+			// no DTEND: the event is the instant the task is due
+			w.Line("DTSTART", utils.ICalDateTime(due))
+		}
+		w.Line("STATUS", iCalEventStatus(task.Status))
+		w.Line("TRANSP", "TRANSPARENT")
+	}
+	w.Line("END", component)
+}
+
+// iCalPriority maps task priorities onto the 1 (highest) to 9 (lowest) scale; 0 means undefined
+func iCalPriority(priority string) int {
+	switch priority {
+	case models.TaskPriorityUrgent:
+		return 1
+	case models.TaskPriorityHigh:
+		return 3
+	case models.TaskPriorityMedium:
+		return 5
+	case models.TaskPriorityLow:
+		return 9
+	}
+	return 0
+}
+
+func iCalTodoStatus(status string) string {
+	switch status {
+	case models.TaskStatusInProgress:
+		return "IN-PROCESS"
+	case models.TaskStatusDone:
+		return "COMPLETED"
+	case models.TaskStatusCancelled:
+		return "CANCELLED"
+	}
+	return "NEEDS-ACTION"
+}
+
+// iCalEventStatus only has CANCELLED to say about a task; done is shown in the summary instead
+func iCalEventStatus(status string) string {
+	if status == models.TaskStatusCancelled {
+		return "CANCELLED"
+	}
+	return "CONFIRMED"
+}
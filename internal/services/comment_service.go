@@ -0,0 +1,300 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"TaskManagmentApis/pkg/utils"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrCommentNotFound is returned when a comment does not exist, is deleted or belongs to another task
+var ErrCommentNotFound = errors.New("comment not found")
+
+// ErrCommentForbidden is returned when someone other than the author or task owner changes a comment
+var ErrCommentForbidden = errors.New("only the comment author or the task owner can change this comment")
+
+// ErrInvalidCommentInput is returned for empty bodies or replies to unknown comments
+var ErrInvalidCommentInput = errors.New("invalid comment input")
+
+// CommentService defines the interface for task comment operations
+type CommentService interface {
+	ListComments(userID, taskID uuid.UUID) ([]*models.Comment, error)
+	CreateComment(userID, taskID uuid.UUID, input models.CreateCommentRequest) (*models.Comment, error)
+	UpdateComment(userID, taskID, commentID uuid.UUID, input models.UpdateCommentRequest) (*models.Comment, error)
+	DeleteComment(userID, taskID, commentID uuid.UUID) error
+	GetRevisions(userID, taskID, commentID uuid.UUID) ([]models.CommentRevision, error)
+}
+
+// CommentServiceImpl is the concrete implementation of CommentService
+type CommentServiceImpl struct {
+	CommentRepo         repositories.CommentRepository
+	TaskRepo            repositories.TaskRepository
+	NotificationService NotificationService
+}
+
+// NewCommentService creates a new CommentService instance
+func NewCommentService(commentRepo repositories.CommentRepository, taskRepo repositories.TaskRepository, notificationService NotificationService) CommentService {
+	return &CommentServiceImpl{
+		CommentRepo:         commentRepo,
+		TaskRepo:            taskRepo,
+		NotificationService: notificationService,
+	}
+}
+
+// getTask returns the task if the user may read and comment on it: its owner or an assignee
+func (s *CommentServiceImpl) getTask(userID, taskID uuid.UUID) (*models.Task, error) {
+	task, err := s.TaskRepo.GetAccessibleTask(taskID, userID)
+	if err != nil {
+		log.Printf("Error fetching task %s for user %s: %v", taskID, userID, err)
+		return nil, fmt.Errorf("failed to fetch task: %v", err)
+	}
+	if task == nil {
+		return nil, ErrTaskNotFound
+	}
+	return task, nil
+}
+
+// getEditableComment returns the task and a live comment of it that the user is allowed to change
+func (s *CommentServiceImpl) getEditableComment(userID, taskID, commentID uuid.UUID) (*models.Task, *models.Comment, error) {
+	task, err := s.getTask(userID, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	comment, err := s.CommentRepo.GetCommentByID(commentID, taskID)
+	if err != nil {
+		log.Printf("Error fetching comment %s of task %s: %v", commentID, taskID, err)
+		return nil, nil, fmt.Errorf("failed to fetch comment: %v", err)
+	}
+	if comment == nil {
+		return nil, nil, ErrCommentNotFound
+	}
+	if comment.AuthorID != userID && task.UserID != userID {
+		return nil, nil, ErrCommentForbidden
+	}
+	return task, comment, nil
+}
+
+// resolveMentions maps the mentions in body to the IDs of users who can see the task: its owner and
+// assignees. Emails match exactly one user; a name only counts when it is unambiguous, so "@alex"
+// with two Alexes notifies nobody. Mentions of anyone else are dropped, so a notification never
+// points someone at a task they cannot open.
+func (s *CommentServiceImpl) resolveMentions(task *models.Task, body string) ([]uuid.UUID, error) {
+	mentions := utils.ParseMentions(body)
+	if mentions.IsEmpty() {
+		return nil, nil
+	}
+	users, err := s.CommentRepo.FindMentionedUsers(task.ID, task.WorkspaceID, mentions.Emails, mentions.Names)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make(map[string]bool, len(mentions.Emails))
+	for _, email := range mentions.Emails {
+		emails[email] = true
+	}
+	names := make(map[string]bool, len(mentions.Names))
+	for _, name := range mentions.Names {
+		names[name] = true
+	}
+	byName := make(map[string][]uuid.UUID)
+	for _, user := range users {
+		name := strings.ToLower(user.Name)
+		if names[name] {
+			byName[name] = append(byName[name], user.ID)
+		}
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var ids []uuid.UUID
+	add := func(id uuid.UUID) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, user := range users {
+		if emails[strings.ToLower(user.Email)] {
+			add(user.ID)
+		}
+	}
+	for _, matches := range byName {
+		if len(matches) == 1 {
+			add(matches[0])
+		}
+	}
+	return ids, nil
+}
+
+// notifyMentioned sends a mention notification to each newly mentioned user
+func (s *CommentServiceImpl) notifyMentioned(actorID uuid.UUID, comment *models.Comment, userIDs []uuid.UUID) {
+	notifications := make([]models.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		notifications = append(notifications, models.Notification{
+			UserID:    userID,
+			ActorID:   actorID,
+			Type:      models.NotificationTypeMention,
+			TaskID:    &comment.TaskID,
+			CommentID: &comment.ID,
+		})
+	}
+	s.NotificationService.Notify(notifications...)
+}
+
+// ListComments returns the task's comments as threads. Deleted comments keep their place
+// with an empty body while they still have replies.
+func (s *CommentServiceImpl) ListComments(userID, taskID uuid.UUID) ([]*models.Comment, error) {
+	if _, err := s.getTask(userID, taskID); err != nil {
+		return nil, err
+	}
+	comments, err := s.CommentRepo.ListComments(taskID)
+	if err != nil {
+		log.Printf("Error listing comments of task %s: %v", taskID, err)
+		return nil, fmt.Errorf("failed to list comments: %v", err)
+	}
+	return buildCommentThreads(comments), nil
+}
+
+// buildCommentThreads nests replies under their parents and prunes deleted leaves
+func buildCommentThreads(comments []*models.Comment) []*models.Comment {
+	byID := make(map[uuid.UUID]*models.Comment, len(comments))
+	for _, comment := range comments {
+		byID[comment.ID] = comment
+	}
+
+	roots := make([]*models.Comment, 0)
+	for _, comment := range comments {
+		if comment.DeletedAt.Valid {
+			comment.Body = ""
+			comment.Mentions = nil
+		}
+		if comment.ParentID != nil {
+			if parent, ok := byID[*comment.ParentID]; ok {
+				parent.Replies = append(parent.Replies, comment)
+				continue
+			}
+		}
+		roots = append(roots, comment)
+	}
+	return pruneDeletedComments(roots)
+}
+
+func pruneDeletedComments(comments []*models.Comment) []*models.Comment {
+	kept := comments[:0]
+	for _, comment := range comments {
+		comment.Replies = pruneDeletedComments(comment.Replies)
+		if comment.DeletedAt.Valid && len(comment.Replies) == 0 {
+			continue
+		}
+		kept = append(kept, comment)
+	}
+	return kept
+}
+
+// CreateComment adds a comment or reply and notifies the users it mentions
+func (s *CommentServiceImpl) CreateComment(userID, taskID uuid.UUID, input models.CreateCommentRequest) (*models.Comment, error) {
+	body := strings.TrimSpace(input.Body)
+	if body == "" {
+		return nil, fmt.Errorf("%w: body is required", ErrInvalidCommentInput)
+	}
+	task, err := s.getTask(userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if input.ParentID != nil {
+		parent, err := s.CommentRepo.GetCommentByID(*input.ParentID, taskID)
+		if err != nil {
+			log.Printf("Error fetching comment %s of task %s: %v", *input.ParentID, taskID, err)
+			return nil, fmt.Errorf("failed to fetch parent comment: %v", err)
+		}
+		if parent == nil {
+			return nil, fmt.Errorf("%w: parent comment not found", ErrInvalidCommentInput)
+		}
+	}
+
+	mentionIDs, err := s.resolveMentions(task, body)
+	if err != nil {
+		log.Printf("Error resolving mentions for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to resolve mentions: %v", err)
+	}
+
+	comment, err := s.CommentRepo.CreateComment(&models.Comment{
+		TaskID:   taskID,
+		ParentID: input.ParentID,
+		AuthorID: userID,
+		Body:     body,
+	}, mentionIDs)
+	if err != nil {
+		log.Printf("Error creating comment on task %s for user %s: %v", taskID, userID, err)
+		return nil, fmt.Errorf("failed to create comment: %v", err)
+	}
+
+	s.notifyMentioned(userID, comment, mentionIDs)
+	return comment, nil
+}
+
+// UpdateComment edits a comment, keeping the previous body as a revision. Only users
+// mentioned for the first time are notified.
+func (s *CommentServiceImpl) UpdateComment(userID, taskID, commentID uuid.UUID, input models.UpdateCommentRequest) (*models.Comment, error) {
+	body := strings.TrimSpace(input.Body)
+	if body == "" {
+		return nil, fmt.Errorf("%w: body is required", ErrInvalidCommentInput)
+	}
+	task, _, err := s.getEditableComment(userID, taskID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	mentionIDs, err := s.resolveMentions(task, body)
+	if err != nil {
+		log.Printf("Error resolving mentions for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to resolve mentions: %v", err)
+	}
+
+	comment, added, err := s.CommentRepo.UpdateComment(commentID, userID, body, mentionIDs)
+	if err != nil {
+		log.Printf("Error updating comment %s for user %s: %v", commentID, userID, err)
+		return nil, fmt.Errorf("failed to update comment: %v", err)
+	}
+	if comment == nil {
+		return nil, ErrCommentNotFound
+	}
+
+	s.notifyMentioned(userID, comment, added)
+	return comment, nil
+}
+
+// DeleteComment soft deletes a comment
+func (s *CommentServiceImpl) DeleteComment(userID, taskID, commentID uuid.UUID) error {
+	if _, _, err := s.getEditableComment(userID, taskID, commentID); err != nil {
+		return err
+	}
+
+	deleted, err := s.CommentRepo.DeleteComment(commentID)
+	if err != nil {
+		log.Printf("Error deleting comment %s for user %s: %v", commentID, userID, err)
+		return fmt.Errorf("failed to delete comment: %v", err)
+	}
+	if !deleted {
+		return ErrCommentNotFound
+	}
+	return nil
+}
+
+// GetRevisions returns the edit history of a comment to its author or the task owner
+func (s *CommentServiceImpl) GetRevisions(userID, taskID, commentID uuid.UUID) ([]models.CommentRevision, error) {
+	if _, _, err := s.getEditableComment(userID, taskID, commentID); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.CommentRepo.GetRevisions(commentID)
+	if err != nil {
+		log.Printf("Error fetching revisions of comment %s: %v", commentID, err)
+		return nil, fmt.Errorf("failed to fetch revisions: %v", err)
+	}
+	return revisions, nil
+}
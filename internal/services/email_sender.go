@@ -0,0 +1,106 @@
+package service
+
+import (
+	config "TaskManagmentApis/configs"
+	"TaskManagmentApis/internal/mail"
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	emailBatchSize = 20
+	// emailSendTimeout bounds one send, connecting to the relay included
+	emailSendTimeout = mail.DefaultTimeout
+	// emailSendLease must outlast sending a whole claimed batch one email after another, or the
+	// last emails of a slow batch could be claimed and sent again by another server
+	emailSendLease  = emailBatchSize*emailSendTimeout + time.Minute
+	maxEmailBackoff = 6 * time.Hour
+)
+
+// EmailSender delivers the outbox through a Mailer, retrying failed emails with exponential backoff
+type EmailSender struct {
+	EmailRepo   repositories.EmailRepository
+	Mailer      mail.Mailer
+	From        string
+	MaxAttempts int
+	RetryBase   time.Duration
+	Interval    time.Duration
+}
+
+// NewEmailSender creates an EmailSender configured from MAIL_FROM, MAIL_MAX_ATTEMPTS,
+// MAIL_RETRY_BASE_SECONDS and MAIL_POLL_INTERVAL_SECONDS
+func NewEmailSender(emailRepo repositories.EmailRepository, mailer mail.Mailer) *EmailSender {
+	return &EmailSender{
+		EmailRepo:   emailRepo,
+		Mailer:      mailer,
+		From:        config.Config.MailFrom,
+		MaxAttempts: config.Config.MailMaxAttempts,
+		RetryBase:   time.Duration(config.Config.MailRetryBaseSecs) * time.Second,
+		Interval:    time.Duration(config.Config.MailPollIntervalSecs) * time.Second,
+	}
+}
+
+// Start polls the outbox right away and then every MAIL_POLL_INTERVAL_SECONDS until ctx is
+// cancelled. With an interval of 0 emails pile up in the outbox until another instance sends them.
+func (s *EmailSender) Start(ctx context.Context) {
+	if s.Interval <= 0 {
+		log.Println("Email sender disabled")
+		return
+	}
+
+	runPeriodic(ctx, s.Interval, func(now time.Time) {
+		s.SendDue(ctx, now)
+	})
+}
+
+// SendDue sends every outbox email whose next attempt has come, batch by batch
+func (s *EmailSender) SendDue(ctx context.Context, now time.Time) {
+	for ctx.Err() == nil {
+		emails, err := s.EmailRepo.ClaimDueEmails(now, emailSendLease, emailBatchSize)
+		if err != nil {
+			log.Printf("Error claiming outbound emails: %v", err)
+			return
+		}
+		for i := range emails {
+			s.send(ctx, &emails[i])
+		}
+		if len(emails) < emailBatchSize {
+			return
+		}
+		// the next batch's lease has to run from when it is claimed, not from when this run began
+		now = time.Now()
+	}
+}
+
+func (s *EmailSender) send(ctx context.Context, email *models.OutboundEmail) {
+	sendCtx, cancel := context.WithTimeout(ctx, emailSendTimeout)
+	defer cancel()
+	err := s.Mailer.Send(sendCtx, &mail.Message{
+		From:    s.From,
+		To:      []string{email.Recipient},
+		Subject: email.Subject,
+		Text:    email.TextBody,
+		HTML:    email.HTMLBody,
+	})
+	now := time.Now()
+	if err == nil {
+		if err := s.EmailRepo.MarkEmailSent(email.ID, now); err != nil {
+			log.Printf("Error marking email %s sent: %v", email.ID, err)
+		}
+		return
+	}
+
+	status, next := models.EmailStatusPending, now.Add(retryBackoff(s.RetryBase, maxEmailBackoff, email.Attempts))
+	if mail.IsPermanent(err) || email.Attempts >= s.MaxAttempts {
+		status = models.EmailStatusFailed
+		log.Printf("Giving up on %s email %s after %d attempts: %v", email.Template, email.ID, email.Attempts, err)
+	} else {
+		log.Printf("Sending %s email %s failed (attempt %d), retrying at %s: %v", email.Template, email.ID, email.Attempts, next.Format(time.RFC3339), err)
+	}
+	if err := s.EmailRepo.MarkEmailFailed(email.ID, status, next, err.Error()); err != nil {
+		log.Printf("Error recording failure of email %s: %v", email.ID, err)
+	}
+}
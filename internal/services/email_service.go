@@ -0,0 +1,61 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/mail"
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"fmt"
+	"log"
+	"time"
+)
+
+// EmailService renders transactional emails and puts them in the outbox; EmailSender delivers them
+type EmailService interface {
+	Compose(template, recipient string, data interface{}) (*models.OutboundEmail, error)
+	Enqueue(template, recipient string, data interface{})
+}
+
+// EmailServiceImpl is the concrete implementation of EmailService
+type EmailServiceImpl struct {
+	EmailRepo repositories.EmailRepository
+	Templates *mail.Templates
+}
+
+// NewEmailService creates a new EmailService instance
+func NewEmailService(emailRepo repositories.EmailRepository, templates *mail.Templates) EmailService {
+	return &EmailServiceImpl{
+		EmailRepo: emailRepo,
+		Templates: templates,
+	}
+}
+
+// Compose renders an email for the outbox without storing it, for callers that store it in their
+// own transaction
+func (s *EmailServiceImpl) Compose(template, recipient string, data interface{}) (*models.OutboundEmail, error) {
+	msg, err := s.Templates.Render(template, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render email: %v", err)
+	}
+	return &models.OutboundEmail{
+		Recipient:     recipient,
+		Template:      template,
+		Subject:       msg.Subject,
+		TextBody:      msg.Text,
+		HTMLBody:      msg.HTML,
+		Status:        models.EmailStatusPending,
+		NextAttemptAt: time.Now(),
+	}, nil
+}
+
+// Enqueue renders an email and stores it in the outbox. It returns nothing because a member
+// being added should not fail over the email telling them about it; failures are logged.
+func (s *EmailServiceImpl) Enqueue(template, recipient string, data interface{}) {
+	email, err := s.Compose(template, recipient, data)
+	if err != nil {
+		log.Printf("Error composing %s email: %v", template, err)
+		return
+	}
+	if err := s.EmailRepo.EnqueueEmails([]models.OutboundEmail{*email}); err != nil {
+		log.Printf("Error queueing %s email: %v", template, err)
+	}
+}
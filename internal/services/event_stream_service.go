@@ -0,0 +1,256 @@
+package service
+
+import (
+	config "TaskManagmentApis/configs"
+	"TaskManagmentApis/internal/database"
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// eventStreamChannel carries stream events between servers, so every connected client hears
+	// about changes made through any of them
+	eventStreamChannel = "events:stream"
+	// eventSubscriptionBuffer is how far a client may fall behind before its stream is closed;
+	// it then reconnects and catches up from the replay buffer
+	eventSubscriptionBuffer = 64
+)
+
+// EventSubscription is one connected client's stream. Events is closed when the client falls too
+// far behind, after which it should reconnect with the ID of the last event it received.
+type EventSubscription struct {
+	UserID uuid.UUID
+	Events chan models.StreamEvent
+	closed bool
+}
+
+// EventStreamService defines the interface for pushing task changes to connected clients
+type EventStreamService interface {
+	Subscribe(userID uuid.UUID, lastEventID int64) (*EventSubscription, []models.StreamEvent, bool)
+	Unsubscribe(subscription *EventSubscription)
+	AuditEventRecorded(event *models.AuditEvent)
+	Start(ctx context.Context)
+}
+
+// EventStreamServiceImpl is the concrete implementation of EventStreamService. Events travel through
+// Redis to every server, each of which keeps the latest ReplaySize in memory for resuming clients.
+type EventStreamServiceImpl struct {
+	TaskRepo   repositories.TaskRepository
+	AuditRepo  repositories.AuditRepository
+	Redis      database.RedisService
+	ReplaySize int
+
+	mu            sync.Mutex
+	subscriptions map[uuid.UUID]map[*EventSubscription]struct{}
+	replay        []models.StreamEvent
+	// replayAfter is the ID after which replay holds every event; resuming from an older one misses some
+	replayAfter int64
+}
+
+// NewEventStreamService creates a new EventStreamService keeping EVENTS_REPLAY_BUFFER_SIZE events for resumes
+func NewEventStreamService(taskRepo repositories.TaskRepository, auditRepo repositories.AuditRepository, redis database.RedisService) EventStreamService {
+	return &EventStreamServiceImpl{
+		TaskRepo:      taskRepo,
+		AuditRepo:     auditRepo,
+		Redis:         redis,
+		ReplaySize:    config.Config.EventsReplayBufferSize,
+		subscriptions: make(map[uuid.UUID]map[*EventSubscription]struct{}),
+	}
+}
+
+// Start relays the events published by every server to the clients connected to this one until
+// ctx is cancelled. Events from before Start are not in the replay buffer, so clients resuming
+// from them are told to reload.
+func (s *EventStreamServiceImpl) Start(ctx context.Context) {
+	pubsub := s.Redis.Subscribe(ctx, eventStreamChannel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		log.Printf("Error subscribing to %s, retrying in the background: %v", eventStreamChannel, err)
+	}
+
+	latestID, err := s.AuditRepo.LatestEventID()
+	if err != nil {
+		log.Printf("Error reading the latest audit event ID: %v", err)
+	}
+	s.mu.Lock()
+	if s.replayAfter < latestID {
+		s.replayAfter = latestID
+	}
+	s.mu.Unlock()
+
+	go func() {
+		defer pubsub.Close()
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				var event models.StreamEvent
+				if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+					log.Printf("Error decoding stream event: %v", err)
+					continue
+				}
+				s.dispatch(event)
+			}
+		}
+	}()
+}
+
+// Subscribe connects a client of the user. When lastEventID is set, the buffered events after it
+// are returned to be sent first; reset reports that some were lost and the client should reload.
+func (s *EventStreamServiceImpl) Subscribe(userID uuid.UUID, lastEventID int64) (*EventSubscription, []models.StreamEvent, bool) {
+	subscription := &EventSubscription{
+		UserID: userID,
+		Events: make(chan models.StreamEvent, eventSubscriptionBuffer),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var missed []models.StreamEvent
+	reset := false
+	if lastEventID > 0 {
+		reset = lastEventID < s.replayAfter
+		for _, event := range s.replay {
+			if event.ID > lastEventID && event.IsFor(userID) {
+				missed = append(missed, event)
+			}
+		}
+	}
+
+	if s.subscriptions[userID] == nil {
+		s.subscriptions[userID] = make(map[*EventSubscription]struct{})
+	}
+	s.subscriptions[userID][subscription] = struct{}{}
+	return subscription, missed, reset
+}
+
+// Unsubscribe disconnects a client
+func (s *EventStreamServiceImpl) Unsubscribe(subscription *EventSubscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.subscriptions[subscription.UserID], subscription)
+	if len(s.subscriptions[subscription.UserID]) == 0 {
+		delete(s.subscriptions, subscription.UserID)
+	}
+	s.close(subscription)
+}
+
+// close ends a subscription's stream; s.mu must be held
+func (s *EventStreamServiceImpl) close(subscription *EventSubscription) {
+	if !subscription.closed {
+		subscription.closed = true
+		close(subscription.Events)
+	}
+}
+
+// dispatch buffers an event for resumes and hands it to its recipients' connected clients. A
+// client whose buffer is full is disconnected rather than holding everyone else up.
+func (s *EventStreamServiceImpl) dispatch(event models.StreamEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if event.ID > 0 && s.ReplaySize > 0 {
+		if len(s.replay) >= s.ReplaySize {
+			if evicted := s.replay[0].ID; evicted > s.replayAfter {
+				s.replayAfter = evicted
+			}
+			s.replay = append(s.replay[:0], s.replay[1:]...)
+		}
+		s.replay = append(s.replay, event)
+	}
+
+	for _, userID := range event.Recipients {
+		for subscription := range s.subscriptions[userID] {
+			if subscription.closed {
+				continue
+			}
+			select {
+			case subscription.Events <- event:
+			default:
+				log.Printf("Event stream of user %s fell behind, disconnecting it", userID)
+				s.close(subscription)
+			}
+		}
+	}
+}
+
+// publish sends an event to every server, or only to this one's clients when Redis is unavailable
+func (s *EventStreamServiceImpl) publish(event models.StreamEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Error encoding %s stream event: %v", event.Type, err)
+		return
+	}
+	if err := s.Redis.Publish(context.Background(), eventStreamChannel, payload); err != nil {
+		log.Printf("Error publishing %s stream event, delivering it locally: %v", event.Type, err)
+		s.dispatch(event)
+	}
+}
+
+// AuditEventRecorded turns an audited task change into stream events for the task's owner and
+// assignees. A restored task reappears, so it is announced as created; assignees who were removed
+// lose sight of the task, so for them it is deleted.
+func (s *EventStreamServiceImpl) AuditEventRecorded(event *models.AuditEvent) {
+	if event.EntityType != models.AuditEntityTask {
+		return
+	}
+	var eventType string
+	switch event.Action {
+	case models.AuditActionTaskCreate, models.AuditActionTaskRestore:
+		eventType = models.StreamEventTaskCreated
+	case models.AuditActionTaskUpdate, models.AuditActionTaskMove, models.AuditActionTaskAssignees,
+		models.AuditActionTaskProjectChange, models.AuditActionTaskDependencyAdd, models.AuditActionTaskDependencyDrop,
+		models.AuditActionTaskTags, models.AuditActionTaskAttachmentAdd, models.AuditActionTaskAttachmentDrop:
+		eventType = models.StreamEventTaskUpdated
+	case models.AuditActionTaskDelete:
+		eventType = models.StreamEventTaskDeleted
+	default:
+		return
+	}
+	taskID, err := uuid.Parse(event.EntityID)
+	if err != nil {
+		return
+	}
+
+	task, recipients, err := s.TaskRepo.GetTaskSubscribers(taskID)
+	if err != nil {
+		log.Printf("Error fetching stream recipients of task %s: %v", taskID, err)
+		return
+	}
+	if task == nil {
+		return
+	}
+
+	data := map[string]interface{}{"task_id": taskID}
+	if eventType != models.StreamEventTaskDeleted {
+		data = map[string]interface{}{"task": task}
+		if event.Before != nil && event.After != nil {
+			data["changes"] = map[string]interface{}{"before": event.Before, "after": event.After}
+		}
+	}
+	events := []models.StreamEvent{{ID: event.ID, Type: eventType, Recipients: recipients}}
+	events[0].Data, err = json.Marshal(data)
+	if err != nil {
+		log.Printf("Error encoding %s stream event: %v", eventType, err)
+		return
+	}
+	if removed, ok := event.Metadata["removed"].([]uuid.UUID); ok && len(removed) > 0 {
+		unassigned, _ := json.Marshal(map[string]interface{}{"task_id": taskID})
+		events = append(events, models.StreamEvent{ID: event.ID, Type: models.StreamEventTaskDeleted, Recipients: removed, Data: unassigned})
+	}
+
+	for _, streamed := range events {
+		s.publish(streamed)
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotificationNotFound is returned when a notification does not exist or belongs to another user
+var ErrNotificationNotFound = errors.New("notification not found")
+
+const defaultNotificationLimit = 50
+
+// NotificationService defines the interface for creating and reading notifications
+type NotificationService interface {
+	Notify(notifications ...models.Notification)
+	ListNotifications(userID uuid.UUID, query models.NotificationListQuery) ([]models.Notification, int64, error)
+	MarkRead(userID, notificationID uuid.UUID) error
+	MarkAllRead(userID uuid.UUID) (int64, error)
+}
+
+// NotificationServiceImpl is the concrete implementation of NotificationService
+type NotificationServiceImpl struct {
+	NotificationRepo repositories.NotificationRepository
+}
+
+// NewNotificationService creates a new NotificationService instance
+func NewNotificationService(notificationRepo repositories.NotificationRepository) NotificationService {
+	return &NotificationServiceImpl{
+		NotificationRepo: notificationRepo,
+	}
+}
+
+// Notify stores notifications for their recipients. Users are never notified about their
+// own actions. Failures are logged rather than returned: the action that triggered the
+// notification has already happened and must not be reported as failed.
+func (s *NotificationServiceImpl) Notify(notifications ...models.Notification) {
+	pending := make([]models.Notification, 0, len(notifications))
+	for _, n := range notifications {
+		if n.UserID != n.ActorID {
+			pending = append(pending, n)
+		}
+	}
+	if err := s.NotificationRepo.CreateNotifications(pending); err != nil {
+		log.Printf("Error creating %d notifications: %v", len(pending), err)
+	}
+}
+
+// ListNotifications returns the user's newest notifications and the number still unread
+func (s *NotificationServiceImpl) ListNotifications(userID uuid.UUID, query models.NotificationListQuery) ([]models.Notification, int64, error) {
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultNotificationLimit
+	}
+
+	notifications, err := s.NotificationRepo.ListNotifications(userID, query.UnreadOnly, limit)
+	if err != nil {
+		log.Printf("Error listing notifications for user %s: %v", userID, err)
+		return nil, 0, fmt.Errorf("failed to list notifications: %v", err)
+	}
+	unread, err := s.NotificationRepo.CountUnread(userID)
+	if err != nil {
+		log.Printf("Error counting unread notifications for user %s: %v", userID, err)
+		return nil, 0, fmt.Errorf("failed to count notifications: %v", err)
+	}
+	return notifications, unread, nil
+}
+
+// MarkRead
+func (s *NotificationServiceImpl) MarkRead(userID, notificationID uuid.UUID) error {
+	found, err := s.NotificationRepo.MarkRead(notificationID, userID)
+	if err != nil {
+		log.Printf("Error marking notification %s read for user %s: %v", notificationID, userID, err)
+		return fmt.Errorf("failed to update notification: %v", err)
+	}
+	if !found {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+// MarkAllRead
+func (s *NotificationServiceImpl) MarkAllRead(userID uuid.UUID) (int64, error) {
+	count, err := s.NotificationRepo.MarkAllRead(userID)
+	if err != nil {
+		log.Printf("Error marking notifications read for user %s: %v", userID, err)
+		return 0, fmt.Errorf("failed to update notifications: %v", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// runPeriodic calls fn in the background right away and then every interval until ctx is
+// cancelled. A run that takes longer than the interval delays the next one instead of overlapping
+// it. interval must be positive.
+func runPeriodic(ctx context.Context, interval time.Duration, fn func(now time.Time)) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			fn(time.Now())
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
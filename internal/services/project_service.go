@@ -0,0 +1,205 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrProjectNotFound is returned when a project does not exist or belongs to another user
+var ErrProjectNotFound = errors.New("project not found")
+
+// ErrProjectArchived is returned when adding tasks to an archived project
+var ErrProjectArchived = errors.New("project is archived")
+
+const defaultProjectColor = "#808080"
+
+// ProjectService defines the interface for project operations
+type ProjectService interface {
+	CreateProject(userID, workspaceID uuid.UUID, input models.CreateProjectRequest) (*models.Project, error)
+	GetProject(userID, projectID uuid.UUID) (*models.Project, error)
+	ListProjects(userID, workspaceID uuid.UUID, includeArchived bool) ([]models.Project, error)
+	UpdateProject(userID, projectID uuid.UUID, input models.UpdateProjectRequest) (*models.Project, error)
+	DeleteProject(userID, projectID uuid.UUID) error
+	MoveTasks(userID, workspaceID uuid.UUID, input models.MoveTasksRequest, meta models.RequestMeta) error
+	GetSummary(userID, projectID uuid.UUID) (*models.ProjectSummary, error)
+}
+
+// ProjectServiceImpl is the concrete implementation of ProjectService
+type ProjectServiceImpl struct {
+	ProjectRepo   repositories.ProjectRepository
+	WorkspaceRepo repositories.WorkspaceRepository
+	Audit         AuditService
+}
+
+// NewProjectService creates a new ProjectService instance
+func NewProjectService(projectRepo repositories.ProjectRepository, workspaceRepo repositories.WorkspaceRepository, auditService AuditService) ProjectService {
+	return &ProjectServiceImpl{
+		ProjectRepo:   projectRepo,
+		WorkspaceRepo: workspaceRepo,
+		Audit:         auditService,
+	}
+}
+
+// CreateProject creates a project owned by the user in a workspace; guests cannot create projects
+func (s *ProjectServiceImpl) CreateProject(userID, workspaceID uuid.UUID, input models.CreateProjectRequest) (*models.Project, error) {
+	if _, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleMember); err != nil {
+		return nil, err
+	}
+
+	color := input.Color
+	if color == "" {
+		color = defaultProjectColor
+	}
+
+	project, err := s.ProjectRepo.CreateProject(&models.Project{
+		OwnerID:     userID,
+		WorkspaceID: workspaceID,
+		Name:        strings.TrimSpace(input.Name),
+		Description: input.Description,
+		Color:       color,
+	})
+	if err != nil {
+		log.Printf("Error creating project for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to create project: %v", err)
+	}
+
+	log.Printf("Project %s created for user %s", project.ID, userID)
+	return project, nil
+}
+
+// GetProject returns a project owned by the user
+func (s *ProjectServiceImpl) GetProject(userID, projectID uuid.UUID) (*models.Project, error) {
+	project, err := s.ProjectRepo.GetProjectByID(projectID, userID)
+	if err != nil {
+		log.Printf("Error fetching project %s for user %s: %v", projectID, userID, err)
+		return nil, fmt.Errorf("failed to fetch project: %v", err)
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+	return project, nil
+}
+
+// ListProjects returns the user's projects in a workspace, archived ones only when asked for
+func (s *ProjectServiceImpl) ListProjects(userID, workspaceID uuid.UUID, includeArchived bool) ([]models.Project, error) {
+	if _, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleGuest); err != nil {
+		return nil, err
+	}
+
+	projects, err := s.ProjectRepo.ListProjects(userID, workspaceID, includeArchived)
+	if err != nil {
+		log.Printf("Error listing projects for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to list projects: %v", err)
+	}
+	if projects == nil {
+		projects = []models.Project{}
+	}
+	return projects, nil
+}
+
+// UpdateProject applies a partial update, including archiving and unarchiving
+func (s *ProjectServiceImpl) UpdateProject(userID, projectID uuid.UUID, input models.UpdateProjectRequest) (*models.Project, error) {
+	project, err := s.GetProject(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		project.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Description != nil {
+		project.Description = *input.Description
+	}
+	if input.Color != nil {
+		project.Color = *input.Color
+	}
+	if input.Archived != nil {
+		project.Archived = *input.Archived
+	}
+
+	updated, err := s.ProjectRepo.UpdateProject(project)
+	if err != nil {
+		log.Printf("Error updating project %s for user %s: %v", projectID, userID, err)
+		return nil, fmt.Errorf("failed to update project: %v", err)
+	}
+	return updated, nil
+}
+
+// DeleteProject deletes a project; its tasks stay and no longer belong to a project
+func (s *ProjectServiceImpl) DeleteProject(userID, projectID uuid.UUID) error {
+	deleted, err := s.ProjectRepo.DeleteProject(projectID, userID)
+	if err != nil {
+		log.Printf("Error deleting project %s for user %s: %v", projectID, userID, err)
+		return fmt.Errorf("failed to delete project: %v", err)
+	}
+	if !deleted {
+		return ErrProjectNotFound
+	}
+
+	log.Printf("Project %s deleted by user %s", projectID, userID)
+	return nil
+}
+
+// MoveTasks moves tasks of a workspace into one of its projects (or out of any project) all or nothing
+func (s *ProjectServiceImpl) MoveTasks(userID, workspaceID uuid.UUID, input models.MoveTasksRequest, meta models.RequestMeta) error {
+	if _, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleMember); err != nil {
+		return err
+	}
+	if input.ProjectID != nil {
+		project, err := s.GetProject(userID, *input.ProjectID)
+		if err != nil {
+			return err
+		}
+		if project.WorkspaceID != workspaceID {
+			return ErrProjectNotFound
+		}
+		if project.Archived {
+			return ErrProjectArchived
+		}
+	}
+
+	taskIDs := uniqueIDs(input.TaskIDs)
+	if err := s.ProjectRepo.MoveTasks(userID, workspaceID, input.ProjectID, taskIDs); err != nil {
+		if errors.Is(err, repositories.ErrTasksNotOwned) {
+			return ErrTaskNotFound
+		}
+		log.Printf("Error moving tasks for user %s: %v", userID, err)
+		return fmt.Errorf("failed to move tasks: %v", err)
+	}
+
+	for _, taskID := range taskIDs {
+		event := taskAuditEvent(models.AuditActionTaskProjectChange, userID, taskID, nil, nil)
+		event.After = models.JSONMap{"project_id": input.ProjectID}
+		s.Audit.Record(meta, event)
+	}
+	return nil
+}
+
+// GetSummary counts a project's tasks by status; every known status is present, even at zero
+func (s *ProjectServiceImpl) GetSummary(userID, projectID uuid.UUID) (*models.ProjectSummary, error) {
+	if _, err := s.GetProject(userID, projectID); err != nil {
+		return nil, err
+	}
+
+	counts, err := s.ProjectRepo.CountTasksByStatus(projectID)
+	if err != nil {
+		log.Printf("Error counting tasks of project %s: %v", projectID, err)
+		return nil, fmt.Errorf("failed to summarize project: %v", err)
+	}
+
+	summary := &models.ProjectSummary{ProjectID: projectID, ByStatus: map[string]int64{}}
+	for _, status := range models.TaskStatuses {
+		summary.ByStatus[status] = 0
+	}
+	for status, count := range counts {
+		summary.ByStatus[status] = count
+		summary.Total += count
+	}
+	return summary, nil
+}
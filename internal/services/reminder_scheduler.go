@@ -0,0 +1,168 @@
+package service
+
+import (
+	config "TaskManagmentApis/configs"
+	"TaskManagmentApis/internal/database"
+	"TaskManagmentApis/internal/mail"
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+const reminderBatchSize = 500
+
+// ReminderScheduler periodically sends due-soon and overdue notifications at each recipient's
+// reminder offsets. Every reminder is recorded in the database together with its notification,
+// which is what makes it fire exactly once across restarts and server instances; Redis only keeps
+// instances from scanning for the same run twice.
+type ReminderScheduler struct {
+	ReminderRepo repositories.ReminderRepository
+	Emails       EmailService
+	Redis        database.RedisService
+	Interval     time.Duration
+}
+
+// NewReminderScheduler creates a ReminderScheduler configured from REMINDER_INTERVAL_SECONDS
+func NewReminderScheduler(reminderRepo repositories.ReminderRepository, emailService EmailService, redis database.RedisService) *ReminderScheduler {
+	return &ReminderScheduler{
+		ReminderRepo: reminderRepo,
+		Emails:       emailService,
+		Redis:        redis,
+		Interval:     time.Duration(config.Config.ReminderIntervalSecs) * time.Second,
+	}
+}
+
+// Start runs the scheduler right away and then every REMINDER_INTERVAL_SECONDS until ctx is
+// cancelled. Set the interval to 0 on instances that should not send reminders.
+func (s *ReminderScheduler) Start(ctx context.Context) {
+	if s.Interval <= 0 {
+		log.Println("Reminder job disabled")
+		return
+	}
+
+	runPeriodic(ctx, s.Interval, func(now time.Time) {
+		s.RunOnce(ctx, now)
+	})
+}
+
+// claimRun reports whether this instance should scan for the interval that now falls in. If
+// Redis is unavailable every instance scans; the database still keeps reminders from doubling.
+func (s *ReminderScheduler) claimRun(ctx context.Context, now time.Time) bool {
+	if s.Redis == nil {
+		return true
+	}
+	host, _ := os.Hostname()
+	key := fmt.Sprintf("reminders:run:%d", now.Truncate(s.Interval).Unix())
+	claimed, err := s.Redis.SetNX(ctx, key, fmt.Sprintf("%s:%d", host, os.Getpid()), s.Interval)
+	if err != nil {
+		log.Printf("Error claiming reminder run, scanning anyway: %v", err)
+		return true
+	}
+	return claimed
+}
+
+// RunOnce sends every reminder that has come due by now. Errors are logged; a reminder that
+// could not be recorded is tried again on the next run.
+func (s *ReminderScheduler) RunOnce(ctx context.Context, now time.Time) {
+	if !s.claimRun(ctx, now) {
+		return
+	}
+
+	window := time.Duration(models.MaxReminderOffsetMinutes) * time.Minute
+	sent := 0
+	var after *models.ReminderCandidate
+	for {
+		candidates, err := s.ReminderRepo.ListReminderCandidates(now.Add(-window), now.Add(window), after, reminderBatchSize)
+		if err != nil {
+			log.Printf("Error listing reminder candidates: %v", err)
+			break
+		}
+		for i := range candidates {
+			if s.remind(&candidates[i], now) {
+				sent++
+			}
+		}
+		if len(candidates) < reminderBatchSize {
+			break
+		}
+		after = &candidates[len(candidates)-1]
+	}
+
+	if sent > 0 {
+		log.Printf("Sent %d task reminders", sent)
+	}
+}
+
+// remind records the candidate's reminders that have come due and notifies the latest of them.
+// Reminders that a later one supersedes, such as the day-before reminder of a task created an
+// hour before it is due, are recorded without a notification, and so are due-soon reminders for
+// a task that is already overdue.
+func (s *ReminderScheduler) remind(candidate *models.ReminderCandidate, now time.Time) bool {
+	sentBefore := make(map[int]bool, len(candidate.SentOffsets))
+	for _, offset := range candidate.SentOffsets {
+		sentBefore[offset] = true
+	}
+	var due []int
+	for _, offset := range candidate.ReminderOffsets.Normalize() {
+		fireAt := candidate.DueDate.Add(-time.Duration(offset) * time.Minute)
+		if !sentBefore[offset] && !fireAt.After(now) {
+			due = append(due, offset)
+		}
+	}
+	if len(due) == 0 {
+		return false
+	}
+
+	latest := due[len(due)-1]
+	skipped := due[:len(due)-1]
+	overdue := !candidate.DueDate.After(now)
+	var notification *models.Notification
+	var email *models.OutboundEmail
+	if !overdue || latest <= 0 {
+		taskID := candidate.TaskID
+		notification = &models.Notification{
+			UserID:  candidate.UserID,
+			ActorID: candidate.UserID,
+			Type:    models.NotificationTypeDueSoon,
+			TaskID:  &taskID,
+		}
+		if latest <= 0 {
+			notification.Type = models.NotificationTypeOverdue
+		}
+		email = s.reminderEmail(candidate, latest <= 0)
+	} else {
+		skipped = due
+	}
+
+	notified, err := s.ReminderRepo.RecordReminders(candidate, skipped, notification, email, latest)
+	if err != nil {
+		log.Printf("Error recording reminders for task %s and user %s: %v", candidate.TaskID, candidate.UserID, err)
+		return false
+	}
+	return notified
+}
+
+// reminderEmail renders the email that goes with a reminder notification. Without one the
+// notification is still sent.
+func (s *ReminderScheduler) reminderEmail(candidate *models.ReminderCandidate, overdue bool) *models.OutboundEmail {
+	if s.Emails == nil {
+		return nil
+	}
+	loc := userLocation(&models.User{ID: candidate.UserID, Timezone: candidate.UserTimezone})
+	email, err := s.Emails.Compose(mail.TemplateTaskReminder, candidate.UserEmail, mail.TaskReminderData{
+		UserName:  candidate.UserName,
+		TaskTitle: candidate.TaskTitle,
+		DueDate:   candidate.DueDate.In(loc).Format("Mon, 02 Jan 2006 15:04 MST"),
+		Overdue:   overdue,
+		TaskURL:   config.Config.PublicBaseURL + "/tasks/" + candidate.TaskID.String(),
+	})
+	if err != nil {
+		log.Printf("Error composing reminder email for task %s: %v", candidate.TaskID, err)
+		return nil
+	}
+	return email
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrTagNotFound is returned when a tag does not exist or belongs to another user
+var ErrTagNotFound = errors.New("tag not found")
+
+// ErrTagNameTaken is returned when the user already has a tag with that name, ignoring case
+var ErrTagNameTaken = errors.New("tag name already exists")
+
+// ErrInvalidTagInput is returned for empty names or merging a tag into itself
+var ErrInvalidTagInput = errors.New("invalid tag input")
+
+const defaultTagColor = "#808080"
+
+// TagService defines the interface for tag operations
+type TagService interface {
+	CreateTag(userID uuid.UUID, input models.CreateTagRequest) (*models.Tag, error)
+	ListTags(userID uuid.UUID) ([]models.Tag, error)
+	UpdateTag(userID, tagID uuid.UUID, input models.UpdateTagRequest) (*models.Tag, error)
+	DeleteTag(userID, tagID uuid.UUID, meta models.RequestMeta) error
+	MergeTags(userID, sourceID, targetID uuid.UUID, meta models.RequestMeta) (*models.Tag, error)
+	UpdateTaskTags(userID, taskID uuid.UUID, input models.UpdateTaskTagsRequest, meta models.RequestMeta) ([]models.Tag, error)
+}
+
+// TagServiceImpl is the concrete implementation of TagService
+type TagServiceImpl struct {
+	TagRepo  repositories.TagRepository
+	TaskRepo repositories.TaskRepository
+	Audit    AuditService
+}
+
+// NewTagService creates a new TagService instance
+func NewTagService(tagRepo repositories.TagRepository, taskRepo repositories.TaskRepository, audit AuditService) TagService {
+	return &TagServiceImpl{
+		TagRepo:  tagRepo,
+		TaskRepo: taskRepo,
+		Audit:    audit,
+	}
+}
+
+// tagAuditEvent builds the event for tags added to and removed from a task
+func tagAuditEvent(userID, taskID uuid.UUID, added, removed []uuid.UUID, meta models.RequestMeta) models.AuditEvent {
+	event := taskAuditEvent(models.AuditActionTaskTags, userID, taskID, nil, nil)
+	event.Metadata = models.JSONMap{}
+	if len(added) > 0 {
+		event.Metadata["tags_added"] = added
+	}
+	if len(removed) > 0 {
+		event.Metadata["tags_removed"] = removed
+	}
+	stampAuditEvent(&event, meta)
+	return event
+}
+
+// announceAll passes events written by the tag repository to the audit listeners
+func (s *TagServiceImpl) announceAll(events []models.AuditEvent) {
+	for i := range events {
+		s.Audit.Announce(&events[i])
+	}
+}
+
+// mapTagError converts repository errors into service errors
+func mapTagError(err error, action string) error {
+	if errors.Is(err, repositories.ErrTagNameTaken) {
+		return ErrTagNameTaken
+	}
+	return fmt.Errorf("failed to %s: %v", action, err)
+}
+
+// CreateTag creates a tag for the user
+func (s *TagServiceImpl) CreateTag(userID uuid.UUID, input models.CreateTagRequest) (*models.Tag, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidTagInput)
+	}
+	color := input.Color
+	if color == "" {
+		color = defaultTagColor
+	}
+
+	tag, err := s.TagRepo.CreateTag(&models.Tag{UserID: userID, Name: name, Color: color})
+	if err != nil {
+		log.Printf("Error creating tag %q for user %s: %v", name, userID, err)
+		return nil, mapTagError(err, "create tag")
+	}
+	return tag, nil
+}
+
+// ListTags returns the user's tags
+func (s *TagServiceImpl) ListTags(userID uuid.UUID) ([]models.Tag, error) {
+	tags, err := s.TagRepo.ListTags(userID)
+	if err != nil {
+		log.Printf("Error listing tags for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to list tags: %v", err)
+	}
+	if tags == nil {
+		tags = []models.Tag{}
+	}
+	return tags, nil
+}
+
+// getTag returns a tag owned by the user
+func (s *TagServiceImpl) getTag(userID, tagID uuid.UUID) (*models.Tag, error) {
+	tag, err := s.TagRepo.GetTagByID(tagID, userID)
+	if err != nil {
+		log.Printf("Error fetching tag %s for user %s: %v", tagID, userID, err)
+		return nil, fmt.Errorf("failed to fetch tag: %v", err)
+	}
+	if tag == nil {
+		return nil, ErrTagNotFound
+	}
+	return tag, nil
+}
+
+// UpdateTag renames and/or recolors a tag
+func (s *TagServiceImpl) UpdateTag(userID, tagID uuid.UUID, input models.UpdateTagRequest) (*models.Tag, error) {
+	tag, err := s.getTag(userID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: name is required", ErrInvalidTagInput)
+		}
+		tag.Name = name
+	}
+	if input.Color != nil {
+		tag.Color = *input.Color
+	}
+
+	updated, err := s.TagRepo.UpdateTag(tag)
+	if err != nil {
+		log.Printf("Error updating tag %s for user %s: %v", tagID, userID, err)
+		return nil, mapTagError(err, "update tag")
+	}
+	return updated, nil
+}
+
+// DeleteTag deletes a tag and detaches it from every task
+func (s *TagServiceImpl) DeleteTag(userID, tagID uuid.UUID, meta models.RequestMeta) error {
+	deleted, events, err := s.TagRepo.DeleteTag(tagID, userID, func(taskID uuid.UUID) models.AuditEvent {
+		return tagAuditEvent(userID, taskID, nil, []uuid.UUID{tagID}, meta)
+	})
+	if err != nil {
+		log.Printf("Error deleting tag %s for user %s: %v", tagID, userID, err)
+		return fmt.Errorf("failed to delete tag: %v", err)
+	}
+	if !deleted {
+		return ErrTagNotFound
+	}
+	s.announceAll(events)
+	return nil
+}
+
+// MergeTags re-tags every task of the source tag with the target tag and deletes the source
+func (s *TagServiceImpl) MergeTags(userID, sourceID, targetID uuid.UUID, meta models.RequestMeta) (*models.Tag, error) {
+	if sourceID == targetID {
+		return nil, fmt.Errorf("%w: cannot merge a tag into itself", ErrInvalidTagInput)
+	}
+	if _, err := s.getTag(userID, sourceID); err != nil {
+		return nil, err
+	}
+	target, err := s.getTag(userID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.TagRepo.MergeTags(sourceID, targetID, userID, func(taskID uuid.UUID, gainedTarget bool) models.AuditEvent {
+		var added []uuid.UUID
+		if gainedTarget {
+			added = []uuid.UUID{targetID}
+		}
+		event := tagAuditEvent(userID, taskID, added, []uuid.UUID{sourceID}, meta)
+		event.Metadata["merge"] = true
+		return event
+	})
+	if err != nil {
+		log.Printf("Error merging tag %s into %s for user %s: %v", sourceID, targetID, userID, err)
+		return nil, fmt.Errorf("failed to merge tags: %v", err)
+	}
+	s.announceAll(events)
+
+	log.Printf("Tag %s merged into %s for user %s", sourceID, targetID, userID)
+	return target, nil
+}
+
+// UpdateTaskTags attaches and detaches tags on one of the user's tasks atomically
+func (s *TagServiceImpl) UpdateTaskTags(userID, taskID uuid.UUID, input models.UpdateTaskTagsRequest, meta models.RequestMeta) ([]models.Tag, error) {
+	task, err := s.TaskRepo.GetTaskByID(taskID, userID)
+	if err != nil {
+		log.Printf("Error fetching task %s for user %s: %v", taskID, userID, err)
+		return nil, fmt.Errorf("failed to fetch task: %v", err)
+	}
+	if task == nil {
+		return nil, ErrTaskNotFound
+	}
+
+	// every referenced tag must be the user's own
+	referenced := uniqueIDs(append(append([]uuid.UUID{}, input.Attach...), input.Detach...))
+	if len(referenced) > 0 {
+		owned, err := s.TagRepo.CountOwnedTags(referenced, userID)
+		if err != nil {
+			log.Printf("Error checking tags for user %s: %v", userID, err)
+			return nil, fmt.Errorf("failed to check tags: %v", err)
+		}
+		if owned != int64(len(referenced)) {
+			return nil, ErrTagNotFound
+		}
+	}
+
+	attached := make(map[uuid.UUID]bool, len(task.Tags))
+	for _, tag := range task.Tags {
+		attached[tag.ID] = true
+	}
+	attach, detach := uniqueIDs(input.Attach), uniqueIDs(input.Detach)
+	var added, removed []uuid.UUID
+	for _, tagID := range attach {
+		if !attached[tagID] {
+			added = append(added, tagID)
+		}
+	}
+	for _, tagID := range detach {
+		if attached[tagID] {
+			removed = append(removed, tagID)
+		}
+	}
+	var event *models.AuditEvent
+	if len(added)+len(removed) > 0 {
+		tagEvent := tagAuditEvent(userID, taskID, added, removed, meta)
+		event = &tagEvent
+	}
+
+	if err := s.TagRepo.UpdateTaskTags(taskID, attach, detach, event); err != nil {
+		log.Printf("Error updating tags of task %s for user %s: %v", taskID, userID, err)
+		return nil, fmt.Errorf("failed to update task tags: %v", err)
+	}
+	if event != nil {
+		s.Audit.Announce(event)
+	}
+
+	tags, err := s.TagRepo.GetTaskTags(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch task tags: %v", err)
+	}
+	if tags == nil {
+		tags = []models.Tag{}
+	}
+	return tags, nil
+}
+
+// uniqueIDs drops duplicate IDs while keeping their order
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	result := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/models"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// ErrAssigneeNotFound is returned when unassigning a user who is not assigned to the task
+var ErrAssigneeNotFound = errors.New("user is not assigned to this task")
+
+// GetAssignees lists who is assigned to a task
+func (s *TaskServiceImpl) GetAssignees(userID, taskID uuid.UUID) ([]models.TaskAssignee, error) {
+	if _, err := s.GetTask(userID, taskID); err != nil {
+		return nil, err
+	}
+	return s.loadAssignees(taskID)
+}
+
+func (s *TaskServiceImpl) loadAssignees(taskID uuid.UUID) ([]models.TaskAssignee, error) {
+	assignees, err := s.TaskRepo.GetAssignees(taskID)
+	if err != nil {
+		log.Printf("Error fetching assignees of task %s: %v", taskID, err)
+		return nil, fmt.Errorf("failed to fetch assignees: %v", err)
+	}
+	if assignees == nil {
+		assignees = []models.TaskAssignee{}
+	}
+	return assignees, nil
+}
+
+// ensureWorkspaceMembers rejects assignee lists that name users outside the task's workspace
+func (s *TaskServiceImpl) ensureWorkspaceMembers(workspaceID uuid.UUID, userIDs []uuid.UUID) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	count, err := s.WorkspaceRepo.CountMembers(workspaceID, userIDs)
+	if err != nil {
+		log.Printf("Error checking assignee members of workspace %s: %v", workspaceID, err)
+		return fmt.Errorf("failed to check users: %v", err)
+	}
+	if count != int64(len(userIDs)) {
+		return fmt.Errorf("%w: assignees must be members of the task's workspace", ErrInvalidTaskInput)
+	}
+	return nil
+}
+
+// changeAssignees applies an assignment change for the owner and notifies everyone affected.
+// A non-zero version must still be the task's current one.
+func (s *TaskServiceImpl) changeAssignees(userID, taskID uuid.UUID, version int, add, remove []uuid.UUID, meta models.RequestMeta) ([]uuid.UUID, []uuid.UUID, error) {
+	task, err := s.getOwnedTask(userID, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.ensureWorkspaceMembers(task.WorkspaceID, add); err != nil {
+		return nil, nil, err
+	}
+
+	found, added, removed, err := s.TaskRepo.ChangeAssignees(taskID, userID, version, add, remove)
+	if err != nil {
+		if isVersionConflict(err) {
+			return nil, nil, ErrPreconditionFailed
+		}
+		log.Printf("Error changing assignees of task %s for user %s: %v", taskID, userID, err)
+		return nil, nil, fmt.Errorf("failed to change assignees: %v", err)
+	}
+	if !found {
+		return nil, nil, ErrTaskNotFound
+	}
+
+	notifications := make([]models.Notification, 0, len(added)+len(removed))
+	for _, assigneeID := range added {
+		notifications = append(notifications, models.Notification{
+			UserID: assigneeID, ActorID: userID, Type: models.NotificationTypeAssigned, TaskID: &taskID,
+		})
+	}
+	for _, assigneeID := range removed {
+		notifications = append(notifications, models.Notification{
+			UserID: assigneeID, ActorID: userID, Type: models.NotificationTypeUnassigned, TaskID: &taskID,
+		})
+	}
+	s.NotificationService.Notify(notifications...)
+
+	if len(added)+len(removed) > 0 {
+		log.Printf("Task %s assignees changed by user %s: +%v -%v", taskID, userID, added, removed)
+		event := taskAuditEvent(models.AuditActionTaskAssignees, userID, taskID, nil, nil)
+		event.Metadata = models.JSONMap{"added": added, "removed": removed}
+		s.Audit.Record(meta, event)
+	}
+	return added, removed, nil
+}
+
+// AssignTask adds assignees to a task; users who are already assigned are left as they are
+func (s *TaskServiceImpl) AssignTask(userID, taskID uuid.UUID, assigneeIDs []uuid.UUID, meta models.RequestMeta) ([]models.TaskAssignee, error) {
+	if _, _, err := s.changeAssignees(userID, taskID, 0, uniqueIDs(assigneeIDs), nil, meta); err != nil {
+		return nil, err
+	}
+	return s.loadAssignees(taskID)
+}
+
+// UnassignTask removes one assignee from a task if it is still at the given version
+func (s *TaskServiceImpl) UnassignTask(userID, taskID, assigneeID uuid.UUID, version int, meta models.RequestMeta) error {
+	_, removed, err := s.changeAssignees(userID, taskID, version, nil, []uuid.UUID{assigneeID}, meta)
+	if err != nil {
+		return err
+	}
+	if len(removed) == 0 {
+		return ErrAssigneeNotFound
+	}
+	return nil
+}
+
+// ReplaceAssignees reassigns a task so that exactly the given users are assigned. The version keeps
+// a replacement based on a stale assignee list from undoing someone else's change.
+func (s *TaskServiceImpl) ReplaceAssignees(userID, taskID uuid.UUID, version int, assigneeIDs []uuid.UUID, meta models.RequestMeta) ([]models.TaskAssignee, error) {
+	current, err := s.GetAssignees(userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[uuid.UUID]bool)
+	for _, id := range assigneeIDs {
+		wanted[id] = true
+	}
+	var remove []uuid.UUID
+	for _, assignee := range current {
+		if !wanted[assignee.UserID] {
+			remove = append(remove, assignee.UserID)
+		}
+	}
+
+	if _, _, err := s.changeAssignees(userID, taskID, version, uniqueIDs(assigneeIDs), remove, meta); err != nil {
+		return nil, err
+	}
+	return s.loadAssignees(taskID)
+}
+
+// GetAssignmentHistory returns every recorded assignment and unassignment of a task
+func (s *TaskServiceImpl) GetAssignmentHistory(userID, taskID uuid.UUID) ([]models.TaskAssignmentEvent, error) {
+	if _, err := s.GetTask(userID, taskID); err != nil {
+		return nil, err
+	}
+
+	events, err := s.TaskRepo.GetAssignmentEvents(taskID)
+	if err != nil {
+		log.Printf("Error fetching assignment history of task %s: %v", taskID, err)
+		return nil, fmt.Errorf("failed to fetch assignment history: %v", err)
+	}
+	if events == nil {
+		events = []models.TaskAssignmentEvent{}
+	}
+	return events, nil
+}
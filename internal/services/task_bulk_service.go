@@ -0,0 +1,302 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// ErrBulkRolledBack is reported for the tasks of an atomic bulk operation that were left unchanged
+// because another task of the batch failed
+var ErrBulkRolledBack = errors.New("not applied because another task in the batch failed")
+
+// maxBulkTasks caps how many tasks one bulk operation may touch
+const maxBulkTasks = 1000
+
+// BulkUpdateTasks applies one operation to many tasks of a workspace. Every task goes through the
+// same ownership and validation rules as the single task endpoints: assignees may only change the
+// status, everything else is reserved to the owner. In atomic mode nothing is written unless every
+// task passes; in best effort mode each task succeeds or fails on its own.
+func (s *TaskServiceImpl) BulkUpdateTasks(userID, workspaceID uuid.UUID, input models.BulkTaskRequest, meta models.RequestMeta) (*models.BulkTaskResult, error) {
+	if _, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleGuest); err != nil {
+		return nil, err
+	}
+	if input.Mode == "" {
+		input.Mode = models.BulkModeAtomic
+	}
+	atomic := input.Mode == models.BulkModeAtomic
+
+	if err := s.validateBulkOperation(userID, &input); err != nil {
+		return nil, err
+	}
+	taskIDs, err := s.resolveBulkTargets(userID, workspaceID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.TaskRepo.GetAccessibleTasks(taskIDs, userID)
+	if err != nil {
+		log.Printf("Error loading tasks for bulk %s by user %s: %v", input.Operation, userID, err)
+		return nil, fmt.Errorf("failed to load tasks: %v", err)
+	}
+	byID := make(map[uuid.UUID]models.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	result := &models.BulkTaskResult{
+		Operation: input.Operation,
+		Mode:      input.Mode,
+		Results:   make([]models.BulkTaskItemResult, len(taskIDs)),
+	}
+	itemErrs := make([]error, len(taskIDs))
+
+	var changes []repositories.BulkTaskChange
+	var changeItems []int
+	befores := map[int]models.Task{}
+	for i, taskID := range taskIDs {
+		result.Results[i].TaskID = taskID
+		task, ok := byID[taskID]
+		if !ok || task.WorkspaceID != workspaceID {
+			itemErrs[i] = ErrTaskNotFound
+			continue
+		}
+		before := task
+		change, err := s.bulkChange(userID, &task, input)
+		if err != nil {
+			itemErrs[i] = err
+			continue
+		}
+		if change == nil {
+			// already in the requested state
+			result.Results[i].Version = task.Version
+			continue
+		}
+		befores[i] = before
+		changes = append(changes, *change)
+		changeItems = append(changeItems, i)
+	}
+
+	// in atomic mode a task that failed validation means nothing is written
+	rolledBack := atomic && hasErrors(itemErrs)
+	if len(changes) > 0 && !rolledBack {
+		errs, err := s.TaskRepo.ApplyBulkChanges(changes, atomic)
+		if err != nil && !hasErrors(errs) {
+			log.Printf("Error applying bulk %s for user %s: %v", input.Operation, userID, err)
+			return nil, fmt.Errorf("failed to apply bulk operation: %v", err)
+		}
+		rolledBack = err != nil
+		for c, i := range changeItems {
+			switch {
+			case errs[c] != nil:
+				itemErrs[i] = s.bulkItemError(userID, taskIDs[i], errs[c])
+			case !rolledBack:
+				s.finishBulkChange(userID, input, befores[i], &changes[c], &result.Results[i], meta)
+			}
+		}
+	}
+	if rolledBack {
+		for i := range itemErrs {
+			if itemErrs[i] == nil {
+				itemErrs[i] = ErrBulkRolledBack
+				result.Results[i].Version = 0
+			}
+		}
+	}
+
+	for i, err := range itemErrs {
+		if err != nil {
+			result.Results[i].Error = err.Error()
+			result.Failed++
+			continue
+		}
+		result.Results[i].Success = true
+		result.Succeeded++
+	}
+	result.Applied = !rolledBack
+
+	log.Printf("Bulk %s (%s) by user %s: %d succeeded, %d failed", input.Operation, input.Mode, userID, result.Succeeded, result.Failed)
+	return result, nil
+}
+
+func hasErrors(errs []error) bool {
+	for _, err := range errs {
+		if err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// validateBulkOperation checks the parameters of the operation itself, before any task is looked at
+func (s *TaskServiceImpl) validateBulkOperation(userID uuid.UUID, input *models.BulkTaskRequest) error {
+	switch input.Operation {
+	case models.BulkOpSetStatus:
+		if input.Status == "" {
+			return fmt.Errorf("%w: status is required", ErrInvalidTaskInput)
+		}
+		return validateTaskFields(input.Status, "")
+	case models.BulkOpSetPriority:
+		if input.Priority == "" {
+			return fmt.Errorf("%w: priority is required", ErrInvalidTaskInput)
+		}
+		return validateTaskFields("", input.Priority)
+	case models.BulkOpAddTags, models.BulkOpRemoveTags:
+		input.TagIDs = uniqueIDs(input.TagIDs)
+		if len(input.TagIDs) == 0 {
+			return fmt.Errorf("%w: tag_ids is required", ErrInvalidTaskInput)
+		}
+		owned, err := s.TagRepo.CountOwnedTags(input.TagIDs, userID)
+		if err != nil {
+			log.Printf("Error checking tags of user %s: %v", userID, err)
+			return fmt.Errorf("failed to check tags: %v", err)
+		}
+		if owned != int64(len(input.TagIDs)) {
+			return ErrTagNotFound
+		}
+	case models.BulkOpDelete:
+		if input.Policy == "" {
+			input.Policy = s.DefaultDeletePolicy
+		}
+	}
+	return nil
+}
+
+// resolveBulkTargets returns the task IDs named in the request, or those matching its filter
+func (s *TaskServiceImpl) resolveBulkTargets(userID, workspaceID uuid.UUID, input models.BulkTaskRequest) ([]uuid.UUID, error) {
+	if len(input.TaskIDs) > 0 && input.Filter != nil {
+		return nil, fmt.Errorf("%w: give either task_ids or filter, not both", ErrInvalidTaskInput)
+	}
+
+	if len(input.TaskIDs) > 0 {
+		taskIDs := uniqueIDs(input.TaskIDs)
+		if len(taskIDs) > maxBulkTasks {
+			return nil, fmt.Errorf("%w: at most %d tasks per bulk operation", ErrInvalidTaskInput, maxBulkTasks)
+		}
+		return taskIDs, nil
+	}
+	if input.Filter == nil {
+		return nil, fmt.Errorf("%w: task_ids or filter is required", ErrInvalidTaskInput)
+	}
+
+	filter, err := buildTaskListFilter(userID, workspaceID, models.TaskListQuery{
+		Status:      input.Filter.Status,
+		Priority:    input.Filter.Priority,
+		DueFrom:     input.Filter.DueFrom,
+		DueTo:       input.Filter.DueTo,
+		CreatedFrom: input.Filter.CreatedFrom,
+		CreatedTo:   input.Filter.CreatedTo,
+		UpdatedFrom: input.Filter.UpdatedFrom,
+		UpdatedTo:   input.Filter.UpdatedTo,
+		ProjectID:   input.Filter.ProjectID,
+		Tags:        input.Filter.Tags,
+		TagMode:     input.Filter.TagMode,
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// one more than allowed tells a filter that is too broad from one that fits exactly
+	taskIDs, err := s.TaskRepo.ListTaskIDs(filter, maxBulkTasks+1)
+	if err != nil {
+		log.Printf("Error resolving bulk filter for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to list tasks: %v", err)
+	}
+	if len(taskIDs) > maxBulkTasks {
+		return nil, fmt.Errorf("%w: filter matches more than %d tasks", ErrInvalidTaskInput, maxBulkTasks)
+	}
+	return taskIDs, nil
+}
+
+// bulkChange validates the operation against one task and applies it to the task in memory.
+// It returns nil without an error when the task is already in the requested state.
+func (s *TaskServiceImpl) bulkChange(userID uuid.UUID, task *models.Task, input models.BulkTaskRequest) (*repositories.BulkTaskChange, error) {
+	if task.UserID != userID && input.Operation != models.BulkOpSetStatus {
+		return nil, ErrTaskForbidden
+	}
+
+	change := &repositories.BulkTaskChange{Task: task}
+	switch input.Operation {
+	case models.BulkOpSetStatus:
+		if task.Status == input.Status {
+			return nil, nil
+		}
+		transition, err := checkTransition(task, userID, input.Status, input.StatusReason)
+		if err != nil {
+			return nil, err
+		}
+		if input.Status == models.TaskStatusDone {
+			if err := s.ensureUnblocked(task.ID); err != nil {
+				return nil, err
+			}
+		}
+		task.Status = input.Status
+		change.Transition = transition
+	case models.BulkOpSetPriority:
+		if task.Priority == input.Priority {
+			return nil, nil
+		}
+		task.Priority = input.Priority
+	case models.BulkOpAddTags, models.BulkOpRemoveTags:
+		attached := make(map[uuid.UUID]bool, len(task.Tags))
+		for _, tag := range task.Tags {
+			attached[tag.ID] = true
+		}
+		for _, tagID := range input.TagIDs {
+			if input.Operation == models.BulkOpAddTags && !attached[tagID] {
+				change.AttachTags = append(change.AttachTags, tagID)
+			}
+			if input.Operation == models.BulkOpRemoveTags && attached[tagID] {
+				change.DetachTags = append(change.DetachTags, tagID)
+			}
+		}
+		if len(change.AttachTags)+len(change.DetachTags) == 0 {
+			return nil, nil
+		}
+	case models.BulkOpDelete:
+		change.Delete = true
+		change.Cascade = input.Policy == DeletePolicyCascade
+	}
+	return change, nil
+}
+
+// bulkItemError converts the repository error of one task into the error reported for it
+func (s *TaskServiceImpl) bulkItemError(userID, taskID uuid.UUID, err error) error {
+	switch {
+	case isVersionConflict(err):
+		return ErrPreconditionFailed
+	case errors.Is(err, repositories.ErrTaskNotFound):
+		return ErrTaskNotFound
+	}
+	log.Printf("Error applying bulk change to task %s for user %s: %v", taskID, userID, err)
+	return fmt.Errorf("failed to update task: %v", err)
+}
+
+// finishBulkChange runs the side effects of a written change and fills in its result
+func (s *TaskServiceImpl) finishBulkChange(userID uuid.UUID, input models.BulkTaskRequest, before models.Task, change *repositories.BulkTaskChange, item *models.BulkTaskItemResult, meta models.RequestMeta) {
+	task := change.Task
+	if change.Delete {
+		event := taskAuditEvent(models.AuditActionTaskDelete, userID, task.ID, &before, nil)
+		event.Metadata = models.JSONMap{"policy": input.Policy, "bulk": true}
+		s.Audit.Record(meta, event)
+		return
+	}
+
+	item.Version = task.Version
+	if change.Transition != nil {
+		s.HandleStatusChange(task, change.Transition.FromStatus)
+	}
+	event := taskAuditEvent(models.AuditActionTaskUpdate, userID, task.ID, &before, task)
+	event.Metadata = models.JSONMap{"bulk": true}
+	if len(change.AttachTags) > 0 {
+		event.Metadata["tags_added"] = change.AttachTags
+	}
+	if len(change.DetachTags) > 0 {
+		event.Metadata["tags_removed"] = change.DetachTags
+	}
+	s.Audit.Record(meta, event)
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"container/heap"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// ErrDependencyCycle is returned when a dependency would make a task (transitively) block itself
+var ErrDependencyCycle = errors.New("dependency would create a cycle")
+
+// ErrDependencyNotFound is returned when removing an edge that does not exist
+var ErrDependencyNotFound = errors.New("dependency not found")
+
+// ErrTaskBlocked is returned when completing a task that still has open blockers
+var ErrTaskBlocked = errors.New("task is blocked by open tasks")
+
+// AddDependency records that blockerID must be done before taskID
+func (s *TaskServiceImpl) AddDependency(userID, taskID, blockerID uuid.UUID, meta models.RequestMeta) error {
+	if taskID == blockerID {
+		return ErrDependencyCycle
+	}
+
+	added, err := s.TaskRepo.AddDependency(taskID, blockerID, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDependencyCycle) {
+			return ErrDependencyCycle
+		}
+		log.Printf("Error adding dependency %s -> %s for user %s: %v", blockerID, taskID, userID, err)
+		return fmt.Errorf("failed to add dependency: %v", err)
+	}
+	if !added {
+		return ErrTaskNotFound
+	}
+
+	log.Printf("Task %s now blocks task %s for user %s", blockerID, taskID, userID)
+	event := taskAuditEvent(models.AuditActionTaskDependencyAdd, userID, taskID, nil, nil)
+	event.Metadata = models.JSONMap{"blocker_id": blockerID}
+	s.Audit.Record(meta, event)
+	return nil
+}
+
+// RemoveDependency deletes the "blockerID blocks taskID" edge if the task is still at the given version
+func (s *TaskServiceImpl) RemoveDependency(userID, taskID, blockerID uuid.UUID, version int, meta models.RequestMeta) error {
+	removed, err := s.TaskRepo.RemoveDependency(taskID, blockerID, userID, version)
+	if err != nil {
+		if isVersionConflict(err) {
+			return ErrPreconditionFailed
+		}
+		log.Printf("Error removing dependency %s -> %s for user %s: %v", blockerID, taskID, userID, err)
+		return fmt.Errorf("failed to remove dependency: %v", err)
+	}
+	if !removed {
+		return ErrDependencyNotFound
+	}
+
+	event := taskAuditEvent(models.AuditActionTaskDependencyDrop, userID, taskID, nil, nil)
+	event.Metadata = models.JSONMap{"blocker_id": blockerID}
+	s.Audit.Record(meta, event)
+	return nil
+}
+
+// GetDependencies returns the direct blockers of a task and the tasks it blocks
+func (s *TaskServiceImpl) GetDependencies(userID, taskID uuid.UUID) (*models.TaskDependencies, error) {
+	task, err := s.GetTask(userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// assignees see the dependencies as the owner set them up
+	deps, err := s.TaskRepo.GetDependencies(taskID, task.UserID)
+	if err != nil {
+		log.Printf("Error fetching dependencies of task %s for user %s: %v", taskID, userID, err)
+		return nil, fmt.Errorf("failed to fetch dependencies: %v", err)
+	}
+	return deps, nil
+}
+
+// ensureUnblocked fails with ErrTaskBlocked while any blocker of the task is still open
+func (s *TaskServiceImpl) ensureUnblocked(taskID uuid.UUID) error {
+	open, err := s.TaskRepo.CountOpenBlockers(taskID)
+	if err != nil {
+		log.Printf("Error counting blockers of task %s: %v", taskID, err)
+		return fmt.Errorf("failed to check blockers: %v", err)
+	}
+	if open > 0 {
+		return fmt.Errorf("%w (%d open)", ErrTaskBlocked, open)
+	}
+	return nil
+}
+
+// GetExecutionPlan orders the user's open tasks in a workspace so that every task comes after its
+// blockers. Among tasks that are ready at the same time, higher priority and earlier due dates go first.
+func (s *TaskServiceImpl) GetExecutionPlan(userID, workspaceID uuid.UUID) ([]models.Task, error) {
+	if _, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleGuest); err != nil {
+		return nil, err
+	}
+
+	tasks, edges, err := s.TaskRepo.GetOpenTaskGraph(userID, workspaceID)
+	if err != nil {
+		log.Printf("Error loading task graph for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to load task graph: %v", err)
+	}
+
+	byID := make(map[uuid.UUID]models.Task, len(tasks))
+	inDegree := make(map[uuid.UUID]int, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+		inDegree[task.ID] = 0
+	}
+	dependents := make(map[uuid.UUID][]uuid.UUID)
+	for _, edge := range edges {
+		dependents[edge.BlockerID] = append(dependents[edge.BlockerID], edge.TaskID)
+		inDegree[edge.TaskID]++
+	}
+
+	// Kahn's algorithm
+	ready := &planQueue{}
+	for id, degree := range inDegree {
+		if degree == 0 {
+			*ready = append(*ready, byID[id])
+		}
+	}
+	heap.Init(ready)
+
+	plan := make([]models.Task, 0, len(tasks))
+	for ready.Len() > 0 {
+		next := heap.Pop(ready).(models.Task)
+		plan = append(plan, next)
+
+		for _, dependentID := range dependents[next.ID] {
+			inDegree[dependentID]--
+			if inDegree[dependentID] == 0 {
+				heap.Push(ready, byID[dependentID])
+			}
+		}
+	}
+
+	if len(plan) != len(tasks) {
+		// inserts reject cycles, so this only happens if the table was edited by hand
+		log.Printf("Dependency cycle detected in task graph of user %s", userID)
+		return nil, ErrDependencyCycle
+	}
+	return plan, nil
+}
+
+// planQueue is a heap of the tasks that are ready, the one planBefore puts first on top
+type planQueue []models.Task
+
+func (q planQueue) Len() int           { return len(q) }
+func (q planQueue) Less(i, j int) bool { return planBefore(q[i], q[j]) }
+func (q planQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *planQueue) Push(x interface{}) { *q = append(*q, x.(models.Task)) }
+
+func (q *planQueue) Pop() interface{} {
+	old := *q
+	task := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return task
+}
+
+// planBefore is the tie breaker between tasks that are ready at the same time
+func planBefore(a, b models.Task) bool {
+	if ra, rb := models.PriorityRank(a.Priority), models.PriorityRank(b.Priority); ra != rb {
+		return ra > rb
+	}
+	switch {
+	case a.DueDate != nil && b.DueDate == nil:
+		return true
+	case a.DueDate == nil && b.DueDate != nil:
+		return false
+	case a.DueDate != nil && !a.DueDate.Equal(*b.DueDate):
+		return a.DueDate.Before(*b.DueDate)
+	}
+	return a.CreatedAt.Before(b.CreatedAt)
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/pkg/utils"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidRecurrence is returned for malformed rules or recurring tasks without a first due date
+var ErrInvalidRecurrence = errors.New("invalid recurrence")
+
+// Scopes for edits to a recurring task
+const (
+	RecurrenceScopeThis   = "this"
+	RecurrenceScopeFuture = "future"
+)
+
+const defaultPreviewCount = 5
+
+// parseRecurrenceRule validates a rule and returns it in canonical form (without an "RRULE:" prefix)
+func parseRecurrenceRule(rule string) (*utils.RRule, string, error) {
+	parsed, err := utils.ParseRRule(rule)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrInvalidRecurrence, err)
+	}
+	return parsed, strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:"), nil
+}
+
+// userLocation returns the user's configured time zone, falling back to UTC
+func (s *TaskServiceImpl) userLocation(userID uuid.UUID) *time.Location {
+	user, err := s.UserRepo.GetUserByID(userID)
+	if err != nil || user == nil {
+		return time.UTC
+	}
+	return userLocation(user)
+}
+
+// userLocation loads a user's configured time zone, falling back to UTC
+func userLocation(user *models.User) *time.Location {
+	if user.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		log.Printf("User %s has invalid timezone %q: %v", user.ID, user.Timezone, err)
+		return time.UTC
+	}
+	return loc
+}
+
+// newSeriesFor builds a series template from a task, starting at the task's due date
+func (s *TaskServiceImpl) newSeriesFor(task *models.Task, rule string) (*models.RecurrenceSeries, error) {
+	if task.DueDate == nil {
+		return nil, fmt.Errorf("%w: a recurring task needs a due date for its first occurrence", ErrInvalidRecurrence)
+	}
+	_, canonical, err := parseRecurrenceRule(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RecurrenceSeries{
+		ID:          uuid.New(),
+		UserID:      task.UserID,
+		Rule:        canonical,
+		Timezone:    s.userLocation(task.UserID).String(),
+		StartAt:     *task.DueDate,
+		Title:       task.Title,
+		Description: task.Description,
+		Priority:    task.Priority,
+	}, nil
+}
+
+// createRecurringTask creates the series and its first occurrence
+func (s *TaskServiceImpl) createRecurringTask(task *models.Task, rule string) (*models.Task, error) {
+	series, err := s.newSeriesFor(task, rule)
+	if err != nil {
+		return nil, err
+	}
+	return s.TaskRepo.CreateRecurringTask(series, task)
+}
+
+// shiftSeriesStart moves a series' start as far as an occurrence moved from one due date to another.
+// The move is counted in calendar days and wall clock time of the series' zone, like the occurrences
+// themselves, so a daylight saving change in between does not shift later occurrences by an hour.
+func shiftSeriesStart(series *models.RecurrenceSeries, from, to time.Time) {
+	loc, err := time.LoadLocation(series.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	from, to = from.In(loc), to.In(loc)
+	date := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	seconds := func(t time.Time) int {
+		hour, minute, second := t.Clock()
+		return hour*3600 + minute*60 + second
+	}
+	days := int(date(to).Sub(date(from)).Hours() / 24)
+	start := series.StartAt.In(loc)
+	series.StartAt = time.Date(start.Year(), start.Month(), start.Day()+days, start.Hour(), start.Minute(),
+		start.Second()+seconds(to)-seconds(from), start.Nanosecond()+to.Nanosecond()-from.Nanosecond(), loc)
+}
+
+// applyFutureScope updates (or creates, or ends) the series behind a task for a "future" edit and
+// returns the series that must be saved with the task, or nil when there is none. previousDue is
+// the task's due date before the edit; moving it moves the later occurrences along.
+func (s *TaskServiceImpl) applyFutureScope(userID uuid.UUID, task *models.Task, previousDue *time.Time, input models.UpdateTaskRequest) (*models.RecurrenceSeries, error) {
+	if task.SeriesID == nil {
+		if input.RecurrenceRule == nil || *input.RecurrenceRule == "" {
+			return nil, nil
+		}
+		// turn a one-off task into the first occurrence of a new series
+		series, err := s.newSeriesFor(task, *input.RecurrenceRule)
+		if err != nil {
+			return nil, err
+		}
+		task.SeriesID = &series.ID
+		task.OccurrenceIndex = 0
+		return series, nil
+	}
+
+	series, err := s.TaskRepo.GetSeries(*task.SeriesID, userID)
+	if err != nil {
+		log.Printf("Error fetching series %s for user %s: %v", *task.SeriesID, userID, err)
+		return nil, fmt.Errorf("failed to fetch recurrence: %v", err)
+	}
+	if series == nil {
+		return nil, nil
+	}
+
+	if input.RecurrenceRule != nil {
+		if *input.RecurrenceRule == "" {
+			// stop recurring: this occurrence becomes a plain task
+			task.SeriesID = nil
+			task.OccurrenceIndex = 0
+			return nil, nil
+		}
+		if _, canonical, err := parseRecurrenceRule(*input.RecurrenceRule); err != nil {
+			return nil, err
+		} else if canonical != series.Rule {
+			// a new rule starts a new series at this occurrence; earlier occurrences keep the old one
+			split, err := s.newSeriesFor(task, canonical)
+			if err != nil {
+				return nil, err
+			}
+			task.SeriesID = &split.ID
+			task.OccurrenceIndex = 0
+			return split, nil
+		}
+	}
+
+	series.Title = task.Title
+	series.Description = task.Description
+	series.Priority = task.Priority
+	if previousDue != nil && task.DueDate != nil && !task.DueDate.Equal(*previousDue) {
+		shiftSeriesStart(series, *previousDue, *task.DueDate)
+	}
+	return series, nil
+}
+
+// spawnNextOccurrence creates the occurrence after a completed one, unless the rule has ended.
+// Failures are logged because the completion itself has already been saved.
+func (s *TaskServiceImpl) spawnNextOccurrence(task *models.Task) {
+	series, err := s.TaskRepo.GetSeries(*task.SeriesID, task.UserID)
+	if err != nil || series == nil {
+		log.Printf("Error fetching series %v of task %s: %v", task.SeriesID, task.ID, err)
+		return
+	}
+	rule, _, err := parseRecurrenceRule(series.Rule)
+	if err != nil {
+		log.Printf("Series %s has an invalid rule: %v", series.ID, err)
+		return
+	}
+	loc, err := time.LoadLocation(series.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	nextIndex := task.OccurrenceIndex + 1
+	dueDate, ok := rule.Nth(series.StartAt.In(loc), nextIndex)
+	if !ok {
+		log.Printf("Series %s has no occurrences after #%d", series.ID, task.OccurrenceIndex)
+		return
+	}
+
+	next := &models.Task{
+		UserID:          task.UserID,
+		WorkspaceID:     task.WorkspaceID,
+		ParentID:        task.ParentID,
+		Title:           series.Title,
+		Description:     series.Description,
+		Status:          models.TaskStatusPending,
+		Priority:        series.Priority,
+		DueDate:         &dueDate,
+		SeriesID:        &series.ID,
+		OccurrenceIndex: nextIndex,
+	}
+	created, err := s.TaskRepo.CreateOccurrence(next)
+	if err != nil {
+		log.Printf("Error creating occurrence #%d of series %s: %v", nextIndex, series.ID, err)
+		return
+	}
+	if created {
+		log.Printf("Created occurrence #%d of series %s due %s", nextIndex, series.ID, dueDate.Format(time.RFC3339))
+		// generated by the system, not by whoever completed the previous occurrence
+		s.Audit.Record(models.RequestMeta{}, models.AuditEvent{
+			Action:     models.AuditActionTaskCreate,
+			EntityType: models.AuditEntityTask,
+			EntityID:   next.ID.String(),
+			After:      auditSnapshot(next),
+			Metadata:   models.JSONMap{"reason": "recurrence", "series_id": series.ID},
+		})
+	}
+}
+
+// PreviewRecurrence returns the next dates of a rule, computed in the user's time zone
+func (s *TaskServiceImpl) PreviewRecurrence(userID uuid.UUID, query models.RecurrencePreviewQuery) ([]time.Time, error) {
+	rule, _, err := parseRecurrenceRule(query.Rule)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := s.userLocation(userID)
+	start := time.Now().In(loc).Truncate(time.Minute)
+	if query.Start != nil {
+		start = query.Start.In(loc)
+	}
+	count := query.Count
+	if count <= 0 {
+		count = defaultPreviewCount
+	}
+
+	dates := rule.NextAfter(start, start.Add(-time.Nanosecond), count)
+	if dates == nil {
+		dates = []time.Time{}
+	}
+	return dates, nil
+}
@@ -1 +1,579 @@
 package service
+
+import (
+	config "TaskManagmentApis/configs"
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// ErrTaskNotFound is returned when a task does not exist or belongs to another user
+var ErrTaskNotFound = errors.New("task not found")
+
+// ErrInvalidTaskQuery is returned when list parameters such as sort or cursor are malformed
+var ErrInvalidTaskQuery = errors.New("invalid task query")
+
+// ErrTaskCycle is returned when a move would nest a task inside its own subtree
+var ErrTaskCycle = errors.New("task cannot be moved under itself or one of its subtasks")
+
+// ErrParentTaskNotFound is returned when the requested parent does not exist or belongs to another user
+var ErrParentTaskNotFound = errors.New("parent task not found")
+
+// ErrTaskForbidden is returned when an assignee attempts something only the task owner may do
+var ErrTaskForbidden = errors.New("only the task owner can do this")
+
+const (
+	defaultTaskPageSize = 20
+	maxTaskPageSize     = 100
+)
+
+// Policies for what happens to subtasks when their parent is deleted
+const (
+	DeletePolicyCascade  = "cascade"
+	DeletePolicyReparent = "reparent"
+)
+
+// TaskService defines the interface for task operations
+type TaskService interface {
+	CreateTask(userID, workspaceID uuid.UUID, input models.CreateTaskRequest, meta models.RequestMeta) (*models.Task, error)
+	GetTask(userID, taskID uuid.UUID) (*models.Task, error)
+	UpdateTask(userID, taskID uuid.UUID, version int, input models.UpdateTaskRequest, meta models.RequestMeta) (*models.Task, error)
+	DeleteTask(userID, taskID uuid.UUID, version int, policy string, meta models.RequestMeta) error
+	ListTasks(userID, workspaceID uuid.UUID, query models.TaskListQuery) (*models.TaskListResult, error)
+	SearchTasks(userID, workspaceID uuid.UUID, query models.TaskSearchQuery) ([]models.TaskSearchHit, error)
+	GetSubtree(userID, taskID uuid.UUID) (*models.TaskTreeNode, error)
+	MoveTask(userID, taskID uuid.UUID, parentID *uuid.UUID, meta models.RequestMeta) (*models.Task, error)
+	AddDependency(userID, taskID, blockerID uuid.UUID, meta models.RequestMeta) error
+	RemoveDependency(userID, taskID, blockerID uuid.UUID, version int, meta models.RequestMeta) error
+	GetDependencies(userID, taskID uuid.UUID) (*models.TaskDependencies, error)
+	GetExecutionPlan(userID, workspaceID uuid.UUID) ([]models.Task, error)
+	PreviewRecurrence(userID uuid.UUID, query models.RecurrencePreviewQuery) ([]time.Time, error)
+	GetStatusTransitions(userID, taskID uuid.UUID) ([]models.TaskStatusTransition, error)
+	HandleStatusChange(task *models.Task, previousStatus string)
+	ListAssignedTasks(userID, workspaceID uuid.UUID, query models.TaskListQuery) (*models.TaskListResult, error)
+	GetAssignees(userID, taskID uuid.UUID) ([]models.TaskAssignee, error)
+	AssignTask(userID, taskID uuid.UUID, assigneeIDs []uuid.UUID, meta models.RequestMeta) ([]models.TaskAssignee, error)
+	UnassignTask(userID, taskID, assigneeID uuid.UUID, version int, meta models.RequestMeta) error
+	ReplaceAssignees(userID, taskID uuid.UUID, version int, assigneeIDs []uuid.UUID, meta models.RequestMeta) ([]models.TaskAssignee, error)
+	GetAssignmentHistory(userID, taskID uuid.UUID) ([]models.TaskAssignmentEvent, error)
+	ListDeletedTasks(userID, workspaceID uuid.UUID) ([]models.Task, error)
+	RestoreTask(userID, taskID uuid.UUID, meta models.RequestMeta) (*models.Task, error)
+	PurgeTask(userID, taskID uuid.UUID, meta models.RequestMeta) error
+	BulkUpdateTasks(userID, workspaceID uuid.UUID, input models.BulkTaskRequest, meta models.RequestMeta) (*models.BulkTaskResult, error)
+	ExportTasks(userID, workspaceID uuid.UUID, query models.TaskListQuery, fn func(*models.TaskRecord) error) error
+	ImportTasks(userID, workspaceID uuid.UUID, body io.Reader, query models.TaskImportQuery, meta models.RequestMeta) (*models.TaskImportReport, error)
+}
+
+// TaskServiceImpl is the concrete implementation of TaskService
+type TaskServiceImpl struct {
+	TaskRepo            repositories.TaskRepository
+	UserRepo            repositories.AuthRepository
+	ProjectRepo         repositories.ProjectRepository
+	WorkspaceRepo       repositories.WorkspaceRepository
+	TagRepo             repositories.TagRepository
+	BoardRepo           repositories.BoardRepository
+	NotificationService NotificationService
+	Audit               AuditService
+	Attachments         AttachmentService
+	DefaultDeletePolicy string
+}
+
+// NewTaskService creates a new TaskService instance
+func NewTaskService(taskRepo repositories.TaskRepository, userRepo repositories.AuthRepository, projectRepo repositories.ProjectRepository, workspaceRepo repositories.WorkspaceRepository, tagRepo repositories.TagRepository, boardRepo repositories.BoardRepository, notificationService NotificationService, auditService AuditService, attachmentService AttachmentService) TaskService {
+	return &TaskServiceImpl{
+		TaskRepo:            taskRepo,
+		UserRepo:            userRepo,
+		ProjectRepo:         projectRepo,
+		WorkspaceRepo:       workspaceRepo,
+		TagRepo:             tagRepo,
+		BoardRepo:           boardRepo,
+		NotificationService: notificationService,
+		Audit:               auditService,
+		Attachments:         attachmentService,
+		DefaultDeletePolicy: config.Config.SubtaskDeletePolicy,
+	}
+}
+
+// CreateTask creates a task owned by the given user in a workspace; guests cannot create tasks
+func (s *TaskServiceImpl) CreateTask(userID, workspaceID uuid.UUID, input models.CreateTaskRequest, meta models.RequestMeta) (*models.Task, error) {
+	if _, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleMember); err != nil {
+		return nil, err
+	}
+
+	task := &models.Task{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Title:       input.Title,
+		Description: input.Description,
+		Status:      input.Status,
+		Priority:    input.Priority,
+		DueDate:     input.DueDate,
+		ParentID:    input.ParentID,
+		ProjectID:   input.ProjectID,
+	}
+	if task.Status == "" {
+		task.Status = models.TaskStatusPending
+	}
+	if task.Priority == "" {
+		task.Priority = models.TaskPriorityMedium
+	}
+	if err := validateTaskFields(task.Status, task.Priority); err != nil {
+		return nil, err
+	}
+
+	if input.ParentID != nil {
+		parent, err := s.TaskRepo.GetTaskByID(*input.ParentID, userID)
+		if err != nil {
+			log.Printf("Error fetching parent task %s for user %s: %v", *input.ParentID, userID, err)
+			return nil, fmt.Errorf("failed to fetch parent task: %v", err)
+		}
+		if parent == nil || parent.WorkspaceID != workspaceID {
+			return nil, ErrParentTaskNotFound
+		}
+	}
+
+	if input.ProjectID != nil {
+		if err := s.ensureOpenProject(userID, workspaceID, *input.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+
+	var createdTask *models.Task
+	var err error
+	if input.RecurrenceRule != "" {
+		createdTask, err = s.createRecurringTask(task, input.RecurrenceRule)
+	} else {
+		createdTask, err = s.TaskRepo.CreateTask(task)
+	}
+	if err != nil {
+		if errors.Is(err, ErrInvalidRecurrence) {
+			return nil, err
+		}
+		log.Printf("Error creating task for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to create task: %v", err)
+	}
+
+	log.Printf("Task %s created for user %s", createdTask.ID, userID)
+	s.Audit.Record(meta, taskAuditEvent(models.AuditActionTaskCreate, userID, createdTask.ID, nil, createdTask))
+	return createdTask, nil
+}
+
+// GetTask returns a task the user owns or is assigned to
+func (s *TaskServiceImpl) GetTask(userID, taskID uuid.UUID) (*models.Task, error) {
+	task, err := s.TaskRepo.GetAccessibleTask(taskID, userID)
+	if err != nil {
+		log.Printf("Error fetching task %s for user %s: %v", taskID, userID, err)
+		return nil, fmt.Errorf("failed to fetch task: %v", err)
+	}
+	if task == nil {
+		return nil, ErrTaskNotFound
+	}
+	return task, nil
+}
+
+// getOwnedTask returns the task only to its owner; assignees get ErrTaskForbidden
+func (s *TaskServiceImpl) getOwnedTask(userID, taskID uuid.UUID) (*models.Task, error) {
+	task, err := s.GetTask(userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.UserID != userID {
+		return nil, ErrTaskForbidden
+	}
+	return task, nil
+}
+
+// onlyStatusChange reports whether an update touches nothing but the status
+func onlyStatusChange(input models.UpdateTaskRequest) bool {
+	return input.Title == nil && input.Description == nil && input.Priority == nil && input.DueDate == nil &&
+		!input.ClearDue && input.Scope == "" && input.RecurrenceRule == nil
+}
+
+// UpdateTask applies a partial update to a task. The owner may change everything; assignees
+// may only change the status. version is the one the client last saw; 0 accepts any.
+func (s *TaskServiceImpl) UpdateTask(userID, taskID uuid.UUID, version int, input models.UpdateTaskRequest, meta models.RequestMeta) (*models.Task, error) {
+	task, err := s.GetTask(userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(version, task.Version); err != nil {
+		return nil, err
+	}
+	if task.UserID != userID && !onlyStatusChange(input) {
+		return nil, fmt.Errorf("%w: assignees can only change the status", ErrTaskForbidden)
+	}
+	before := *task
+
+	if input.Title != nil {
+		task.Title = *input.Title
+	}
+	if input.Description != nil {
+		task.Description = *input.Description
+	}
+	var transition *models.TaskStatusTransition
+	if input.Status != nil && *input.Status != task.Status {
+		if err := validateTaskFields(*input.Status, ""); err != nil {
+			return nil, err
+		}
+		if transition, err = checkTransition(task, userID, *input.Status, input.StatusReason); err != nil {
+			return nil, err
+		}
+		if *input.Status == models.TaskStatusDone {
+			if err := s.ensureUnblocked(task.ID); err != nil {
+				return nil, err
+			}
+		}
+		task.Status = *input.Status
+	}
+	if input.Priority != nil {
+		if err := validateTaskFields("", *input.Priority); err != nil {
+			return nil, err
+		}
+		task.Priority = *input.Priority
+	}
+	if input.DueDate != nil {
+		task.DueDate = input.DueDate
+	}
+	if input.ClearDue {
+		task.DueDate = nil
+	}
+
+	// "future" edits also change the series template that later occurrences are generated from
+	var series *models.RecurrenceSeries
+	if input.Scope == RecurrenceScopeFuture {
+		series, err = s.applyFutureScope(userID, task, before.DueDate, input)
+		if err != nil {
+			return nil, err
+		}
+	} else if input.RecurrenceRule != nil {
+		return nil, fmt.Errorf("%w: changing the recurrence rule requires scope \"future\"", ErrInvalidRecurrence)
+	}
+
+	updatedTask, err := s.TaskRepo.SaveTaskChanges(task, series, transition)
+	if err != nil {
+		if isVersionConflict(err) {
+			return nil, ErrPreconditionFailed
+		}
+		log.Printf("Error updating task %s for user %s: %v", taskID, userID, err)
+		return nil, fmt.Errorf("failed to update task: %v", err)
+	}
+	if updatedTask == nil {
+		return nil, ErrTaskNotFound
+	}
+
+	if transition != nil {
+		s.HandleStatusChange(updatedTask, transition.FromStatus)
+	}
+
+	log.Printf("Task %s updated by user %s", taskID, userID)
+	s.Audit.Record(meta, taskAuditEvent(models.AuditActionTaskUpdate, userID, taskID, &before, updatedTask))
+	return updatedTask, nil
+}
+
+// DeleteTask moves a task owned by the given user to the trash. The policy decides whether subtasks
+// are trashed with it or moved up to its parent; an empty policy uses the configured default.
+func (s *TaskServiceImpl) DeleteTask(userID, taskID uuid.UUID, version int, policy string, meta models.RequestMeta) error {
+	if policy == "" {
+		policy = s.DefaultDeletePolicy
+	}
+	if policy != DeletePolicyCascade && policy != DeletePolicyReparent {
+		return fmt.Errorf("%w: unknown delete policy %q", ErrInvalidTaskQuery, policy)
+	}
+
+	// tells assignees why they cannot delete instead of pretending the task is missing,
+	// and keeps its last state for the audit log
+	task, err := s.getOwnedTask(userID, taskID)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(version, task.Version); err != nil {
+		return err
+	}
+
+	deleted, err := s.TaskRepo.DeleteTask(taskID, userID, version, policy == DeletePolicyCascade)
+	if err != nil {
+		if isVersionConflict(err) {
+			return ErrPreconditionFailed
+		}
+		log.Printf("Error deleting task %s for user %s: %v", taskID, userID, err)
+		return fmt.Errorf("failed to delete task: %v", err)
+	}
+	if !deleted {
+		return ErrTaskNotFound
+	}
+
+	log.Printf("Task %s deleted by user %s (policy %s)", taskID, userID, policy)
+	event := taskAuditEvent(models.AuditActionTaskDelete, userID, taskID, task, nil)
+	event.Metadata = models.JSONMap{"policy": policy}
+	s.Audit.Record(meta, event)
+	return nil
+}
+
+// splitListParam accepts both repeated (?status=a&status=b) and comma separated (?status=a,b) values
+func splitListParam(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
+
+// parseTaskSort parses "-priority,due_date" into sort keys, a leading "-" meaning descending
+func parseTaskSort(sort string) ([]repositories.TaskSortKey, error) {
+	var keys []repositories.TaskSortKey
+	for _, field := range splitListParam([]string{sort}) {
+		key := repositories.TaskSortKey{Field: field}
+		if strings.HasPrefix(field, "-") {
+			key = repositories.TaskSortKey{Field: field[1:], Desc: true}
+		}
+		if !repositories.IsTaskSortField(key.Field) {
+			return nil, fmt.Errorf("%w: unknown sort field %q", ErrInvalidTaskQuery, key.Field)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// ListTasks returns a filtered, sorted and cursor paginated list of the user's tasks in a workspace
+func (s *TaskServiceImpl) ListTasks(userID, workspaceID uuid.UUID, query models.TaskListQuery) (*models.TaskListResult, error) {
+	return s.listTasks(userID, workspaceID, query, false)
+}
+
+// ListAssignedTasks is ListTasks over the tasks the user is assigned to, whoever owns them
+func (s *TaskServiceImpl) ListAssignedTasks(userID, workspaceID uuid.UUID, query models.TaskListQuery) (*models.TaskListResult, error) {
+	return s.listTasks(userID, workspaceID, query, true)
+}
+
+func (s *TaskServiceImpl) listTasks(userID, workspaceID uuid.UUID, query models.TaskListQuery, assignedOnly bool) (*models.TaskListResult, error) {
+	if _, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleGuest); err != nil {
+		return nil, err
+	}
+
+	filter, err := buildTaskListFilter(userID, workspaceID, query, assignedOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, nextCursor, total, err := s.TaskRepo.ListTasks(filter)
+	if err != nil {
+		if errors.Is(err, repositories.ErrInvalidCursor) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidTaskQuery, err)
+		}
+		log.Printf("Error listing tasks for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to list tasks: %v", err)
+	}
+	if tasks == nil {
+		tasks = []models.Task{}
+	}
+
+	return &models.TaskListResult{
+		Tasks:      tasks,
+		NextCursor: nextCursor,
+		TotalCount: total,
+	}, nil
+}
+
+// buildTaskListFilter validates list query parameters and turns them into a repository filter
+func buildTaskListFilter(userID, workspaceID uuid.UUID, query models.TaskListQuery, assignedOnly bool) (repositories.TaskListFilter, error) {
+	sortKeys, err := parseTaskSort(query.Sort)
+	if err != nil {
+		return repositories.TaskListFilter{}, err
+	}
+
+	var projectID *uuid.UUID
+	if query.ProjectID != "" && query.ProjectID != "none" {
+		id, err := uuid.Parse(query.ProjectID)
+		if err != nil {
+			return repositories.TaskListFilter{}, fmt.Errorf("%w: invalid project ID %q", ErrInvalidTaskQuery, query.ProjectID)
+		}
+		projectID = &id
+	}
+
+	var tagIDs []uuid.UUID
+	for _, value := range splitListParam(query.Tags) {
+		tagID, err := uuid.Parse(value)
+		if err != nil {
+			return repositories.TaskListFilter{}, fmt.Errorf("%w: invalid tag ID %q", ErrInvalidTaskQuery, value)
+		}
+		tagIDs = append(tagIDs, tagID)
+	}
+	// tag_mode=all counts matching tags per task, so a repeated ID would never match
+	tagIDs = uniqueIDs(tagIDs)
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultTaskPageSize
+	}
+	if limit > maxTaskPageSize {
+		limit = maxTaskPageSize
+	}
+
+	return repositories.TaskListFilter{
+		UserID:       userID,
+		WorkspaceID:  workspaceID,
+		AssignedOnly: assignedOnly,
+		Statuses:     splitListParam(query.Status),
+		Priorities:   splitListParam(query.Priority),
+		DueFrom:      query.DueFrom,
+		DueTo:        query.DueTo,
+		CreatedFrom:  query.CreatedFrom,
+		CreatedTo:    query.CreatedTo,
+		UpdatedFrom:  query.UpdatedFrom,
+		UpdatedTo:    query.UpdatedTo,
+		ProjectID:    projectID,
+		NoProject:    query.ProjectID == "none",
+		TagIDs:       tagIDs,
+		MatchAllTags: query.TagMode == "all",
+		Sort:         sortKeys,
+		Cursor:       query.Cursor,
+		Limit:        limit,
+	}, nil
+}
+
+// buildPrefixTSQuery turns free text into a to_tsquery expression where every word is prefix matched,
+// so "proj rev" becomes "proj:* & rev:*". Anything that is not a letter or digit is dropped.
+func buildPrefixTSQuery(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = strings.ToLower(word) + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+// SearchTasks runs a ranked, prefix matching full-text search over the user's tasks in a workspace
+func (s *TaskServiceImpl) SearchTasks(userID, workspaceID uuid.UUID, query models.TaskSearchQuery) ([]models.TaskSearchHit, error) {
+	if _, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleGuest); err != nil {
+		return nil, err
+	}
+
+	tsQuery := buildPrefixTSQuery(query.Q)
+	if tsQuery == "" {
+		return nil, fmt.Errorf("%w: search text has no searchable words", ErrInvalidTaskQuery)
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultTaskPageSize
+	}
+
+	hits, err := s.TaskRepo.SearchTasks(userID, workspaceID, tsQuery, limit)
+	if err != nil {
+		log.Printf("Error searching tasks for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to search tasks: %v", err)
+	}
+	if hits == nil {
+		hits = []models.TaskSearchHit{}
+	}
+	return hits, nil
+}
+
+// GetSubtree returns a task with all of its nested subtasks and their completion percentages
+func (s *TaskServiceImpl) GetSubtree(userID, taskID uuid.UUID) (*models.TaskTreeNode, error) {
+	nodes, err := s.TaskRepo.GetSubtree(taskID, userID)
+	if err != nil {
+		log.Printf("Error fetching subtree of task %s for user %s: %v", taskID, userID, err)
+		return nil, fmt.Errorf("failed to fetch subtasks: %v", err)
+	}
+	if len(nodes) == 0 {
+		return nil, ErrTaskNotFound
+	}
+
+	// rows are ordered by depth, so every parent is indexed before its children
+	byID := make(map[uuid.UUID]*models.TaskTreeNode, len(nodes))
+	for i := range nodes {
+		node := &nodes[i]
+		node.Children = []*models.TaskTreeNode{}
+		byID[node.ID] = node
+		if node.Depth > 0 && node.ParentID != nil {
+			if parent, ok := byID[*node.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+			}
+		}
+	}
+
+	root := &nodes[0]
+	rollUpCompletion(root)
+	return root, nil
+}
+
+// rollUpCompletion sets PercentComplete bottom-up: a leaf is 0 or 100, a parent is the mean of its children
+func rollUpCompletion(node *models.TaskTreeNode) float64 {
+	if len(node.Children) == 0 {
+		if node.Status == models.TaskStatusDone {
+			node.PercentComplete = 100
+		} else {
+			node.PercentComplete = 0
+		}
+		return node.PercentComplete
+	}
+
+	var total float64
+	for _, child := range node.Children {
+		total += rollUpCompletion(child)
+	}
+	node.PercentComplete = total / float64(len(node.Children))
+	return node.PercentComplete
+}
+
+// MoveTask moves a task and its subtree under a new parent, rejecting moves that would create a cycle
+func (s *TaskServiceImpl) MoveTask(userID, taskID uuid.UUID, parentID *uuid.UUID, meta models.RequestMeta) (*models.Task, error) {
+	if parentID != nil && *parentID == taskID {
+		return nil, ErrTaskCycle
+	}
+	before, err := s.getOwnedTask(userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	moved, err := s.TaskRepo.MoveTask(taskID, userID, parentID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrTaskCycle) {
+			return nil, ErrTaskCycle
+		}
+		log.Printf("Error moving task %s for user %s: %v", taskID, userID, err)
+		return nil, fmt.Errorf("failed to move task: %v", err)
+	}
+	if !moved {
+		// the new parent is missing or lies in another workspace
+		return nil, ErrParentTaskNotFound
+	}
+
+	log.Printf("Task %s moved under %v by user %s", taskID, parentID, userID)
+	after, err := s.GetTask(userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	s.Audit.Record(meta, taskAuditEvent(models.AuditActionTaskMove, userID, taskID, before, after))
+	return after, nil
+}
+
+// ensureOpenProject checks that a project exists in the workspace, belongs to the user and is not archived
+func (s *TaskServiceImpl) ensureOpenProject(userID, workspaceID, projectID uuid.UUID) error {
+	project, err := s.ProjectRepo.GetProjectByID(projectID, userID)
+	if err != nil {
+		log.Printf("Error fetching project %s for user %s: %v", projectID, userID, err)
+		return fmt.Errorf("failed to fetch project: %v", err)
+	}
+	if project == nil || project.WorkspaceID != workspaceID {
+		return ErrProjectNotFound
+	}
+	if project.Archived {
+		return ErrProjectArchived
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/models"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidTaskInput is returned for unknown statuses, priorities and similar bad values
+var ErrInvalidTaskInput = errors.New("invalid task input")
+
+// ErrInvalidStatusTransition is returned when the state machine does not allow a status change
+var ErrInvalidStatusTransition = errors.New("status transition not allowed")
+
+// allowedTransitions is the task status state machine: from -> allowed targets
+var allowedTransitions = map[string][]string{
+	models.TaskStatusPending:    {models.TaskStatusInProgress, models.TaskStatusDone, models.TaskStatusCancelled},
+	models.TaskStatusInProgress: {models.TaskStatusPending, models.TaskStatusDone, models.TaskStatusCancelled},
+	models.TaskStatusDone:       {models.TaskStatusReopened},
+	models.TaskStatusReopened:   {models.TaskStatusInProgress, models.TaskStatusDone, models.TaskStatusCancelled},
+	models.TaskStatusCancelled:  {models.TaskStatusReopened},
+}
+
+// canTransition reports whether a task may move from one status to another
+func canTransition(from, to string) bool {
+	for _, allowed := range allowedTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// validateTaskFields checks status and priority values before they reach the DB constraints
+func validateTaskFields(status, priority string) error {
+	if status != "" && !models.IsValidTaskStatus(status) {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidTaskInput, status)
+	}
+	if priority != "" && !models.IsValidTaskPriority(priority) {
+		return fmt.Errorf("%w: unknown priority %q", ErrInvalidTaskInput, priority)
+	}
+	return nil
+}
+
+// checkTransition validates a status change and builds the history record for it
+func checkTransition(task *models.Task, actorID uuid.UUID, to, reason string) (*models.TaskStatusTransition, error) {
+	if !canTransition(task.Status, to) {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, task.Status, to)
+	}
+	return &models.TaskStatusTransition{
+		TaskID:     task.ID,
+		ActorID:    actorID,
+		FromStatus: task.Status,
+		ToStatus:   to,
+		Reason:     reason,
+	}, nil
+}
+
+// GetStatusTransitions returns the recorded status history of a task
+func (s *TaskServiceImpl) GetStatusTransitions(userID, taskID uuid.UUID) ([]models.TaskStatusTransition, error) {
+	if _, err := s.GetTask(userID, taskID); err != nil {
+		return nil, err
+	}
+
+	transitions, err := s.TaskRepo.GetStatusTransitions(taskID)
+	if err != nil {
+		log.Printf("Error fetching transitions of task %s for user %s: %v", taskID, userID, err)
+		return nil, fmt.Errorf("failed to fetch status history: %v", err)
+	}
+	if transitions == nil {
+		transitions = []models.TaskStatusTransition{}
+	}
+	return transitions, nil
+}
+
+// HandleStatusChange runs the side effects of a saved status change, whichever endpoint made it
+func (s *TaskServiceImpl) HandleStatusChange(task *models.Task, previousStatus string) {
+	if task.Status == models.TaskStatusDone && previousStatus != models.TaskStatusDone && task.SeriesID != nil {
+		s.spawnNextOccurrence(task)
+	}
+	s.syncBoardCards(task)
+}
+
+// syncBoardCards moves the task's cards into a column matching its new status on every board whose
+// column no longer does. The status is already saved, so a card that cannot be moved is only logged.
+func (s *TaskServiceImpl) syncBoardCards(task *models.Task) {
+	placements, err := s.BoardRepo.FindStatusPlacements(task.ID, task.Status)
+	if err != nil {
+		log.Printf("Error finding board cards of task %s: %v", task.ID, err)
+		return
+	}
+	for _, placement := range placements {
+		if _, err := s.BoardRepo.PlaceCard(placement); err != nil {
+			log.Printf("Error moving card of task %s on board %s to a %s column: %v", task.ID, placement.BoardID, task.Status, err)
+		}
+	}
+}
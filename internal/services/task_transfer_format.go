@@ -0,0 +1,264 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/models"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// csvListSeparator joins list values, such as tag names, inside one CSV cell
+const csvListSeparator = ";"
+
+// TaskRecordEncoder writes exported tasks one at a time in one of the export formats
+type TaskRecordEncoder interface {
+	Encode(record *models.TaskRecord) error
+	// Close completes the document, e.g. the closing bracket of a JSON array, and flushes it
+	Close() error
+}
+
+// NewTaskRecordEncoder returns the encoder for a format; anything but csv and ndjson is JSON
+func NewTaskRecordEncoder(format string, w io.Writer) TaskRecordEncoder {
+	switch format {
+	case models.TaskFormatCSV:
+		return &csvTaskEncoder{w: csv.NewWriter(w)}
+	case models.TaskFormatNDJSON:
+		return &ndjsonTaskEncoder{enc: json.NewEncoder(w)}
+	}
+	return &jsonTaskEncoder{w: w}
+}
+
+type csvTaskEncoder struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func (e *csvTaskEncoder) writeHeader() error {
+	if e.wroteHeader {
+		return nil
+	}
+	e.wroteHeader = true
+	return e.w.Write(models.TaskRecordFields)
+}
+
+func (e *csvTaskEncoder) Encode(r *models.TaskRecord) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	return e.w.Write([]string{
+		r.ID.String(), r.ExternalID, r.Title, r.Description, r.Status, r.Priority,
+		formatCSVTime(r.DueDate), formatCSVID(r.ProjectID), formatCSVID(r.ParentID),
+		strings.Join(r.Tags, csvListSeparator), formatCSVTime(&r.CreatedAt), formatCSVTime(&r.UpdatedAt),
+	})
+}
+
+func (e *csvTaskEncoder) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func formatCSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatCSVID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+// jsonTaskEncoder writes a JSON array element by element instead of marshalling it at the end
+type jsonTaskEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonTaskEncoder) Encode(r *models.TaskRecord) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	prefix := ",\n"
+	if e.count == 0 {
+		prefix = "[\n"
+	}
+	e.count++
+	_, err = io.WriteString(e.w, prefix+string(data))
+	return err
+}
+
+func (e *jsonTaskEncoder) Close() error {
+	end := "\n]\n"
+	if e.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
+
+type ndjsonTaskEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonTaskEncoder) Encode(r *models.TaskRecord) error {
+	return e.enc.Encode(r)
+}
+
+func (e *ndjsonTaskEncoder) Close() error {
+	return nil
+}
+
+// taskRowReader reads raw import rows, column name to value, until io.EOF. An error wrapped in
+// badRowError spoils only its own row; any other error means the rest of the input is unreadable.
+type taskRowReader interface {
+	Next() (map[string]interface{}, error)
+}
+
+type badRowError struct {
+	err error
+}
+
+func (e *badRowError) Error() string {
+	return e.err.Error()
+}
+
+func (e *badRowError) Unwrap() error {
+	return e.err
+}
+
+// newTaskRowReader starts reading an import body; a missing CSV header or JSON array is reported
+// here as ErrInvalidTaskInput, before any row is processed
+func newTaskRowReader(format string, r io.Reader) (taskRowReader, error) {
+	switch format {
+	case models.TaskFormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		header, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading CSV header: %v", ErrInvalidTaskInput, err)
+		}
+		for i := range header {
+			header[i] = strings.TrimSpace(header[i])
+		}
+		// spreadsheet programs like to start UTF-8 files with a byte order mark
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+		return &csvRowReader{reader: reader, header: header}, nil
+	case models.TaskFormatNDJSON:
+		dec := json.NewDecoder(r)
+		dec.UseNumber()
+		return &ndjsonRowReader{dec: dec}, nil
+	case models.TaskFormatJSON:
+		dec := json.NewDecoder(r)
+		dec.UseNumber()
+		if token, err := dec.Token(); err != nil || token != json.Delim('[') {
+			return nil, fmt.Errorf("%w: a JSON import must be an array of objects", ErrInvalidTaskInput)
+		}
+		return &jsonRowReader{dec: dec}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown format %q", ErrInvalidTaskInput, format)
+}
+
+type csvRowReader struct {
+	reader *csv.Reader
+	header []string
+}
+
+func (r *csvRowReader) Next() (map[string]interface{}, error) {
+	record, err := r.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	row := make(map[string]interface{}, len(r.header))
+	for i, column := range r.header {
+		if i < len(record) {
+			row[column] = record[i]
+		}
+	}
+	return row, nil
+}
+
+type jsonRowReader struct {
+	dec *json.Decoder
+}
+
+func (r *jsonRowReader) Next() (map[string]interface{}, error) {
+	if !r.dec.More() {
+		if _, err := r.dec.Token(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	return decodeJSONRow(r.dec)
+}
+
+type ndjsonRowReader struct {
+	dec *json.Decoder
+}
+
+func (r *ndjsonRowReader) Next() (map[string]interface{}, error) {
+	return decodeJSONRow(r.dec)
+}
+
+func decodeJSONRow(dec *json.Decoder) (map[string]interface{}, error) {
+	var row map[string]interface{}
+	if err := dec.Decode(&row); err != nil {
+		// a well-formed value of the wrong type is skipped by the decoder, so the next row is fine
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, &badRowError{fmt.Errorf("row is not a JSON object")}
+		}
+		return nil, err
+	}
+	return row, nil
+}
+
+// importValue turns a raw cell into trimmed text; JSON numbers and booleans keep their literal form
+func importValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case json.Number:
+		return v.String()
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(bytes.TrimSpace(data))
+}
+
+// importList reads a list cell: a JSON array, or text separated by csvListSeparator
+func importList(v interface{}) []string {
+	var parts []string
+	if items, ok := v.([]interface{}); ok {
+		for _, item := range items {
+			parts = append(parts, importValue(item))
+		}
+	} else {
+		parts = strings.Split(importValue(v), csvListSeparator)
+	}
+
+	var values []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
@@ -0,0 +1,364 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExportTasks streams the user's tasks in a workspace that match the GET /tasks filters to fn,
+// oldest first
+func (s *TaskServiceImpl) ExportTasks(userID, workspaceID uuid.UUID, query models.TaskListQuery, fn func(*models.TaskRecord) error) error {
+	if _, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleGuest); err != nil {
+		return err
+	}
+	query.Sort, query.Cursor = "", ""
+	filter, err := buildTaskListFilter(userID, workspaceID, query, false)
+	if err != nil {
+		return err
+	}
+
+	err = s.TaskRepo.ExportTasks(filter, func(task *models.Task, tagNames []string) error {
+		return fn(taskRecord(task, tagNames))
+	})
+	if err != nil {
+		log.Printf("Error exporting tasks for user %s: %v", userID, err)
+		return fmt.Errorf("failed to export tasks: %v", err)
+	}
+	return nil
+}
+
+func taskRecord(task *models.Task, tagNames []string) *models.TaskRecord {
+	record := &models.TaskRecord{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      task.Status,
+		Priority:    task.Priority,
+		DueDate:     task.DueDate,
+		ProjectID:   task.ProjectID,
+		ParentID:    task.ParentID,
+		Tags:        tagNames,
+		CreatedAt:   task.CreatedAt,
+		UpdatedAt:   task.UpdatedAt,
+	}
+	if task.ExternalID != nil {
+		record.ExternalID = *task.ExternalID
+	}
+	if record.Tags == nil {
+		record.Tags = []string{}
+	}
+	return record
+}
+
+// taskImport is the state of one import run
+type taskImport struct {
+	userID      uuid.UUID
+	workspaceID uuid.UUID
+	dryRun      bool
+	meta        models.RequestMeta
+	columns     map[string]string    // import field -> column it is read from
+	projects    map[uuid.UUID]error  // result of the project check, per project
+	tags        map[string]uuid.UUID // the user's tags by lower-cased name, loaded on first use
+	seen        map[string]bool      // external IDs of rows a dry run would have created
+}
+
+// ImportTasks creates tasks in a workspace from a CSV, JSON or NDJSON body, row by row. A row
+// whose external_id the user already has in the workspace is reported as existing instead of
+// being created again, so running the same import twice is harmless. A dry run validates every
+// row and reports what would happen without writing anything.
+func (s *TaskServiceImpl) ImportTasks(userID, workspaceID uuid.UUID, body io.Reader, query models.TaskImportQuery, meta models.RequestMeta) (*models.TaskImportReport, error) {
+	if _, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleMember); err != nil {
+		return nil, err
+	}
+	columns, err := parseImportMapping(query.Mapping)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := newTaskRowReader(query.Format, body)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &taskImport{
+		userID:      userID,
+		workspaceID: workspaceID,
+		dryRun:      query.DryRun,
+		meta:        meta,
+		columns:     columns,
+		projects:    map[uuid.UUID]error{},
+		seen:        map[string]bool{},
+	}
+	report := &models.TaskImportReport{Format: query.Format, DryRun: query.DryRun, Rows: []models.TaskImportRow{}}
+	for number := 1; ; number++ {
+		raw, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		row := models.TaskImportRow{Row: number}
+		var badRow *badRowError
+		switch {
+		case err == nil:
+			s.importRow(run, raw, &row)
+		case errors.As(err, &badRow):
+			row.Action = models.TaskImportFailed
+			row.Errors = []string{err.Error()}
+		default:
+			// the rest of the body cannot be read; what was imported so far stays
+			row.Action = models.TaskImportFailed
+			row.Errors = []string{fmt.Sprintf("malformed input, import stopped: %v", err)}
+		}
+		report.Rows = append(report.Rows, row)
+
+		switch row.Action {
+		case models.TaskImportCreated:
+			report.Created++
+		case models.TaskImportExists:
+			report.Existing++
+		default:
+			report.Failed++
+		}
+		if err != nil && badRow == nil {
+			break
+		}
+	}
+	report.Total = len(report.Rows)
+
+	log.Printf("Task import by user %s (dry run %t): %d created, %d existing, %d failed",
+		userID, query.DryRun, report.Created, report.Existing, report.Failed)
+	return report, nil
+}
+
+// parseImportMapping reads "field=column" entries; fields that are not mapped use their own name
+func parseImportMapping(entries []string) (map[string]string, error) {
+	columns := make(map[string]string, len(models.TaskImportFields))
+	for _, field := range models.TaskImportFields {
+		columns[field] = field
+	}
+	for _, entry := range entries {
+		field, column, ok := strings.Cut(entry, "=")
+		field, column = strings.TrimSpace(field), strings.TrimSpace(column)
+		if _, known := columns[field]; !ok || !known || column == "" {
+			return nil, fmt.Errorf("%w: invalid mapping %q, expected field=column with field one of %s",
+				ErrInvalidTaskInput, entry, strings.Join(models.TaskImportFields, ", "))
+		}
+		columns[field] = column
+	}
+	return columns, nil
+}
+
+// importRow validates one row and, unless it is a dry run, creates its task
+func (s *TaskServiceImpl) importRow(run *taskImport, raw map[string]interface{}, row *models.TaskImportRow) {
+	task, tagNames, problems := s.parseImportRow(run, raw)
+	if task.ExternalID != nil {
+		row.ExternalID = *task.ExternalID
+	}
+	if len(problems) > 0 {
+		row.Action = models.TaskImportFailed
+		row.Errors = problems
+		return
+	}
+
+	if task.ExternalID != nil {
+		if run.seen[*task.ExternalID] {
+			row.Action = models.TaskImportExists
+			return
+		}
+		existing, err := s.TaskRepo.GetTaskByExternalID(run.userID, run.workspaceID, *task.ExternalID)
+		if err != nil {
+			log.Printf("Error looking up external ID %q for user %s: %v", *task.ExternalID, run.userID, err)
+			row.Action = models.TaskImportFailed
+			row.Errors = []string{"failed to look up external_id"}
+			return
+		}
+		if existing != nil {
+			row.Action = models.TaskImportExists
+			row.TaskID = &existing.ID
+			return
+		}
+	}
+
+	if run.dryRun {
+		if task.ExternalID != nil {
+			run.seen[*task.ExternalID] = true
+		}
+		row.Action = models.TaskImportCreated
+		return
+	}
+
+	tagIDs, err := s.resolveImportTags(run, tagNames)
+	if err != nil {
+		row.Action = models.TaskImportFailed
+		row.Errors = []string{err.Error()}
+		return
+	}
+	created, err := s.TaskRepo.ImportTask(task, tagIDs)
+	if err != nil {
+		log.Printf("Error importing task for user %s: %v", run.userID, err)
+		row.Action = models.TaskImportFailed
+		row.Errors = []string{"failed to create task"}
+		return
+	}
+	if !created {
+		// a concurrent import of the same row got there first
+		row.Action = models.TaskImportExists
+		if existing, err := s.TaskRepo.GetTaskByExternalID(run.userID, run.workspaceID, *task.ExternalID); err == nil && existing != nil {
+			row.TaskID = &existing.ID
+		}
+		return
+	}
+
+	row.Action = models.TaskImportCreated
+	row.TaskID = &task.ID
+	event := taskAuditEvent(models.AuditActionTaskCreate, run.userID, task.ID, nil, task)
+	event.Metadata = models.JSONMap{"source": "import"}
+	s.Audit.Record(run.meta, event)
+}
+
+// parseImportRow builds the task of a row and lists everything wrong with it
+func (s *TaskServiceImpl) parseImportRow(run *taskImport, raw map[string]interface{}) (*models.Task, []string, []string) {
+	value := func(field string) string {
+		return importValue(raw[run.columns[field]])
+	}
+	var problems []string
+
+	task := &models.Task{
+		UserID:      run.userID,
+		WorkspaceID: run.workspaceID,
+		Title:       value("title"),
+		Description: value("description"),
+		Status:      value("status"),
+		Priority:    value("priority"),
+	}
+	if externalID := value("external_id"); externalID != "" {
+		if len(externalID) > 255 {
+			problems = append(problems, "external_id: longer than 255 characters")
+		}
+		task.ExternalID = &externalID
+	}
+
+	if task.Title == "" {
+		problems = append(problems, "title: required")
+	} else if len(task.Title) > 255 {
+		problems = append(problems, "title: longer than 255 characters")
+	}
+	if task.Status == "" {
+		task.Status = models.TaskStatusPending
+	}
+	if task.Priority == "" {
+		task.Priority = models.TaskPriorityMedium
+	}
+	if !models.IsValidTaskStatus(task.Status) {
+		problems = append(problems, fmt.Sprintf("status: unknown status %q", task.Status))
+	}
+	if !models.IsValidTaskPriority(task.Priority) {
+		problems = append(problems, fmt.Sprintf("priority: unknown priority %q", task.Priority))
+	}
+
+	if due := value("due_date"); due != "" {
+		dueDate, err := parseImportDate(due)
+		if err != nil {
+			problems = append(problems, "due_date: "+err.Error())
+		}
+		task.DueDate = dueDate
+	}
+
+	if project := value("project_id"); project != "" {
+		projectID, err := uuid.Parse(project)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("project_id: %q is not a UUID", project))
+		} else if err := s.checkImportProject(run, projectID); err != nil {
+			problems = append(problems, "project_id: "+err.Error())
+		} else {
+			task.ProjectID = &projectID
+		}
+	}
+
+	tagNames := importList(raw[run.columns["tags"]])
+	for _, name := range tagNames {
+		if len(name) > 50 {
+			problems = append(problems, fmt.Sprintf("tags: %q is longer than 50 characters", name))
+		}
+	}
+	return task, tagNames, problems
+}
+
+// parseImportDate accepts RFC 3339 times and plain dates, which are taken as midnight UTC
+func parseImportDate(value string) (*time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return &t, nil
+	}
+	return nil, fmt.Errorf("%q is neither an RFC 3339 time nor a YYYY-MM-DD date", value)
+}
+
+// checkImportProject runs ensureOpenProject once per project and remembers the answer
+func (s *TaskServiceImpl) checkImportProject(run *taskImport, projectID uuid.UUID) error {
+	if err, checked := run.projects[projectID]; checked {
+		return err
+	}
+	err := s.ensureOpenProject(run.userID, run.workspaceID, projectID)
+	run.projects[projectID] = err
+	return err
+}
+
+// resolveImportTags maps tag names to the user's tags, creating the ones that do not exist yet
+func (s *TaskServiceImpl) resolveImportTags(run *taskImport, names []string) ([]uuid.UUID, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if run.tags == nil {
+		if err := s.loadImportTags(run); err != nil {
+			return nil, err
+		}
+	}
+
+	tagIDs := make([]uuid.UUID, 0, len(names))
+	for _, name := range names {
+		tagID, ok := run.tags[strings.ToLower(name)]
+		if !ok {
+			tag, err := s.TagRepo.CreateTag(&models.Tag{UserID: run.userID, Name: name, Color: defaultTagColor})
+			switch {
+			case errors.Is(err, repositories.ErrTagNameTaken):
+				// created in the meantime by someone else
+				if err := s.loadImportTags(run); err != nil {
+					return nil, err
+				}
+				if tagID, ok = run.tags[strings.ToLower(name)]; !ok {
+					return nil, fmt.Errorf("failed to create tag %q", name)
+				}
+			case err != nil:
+				log.Printf("Error creating tag %q for user %s during import: %v", name, run.userID, err)
+				return nil, fmt.Errorf("failed to create tag %q", name)
+			default:
+				tagID = tag.ID
+				run.tags[strings.ToLower(name)] = tagID
+			}
+		}
+		tagIDs = append(tagIDs, tagID)
+	}
+	return uniqueIDs(tagIDs), nil
+}
+
+func (s *TaskServiceImpl) loadImportTags(run *taskImport) error {
+	tags, err := s.TagRepo.ListTags(run.userID)
+	if err != nil {
+		log.Printf("Error listing tags of user %s during import: %v", run.userID, err)
+		return fmt.Errorf("failed to load tags")
+	}
+	run.tags = make(map[string]uuid.UUID, len(tags))
+	for _, tag := range tags {
+		run.tags[strings.ToLower(tag.Name)] = tag.ID
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/models"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// ListDeletedTasks returns the user's trashed tasks in a workspace
+func (s *TaskServiceImpl) ListDeletedTasks(userID, workspaceID uuid.UUID) ([]models.Task, error) {
+	tasks, err := s.TaskRepo.ListDeletedTasks(userID, workspaceID)
+	if err != nil {
+		log.Printf("Error listing trashed tasks for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to list trash: %v", err)
+	}
+	if tasks == nil {
+		tasks = []models.Task{}
+	}
+	return tasks, nil
+}
+
+// RestoreTask brings a task back from the trash with the subtasks that were deleted along with it
+func (s *TaskServiceImpl) RestoreTask(userID, taskID uuid.UUID, meta models.RequestMeta) (*models.Task, error) {
+	restored, err := s.TaskRepo.RestoreTask(taskID, userID)
+	if err != nil {
+		log.Printf("Error restoring task %s for user %s: %v", taskID, userID, err)
+		return nil, fmt.Errorf("failed to restore task: %v", err)
+	}
+	if !restored {
+		return nil, ErrTaskNotFound
+	}
+
+	task, err := s.GetTask(userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Task %s restored by user %s", taskID, userID)
+	s.Audit.Record(meta, taskAuditEvent(models.AuditActionTaskRestore, userID, taskID, nil, task))
+	return task, nil
+}
+
+// PurgeTask permanently deletes a task that is already in the trash
+func (s *TaskServiceImpl) PurgeTask(userID, taskID uuid.UUID, meta models.RequestMeta) error {
+	task, objects, err := s.TaskRepo.PurgeTask(taskID, userID)
+	if err != nil {
+		log.Printf("Error purging task %s for user %s: %v", taskID, userID, err)
+		return fmt.Errorf("failed to purge task: %v", err)
+	}
+	if task == nil {
+		return ErrTaskNotFound
+	}
+
+	log.Printf("Task %s purged by user %s", taskID, userID)
+	s.Attachments.RemoveUnusedObjects(objects)
+	s.Audit.Record(meta, taskAuditEvent(models.AuditActionTaskPurge, userID, taskID, task, nil))
+	return nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	config "TaskManagmentApis/configs"
+	"TaskManagmentApis/internal/repositories"
+	"context"
+	"log"
+	"time"
+)
+
+// TrashPurger periodically deletes tasks and users for good once they have been in the trash
+// longer than the retention period
+type TrashPurger struct {
+	TaskRepo    repositories.TaskRepository
+	UserRepo    repositories.AuthRepository
+	Attachments AttachmentService
+	Retention   time.Duration
+	Interval    time.Duration
+}
+
+// NewTrashPurger creates a TrashPurger configured from TRASH_RETENTION_DAYS and TRASH_PURGE_INTERVAL_MINUTES
+func NewTrashPurger(taskRepo repositories.TaskRepository, userRepo repositories.AuthRepository, attachmentService AttachmentService) *TrashPurger {
+	return &TrashPurger{
+		TaskRepo:    taskRepo,
+		UserRepo:    userRepo,
+		Attachments: attachmentService,
+		Retention:   time.Duration(config.Config.TrashRetentionDays) * 24 * time.Hour,
+		Interval:    time.Duration(config.Config.TrashPurgeIntervalMins) * time.Minute,
+	}
+}
+
+// Start runs a purge right away and then every TRASH_PURGE_INTERVAL_MINUTES until ctx is
+// cancelled; with an interval of 0 nothing is ever purged.
+func (p *TrashPurger) Start(ctx context.Context) {
+	if p.Interval <= 0 {
+		log.Println("Trash purge job disabled")
+		return
+	}
+
+	runPeriodic(ctx, p.Interval, p.PurgeOnce)
+}
+
+// PurgeOnce removes everything that went to the trash before now minus the retention period.
+// Errors are logged; the next run tries again.
+func (p *TrashPurger) PurgeOnce(now time.Time) {
+	cutoff := now.Add(-p.Retention)
+
+	tasks, objects, err := p.TaskRepo.PurgeDeletedTasks(cutoff)
+	if err != nil {
+		log.Printf("Error purging trashed tasks: %v", err)
+	} else if tasks > 0 {
+		log.Printf("Purged %d tasks trashed before %s", tasks, cutoff.Format(time.RFC3339))
+	}
+	p.Attachments.RemoveUnusedObjects(objects)
+
+	users, objects, err := p.UserRepo.PurgeDeletedUsers(cutoff)
+	if err != nil {
+		log.Printf("Error purging deleted users: %v", err)
+	} else if users > 0 {
+		log.Printf("Purged %d users deleted before %s", users, cutoff.Format(time.RFC3339))
+	}
+	p.Attachments.RemoveUnusedObjects(objects)
+}
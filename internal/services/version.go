@@ -0,0 +1,22 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/repositories"
+	"errors"
+)
+
+// ErrPreconditionFailed is returned when the version a client sent in If-Match is no longer current
+var ErrPreconditionFailed = errors.New("resource has been modified since it was fetched")
+
+// checkVersion compares the version the client expects with the current one; 0 accepts any version
+func checkVersion(expected, current int) error {
+	if expected != 0 && expected != current {
+		return ErrPreconditionFailed
+	}
+	return nil
+}
+
+// isVersionConflict reports whether a repository write lost a race with another writer
+func isVersionConflict(err error) bool {
+	return errors.Is(err, repositories.ErrVersionConflict)
+}
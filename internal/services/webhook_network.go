@@ -0,0 +1,94 @@
+package service
+
+import (
+	config "TaskManagmentApis/configs"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// cgnatNetwork is the shared address space carriers use; like private networks it is not the internet
+var cgnatNetwork = &net.IPNet{IP: net.IPv4(100, 64, 0, 0).To4(), Mask: net.CIDRMask(10, 32)}
+
+// WebhookAddressPolicy keeps webhooks from reaching the server's own network. Loopback, private,
+// link-local and other non-public addresses are refused unless they are in one of the networks
+// the operator allowed with WEBHOOK_ALLOWED_NETWORKS.
+type WebhookAddressPolicy struct {
+	Allowed []*net.IPNet
+}
+
+// NewWebhookAddressPolicy creates a WebhookAddressPolicy from WEBHOOK_ALLOWED_NETWORKS, a comma
+// separated list of CIDRs or single addresses. Invalid entries are logged and ignored.
+func NewWebhookAddressPolicy() *WebhookAddressPolicy {
+	policy := &WebhookAddressPolicy{}
+	for _, entry := range strings.Split(config.Config.WebhookAllowedNetworks, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		cidr := entry
+		if !strings.Contains(cidr, "/") {
+			if strings.Contains(cidr, ":") {
+				cidr += "/128"
+			} else {
+				cidr += "/32"
+			}
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			log.Printf("Ignoring invalid entry %q in WEBHOOK_ALLOWED_NETWORKS: %v", entry, err)
+			continue
+		}
+		policy.Allowed = append(policy.Allowed, network)
+	}
+	return policy
+}
+
+// Permits reports whether webhooks may connect to ip
+func (p *WebhookAddressPolicy) Permits(ip net.IP) bool {
+	for _, network := range p.Allowed {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !cgnatNetwork.Contains(ip)
+}
+
+// CheckHost refuses a webhook URL host that is a forbidden IP address or names this machine.
+// Other host names are only checked when a delivery connects, since what they resolve to can change.
+func (p *WebhookAddressPolicy) CheckHost(host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if !p.Permits(ip) {
+			return fmt.Errorf("%s is not a public address", host)
+		}
+		return nil
+	}
+	if host = strings.TrimSuffix(strings.ToLower(host), "."); host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		if !p.Permits(net.IPv4(127, 0, 0, 1)) {
+			return fmt.Errorf("%s is not a public host", host)
+		}
+	}
+	return nil
+}
+
+// DialContext connects like a net.Dialer with the given timeout, but checks every address the host
+// resolved to right before connecting to it, so DNS cannot point a checked host name elsewhere
+func (p *WebhookAddressPolicy) DialContext(timeout time.Duration) func(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !p.Permits(ip) {
+				return fmt.Errorf("webhook target %s is not a public address", host)
+			}
+			return nil
+		},
+	}
+	return dialer.DialContext
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	config "TaskManagmentApis/configs"
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	webhookBatchSize = 20
+	// webhookSendLease must outlast a batch of requests, or a slow one could be picked up twice
+	webhookSendLease      = 2 * time.Minute
+	maxWebhookBackoff     = 12 * time.Hour
+	maxWebhookCircuitOpen = 24 * time.Hour
+	// maxWebhookResponseBody is how much of a response is read and discarded so the connection can be reused
+	maxWebhookResponseBody = 2048
+)
+
+// Headers sent with every delivery. The signature is the hex HMAC-SHA256, keyed with the webhook's
+// secret, of the timestamp header, a dot and the body; receivers should reject stale timestamps.
+const (
+	WebhookHeaderEvent      = "X-Webhook-Event"
+	WebhookHeaderEventID    = "X-Webhook-Event-ID"
+	WebhookHeaderDelivery   = "X-Webhook-Delivery"
+	WebhookHeaderTimestamp  = "X-Webhook-Timestamp"
+	WebhookHeaderSignature  = "X-Webhook-Signature"
+	webhookSignaturePrefix  = "sha256="
+	webhookUserAgentSuffix  = "-Webhooks/1.0"
+	webhookContentTypeValue = "application/json"
+)
+
+// WebhookSender POSTs queued deliveries to their webhooks, retrying failures with exponential backoff.
+// After BreakerThreshold failures in a row a webhook's circuit opens: its deliveries wait for
+// BreakerCooldown, doubling with every further failure, and then a single trial delivery decides
+// whether the circuit closes again.
+type WebhookSender struct {
+	WebhookRepo      repositories.WebhookRepository
+	Client           *http.Client
+	UserAgent        string
+	MaxAttempts      int
+	RetryBase        time.Duration
+	Interval         time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// NewWebhookSender creates a WebhookSender configured from the WEBHOOK_* settings. Deliveries
+// connect directly, never through a proxy, so that address checks see the receiver's address.
+func NewWebhookSender(webhookRepo repositories.WebhookRepository, addresses *WebhookAddressPolicy) *WebhookSender {
+	timeout := time.Duration(config.Config.WebhookTimeoutSecs) * time.Second
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = addresses.DialContext(timeout)
+	return &WebhookSender{
+		WebhookRepo: webhookRepo,
+		Client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			// a redirect is not a delivery; the receiver should register the final URL
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		UserAgent:        config.Config.AppName + webhookUserAgentSuffix,
+		MaxAttempts:      config.Config.WebhookMaxAttempts,
+		RetryBase:        time.Duration(config.Config.WebhookRetryBaseSecs) * time.Second,
+		Interval:         time.Duration(config.Config.WebhookPollIntervalSecs) * time.Second,
+		BreakerThreshold: config.Config.WebhookBreakerThreshold,
+		BreakerCooldown:  time.Duration(config.Config.WebhookBreakerCooldownSecs) * time.Second,
+	}
+}
+
+// SignWebhookPayload returns the signature header value for a body sent at timestamp
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return webhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Start polls for due deliveries right away and then every WEBHOOK_POLL_INTERVAL_SECONDS until
+// ctx is cancelled; an interval of 0 leaves delivering to other instances.
+func (s *WebhookSender) Start(ctx context.Context) {
+	if s.Interval <= 0 {
+		log.Println("Webhook sender disabled")
+		return
+	}
+
+	runPeriodic(ctx, s.Interval, func(now time.Time) {
+		s.SendDue(ctx, now)
+	})
+}
+
+// SendDue sends every delivery whose next attempt has come, batch by batch. The requests of a
+// batch run concurrently so that one slow endpoint does not hold up the others.
+func (s *WebhookSender) SendDue(ctx context.Context, now time.Time) {
+	for ctx.Err() == nil {
+		deliveries, err := s.WebhookRepo.ClaimDueDeliveries(now, webhookSendLease, webhookBatchSize, s.BreakerThreshold)
+		if err != nil {
+			log.Printf("Error claiming webhook deliveries: %v", err)
+			return
+		}
+
+		var wg sync.WaitGroup
+		for i := range deliveries {
+			wg.Add(1)
+			go func(delivery *models.WebhookDelivery) {
+				defer wg.Done()
+				s.send(ctx, delivery)
+			}(&deliveries[i])
+		}
+		wg.Wait()
+
+		if len(deliveries) < webhookBatchSize {
+			return
+		}
+	}
+}
+
+// post makes one delivery attempt and returns the response status, if any, and what went wrong
+func (s *WebhookSender) post(ctx context.Context, delivery *models.WebhookDelivery) (*int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", webhookContentTypeValue)
+	req.Header.Set("User-Agent", s.UserAgent)
+	req.Header.Set(WebhookHeaderEvent, delivery.EventType)
+	req.Header.Set(WebhookHeaderEventID, delivery.EventID.String())
+	req.Header.Set(WebhookHeaderDelivery, delivery.ID.String())
+	req.Header.Set(WebhookHeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookHeaderSignature, SignWebhookPayload(delivery.Webhook.Secret, timestamp, body))
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxWebhookResponseBody))
+	status := resp.StatusCode
+	if status < 200 || status > 299 {
+		return &status, fmt.Errorf("endpoint responded %s", resp.Status)
+	}
+	return &status, nil
+}
+
+func (s *WebhookSender) send(ctx context.Context, delivery *models.WebhookDelivery) {
+	started := time.Now()
+	status, err := s.post(ctx, delivery)
+	now := time.Now()
+	duration := int(now.Sub(started) / time.Millisecond)
+
+	delivery.ResponseStatus = status
+	delivery.DurationMs = &duration
+	if err == nil {
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+		if err := s.WebhookRepo.SaveDeliveryAttempt(delivery); err != nil {
+			log.Printf("Error recording delivery %s: %v", delivery.ID, err)
+		}
+		if delivery.Webhook.ConsecutiveFailures > 0 || delivery.Webhook.CircuitOpenUntil != nil {
+			if err := s.WebhookRepo.RecordWebhookSuccess(delivery.WebhookID); err != nil {
+				log.Printf("Error closing circuit of webhook %s: %v", delivery.WebhookID, err)
+			}
+		}
+		return
+	}
+
+	delivery.LastError = err.Error()
+	delivery.Status, delivery.NextAttemptAt = models.WebhookDeliveryPending, now.Add(retryBackoff(s.RetryBase, maxWebhookBackoff, delivery.Attempts))
+	if delivery.Attempts >= s.MaxAttempts {
+		delivery.Status = models.WebhookDeliveryFailed
+		log.Printf("Giving up on %s delivery %s to webhook %s after %d attempts: %v", delivery.EventType, delivery.ID, delivery.WebhookID, delivery.Attempts, err)
+	} else {
+		log.Printf("Delivering %s %s to webhook %s failed (attempt %d), retrying at %s: %v", delivery.EventType, delivery.ID, delivery.WebhookID, delivery.Attempts, delivery.NextAttemptAt.Format(time.RFC3339), err)
+	}
+	if err := s.WebhookRepo.SaveDeliveryAttempt(delivery); err != nil {
+		log.Printf("Error recording failure of delivery %s: %v", delivery.ID, err)
+	}
+	s.recordFailure(delivery.WebhookID, now)
+}
+
+// recordFailure counts a failed delivery against the webhook and opens its circuit once the
+// failures in a row reach the threshold, for longer with every failure past it
+func (s *WebhookSender) recordFailure(webhookID uuid.UUID, now time.Time) {
+	failures, err := s.WebhookRepo.RecordWebhookFailure(webhookID)
+	if err != nil {
+		log.Printf("Error counting failure of webhook %s: %v", webhookID, err)
+		return
+	}
+	if s.BreakerThreshold <= 0 || failures < s.BreakerThreshold {
+		return
+	}
+
+	wait := s.BreakerCooldown
+	for i := s.BreakerThreshold; i < failures && wait < maxWebhookCircuitOpen; i++ {
+		wait *= 2
+	}
+	if wait > maxWebhookCircuitOpen {
+		wait = maxWebhookCircuitOpen
+	}
+	until := now.Add(wait)
+	if err := s.WebhookRepo.OpenCircuit(webhookID, until); err != nil {
+		log.Printf("Error opening circuit of webhook %s: %v", webhookID, err)
+		return
+	}
+	log.Printf("Circuit of webhook %s open until %s after %d failures in a row", webhookID, until.Format(time.RFC3339), failures)
+}
@@ -0,0 +1,389 @@
+package service
+
+import (
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidWebhookInput     = errors.New("invalid webhook")
+)
+
+const defaultWebhookDeliveryPageSize = 50
+
+// WebhookService defines the interface for managing webhooks and turning audited actions into deliveries
+type WebhookService interface {
+	CreateWebhook(userID uuid.UUID, input models.CreateWebhookRequest) (*models.WebhookWithSecret, error)
+	ListWebhooks(userID uuid.UUID) ([]models.Webhook, error)
+	GetWebhook(userID, webhookID uuid.UUID) (*models.Webhook, error)
+	UpdateWebhook(userID, webhookID uuid.UUID, input models.UpdateWebhookRequest) (*models.Webhook, error)
+	DeleteWebhook(userID, webhookID uuid.UUID) error
+	RotateSecret(userID, webhookID uuid.UUID) (*models.WebhookWithSecret, error)
+	ListDeliveries(userID, webhookID uuid.UUID, query models.WebhookDeliveryQuery) ([]models.WebhookDelivery, error)
+	Redeliver(userID, webhookID, deliveryID uuid.UUID) (*models.WebhookDelivery, error)
+	AuditEventRecorded(event *models.AuditEvent)
+}
+
+// WebhookServiceImpl is the concrete implementation of WebhookService
+type WebhookServiceImpl struct {
+	WebhookRepo repositories.WebhookRepository
+	TaskRepo    repositories.TaskRepository
+	Addresses   *WebhookAddressPolicy
+}
+
+// NewWebhookService creates a new WebhookService instance
+func NewWebhookService(webhookRepo repositories.WebhookRepository, taskRepo repositories.TaskRepository, addresses *WebhookAddressPolicy) WebhookService {
+	return &WebhookServiceImpl{
+		WebhookRepo: webhookRepo,
+		TaskRepo:    taskRepo,
+		Addresses:   addresses,
+	}
+}
+
+// newWebhookSecret returns a random key for signing a webhook's deliveries
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %v", err)
+	}
+	return "whsec_" + hex.EncodeToString(secret), nil
+}
+
+// validateWebhookURL accepts absolute http and https URLs of hosts the address policy permits
+func (s *WebhookServiceImpl) validateWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhookInput)
+	}
+	if err := s.Addresses.CheckHost(parsed.Hostname()); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWebhookInput, err)
+	}
+	return nil
+}
+
+// normalizeWebhookEvents checks the events are known and drops duplicates
+func normalizeWebhookEvents(events []string) (models.StringList, error) {
+	list := models.StringList{}
+	for _, event := range events {
+		if !models.IsValidWebhookEvent(event) {
+			return nil, fmt.Errorf("%w: unknown event %q", ErrInvalidWebhookInput, event)
+		}
+		if !list.Has(event) {
+			list = append(list, event)
+		}
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("%w: at least one event is required", ErrInvalidWebhookInput)
+	}
+	return list, nil
+}
+
+// CreateWebhook registers an endpoint for the user; the signing secret is only returned here
+func (s *WebhookServiceImpl) CreateWebhook(userID uuid.UUID, input models.CreateWebhookRequest) (*models.WebhookWithSecret, error) {
+	if err := s.validateWebhookURL(input.URL); err != nil {
+		return nil, err
+	}
+	events, err := normalizeWebhookEvents(input.Events)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &models.Webhook{
+		UserID:      userID,
+		URL:         input.URL,
+		Description: input.Description,
+		Events:      events,
+		Secret:      secret,
+		Active:      true,
+	}
+	if err := s.WebhookRepo.CreateWebhook(webhook); err != nil {
+		log.Printf("Error creating webhook for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to create webhook: %v", err)
+	}
+
+	log.Printf("Webhook %s created for user %s", webhook.ID, userID)
+	return &models.WebhookWithSecret{Webhook: webhook, Secret: secret}, nil
+}
+
+// ListWebhooks
+func (s *WebhookServiceImpl) ListWebhooks(userID uuid.UUID) ([]models.Webhook, error) {
+	webhooks, err := s.WebhookRepo.ListWebhooks(userID)
+	if err != nil {
+		log.Printf("Error listing webhooks of user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to list webhooks: %v", err)
+	}
+	if webhooks == nil {
+		webhooks = []models.Webhook{}
+	}
+	return webhooks, nil
+}
+
+// GetWebhook returns one of the user's webhooks
+func (s *WebhookServiceImpl) GetWebhook(userID, webhookID uuid.UUID) (*models.Webhook, error) {
+	webhook, err := s.WebhookRepo.GetWebhook(webhookID, userID)
+	if err != nil {
+		log.Printf("Error fetching webhook %s: %v", webhookID, err)
+		return nil, fmt.Errorf("failed to fetch webhook: %v", err)
+	}
+	if webhook == nil {
+		return nil, ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+// UpdateWebhook changes the fields that are set. Reactivating a webhook also resets its circuit
+// breaker, so its pending deliveries go out on the next poll.
+func (s *WebhookServiceImpl) UpdateWebhook(userID, webhookID uuid.UUID, input models.UpdateWebhookRequest) (*models.Webhook, error) {
+	webhook, err := s.GetWebhook(userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.URL != nil {
+		if err := s.validateWebhookURL(*input.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = *input.URL
+	}
+	if input.Description != nil {
+		webhook.Description = *input.Description
+	}
+	if input.Events != nil {
+		events, err := normalizeWebhookEvents(*input.Events)
+		if err != nil {
+			return nil, err
+		}
+		webhook.Events = events
+	}
+	reactivated := false
+	if input.Active != nil {
+		reactivated = *input.Active && !webhook.Active
+		webhook.Active = *input.Active
+	}
+	webhook.UpdatedAt = time.Now()
+
+	if err := s.WebhookRepo.UpdateWebhook(webhook); err != nil {
+		log.Printf("Error updating webhook %s: %v", webhookID, err)
+		return nil, fmt.Errorf("failed to update webhook: %v", err)
+	}
+	if reactivated {
+		if err := s.WebhookRepo.RecordWebhookSuccess(webhook.ID); err != nil {
+			log.Printf("Error resetting circuit of webhook %s: %v", webhookID, err)
+		}
+		webhook.ConsecutiveFailures, webhook.CircuitOpenUntil = 0, nil
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook removes the webhook along with its delivery log
+func (s *WebhookServiceImpl) DeleteWebhook(userID, webhookID uuid.UUID) error {
+	deleted, err := s.WebhookRepo.DeleteWebhook(webhookID, userID)
+	if err != nil {
+		log.Printf("Error deleting webhook %s: %v", webhookID, err)
+		return fmt.Errorf("failed to delete webhook: %v", err)
+	}
+	if !deleted {
+		return ErrWebhookNotFound
+	}
+	log.Printf("Webhook %s deleted by user %s", webhookID, userID)
+	return nil
+}
+
+// RotateSecret replaces the webhook's signing secret; deliveries are signed with the new one from
+// their next attempt on
+func (s *WebhookServiceImpl) RotateSecret(userID, webhookID uuid.UUID) (*models.WebhookWithSecret, error) {
+	webhook, err := s.GetWebhook(userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = secret
+	webhook.UpdatedAt = time.Now()
+	if err := s.WebhookRepo.UpdateWebhook(webhook); err != nil {
+		log.Printf("Error rotating secret of webhook %s: %v", webhookID, err)
+		return nil, fmt.Errorf("failed to rotate webhook secret: %v", err)
+	}
+
+	log.Printf("Secret of webhook %s rotated by user %s", webhookID, userID)
+	return &models.WebhookWithSecret{Webhook: webhook, Secret: secret}, nil
+}
+
+// ListDeliveries returns the webhook's delivery log, newest first
+func (s *WebhookServiceImpl) ListDeliveries(userID, webhookID uuid.UUID, query models.WebhookDeliveryQuery) ([]models.WebhookDelivery, error) {
+	if _, err := s.GetWebhook(userID, webhookID); err != nil {
+		return nil, err
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultWebhookDeliveryPageSize
+	}
+
+	deliveries, err := s.WebhookRepo.ListDeliveries(webhookID, query.Status, limit)
+	if err != nil {
+		log.Printf("Error listing deliveries of webhook %s: %v", webhookID, err)
+		return nil, fmt.Errorf("failed to list webhook deliveries: %v", err)
+	}
+	if deliveries == nil {
+		deliveries = []models.WebhookDelivery{}
+	}
+	return deliveries, nil
+}
+
+// Redeliver queues a delivery's event to be sent again as soon as possible
+func (s *WebhookServiceImpl) Redeliver(userID, webhookID, deliveryID uuid.UUID) (*models.WebhookDelivery, error) {
+	if _, err := s.GetWebhook(userID, webhookID); err != nil {
+		return nil, err
+	}
+	original, err := s.WebhookRepo.GetDelivery(webhookID, deliveryID)
+	if err != nil {
+		log.Printf("Error fetching delivery %s of webhook %s: %v", deliveryID, webhookID, err)
+		return nil, fmt.Errorf("failed to fetch webhook delivery: %v", err)
+	}
+	if original == nil {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	delivery, err := s.WebhookRepo.Redeliver(original)
+	if err != nil {
+		log.Printf("Error redelivering %s of webhook %s: %v", deliveryID, webhookID, err)
+		return nil, fmt.Errorf("failed to redeliver webhook delivery: %v", err)
+	}
+	log.Printf("Delivery %s of webhook %s queued again as %s", deliveryID, webhookID, delivery.ID)
+	return delivery, nil
+}
+
+// webhookEventTypes maps an audit action to the webhook events it raises
+func webhookEventTypes(event *models.AuditEvent) []string {
+	switch event.Action {
+	case models.AuditActionTaskCreate:
+		return []string{models.WebhookEventTaskCreated}
+	case models.AuditActionTaskUpdate, models.AuditActionTaskMove, models.AuditActionTaskAssignees,
+		models.AuditActionTaskProjectChange, models.AuditActionTaskDependencyAdd, models.AuditActionTaskDependencyDrop,
+		models.AuditActionTaskTags, models.AuditActionTaskAttachmentAdd, models.AuditActionTaskAttachmentDrop:
+		types := []string{models.WebhookEventTaskUpdated}
+		if event.After["status"] == models.TaskStatusDone {
+			types = append(types, models.WebhookEventTaskCompleted)
+		}
+		return types
+	case models.AuditActionTaskDelete:
+		return []string{models.WebhookEventTaskDeleted}
+	case models.AuditActionTaskRestore:
+		return []string{models.WebhookEventTaskRestored}
+	case models.AuditActionLogin:
+		return []string{models.WebhookEventUserLogin}
+	case models.AuditActionLogout:
+		return []string{models.WebhookEventUserLogout}
+	}
+	return nil
+}
+
+// AuditEventRecorded queues a delivery to every webhook subscribed to the events the audited action
+// raises: the owner's and assignees' for task events, the user's own for account events. It runs
+// in the request that made the change and only queues deliveries; what cannot be queued is logged.
+func (s *WebhookServiceImpl) AuditEventRecorded(event *models.AuditEvent) {
+	types := webhookEventTypes(event)
+	if len(types) == 0 {
+		return
+	}
+
+	var userIDs []uuid.UUID
+	data := map[string]interface{}{}
+	switch event.EntityType {
+	case models.AuditEntityTask:
+		taskID, err := uuid.Parse(event.EntityID)
+		if err != nil {
+			return
+		}
+		task, subscribers, err := s.TaskRepo.GetTaskSubscribers(taskID)
+		if err != nil {
+			log.Printf("Error fetching webhook subscribers of task %s: %v", taskID, err)
+			return
+		}
+		if task == nil {
+			return
+		}
+		userIDs = subscribers
+		data["task"] = task
+		if event.Before != nil && event.After != nil {
+			data["changes"] = map[string]interface{}{"before": event.Before, "after": event.After}
+		}
+	case models.AuditEntityUser:
+		userID, err := uuid.Parse(event.EntityID)
+		if err != nil {
+			return
+		}
+		userIDs = []uuid.UUID{userID}
+		data["user_id"] = userID
+		data["ip"] = event.IP
+		data["user_agent"] = event.UserAgent
+		if workspaceID, ok := event.Metadata["workspace_id"]; ok {
+			data["workspace_id"] = workspaceID
+		}
+	default:
+		return
+	}
+
+	for _, eventType := range types {
+		s.enqueue(eventType, event, userIDs, data)
+	}
+}
+
+// enqueue stores one delivery of the event per subscribed webhook of the users
+func (s *WebhookServiceImpl) enqueue(eventType string, audit *models.AuditEvent, userIDs []uuid.UUID, data map[string]interface{}) {
+	webhooks, err := s.WebhookRepo.ListSubscribedWebhooks(userIDs, eventType)
+	if err != nil {
+		log.Printf("Error listing webhooks subscribed to %s: %v", eventType, err)
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	event := models.WebhookEvent{
+		ID:         uuid.New(),
+		Type:       eventType,
+		OccurredAt: audit.CreatedAt,
+		ActorID:    audit.ActorID,
+		Data:       data,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Error encoding %s webhook event: %v", eventType, err)
+		return
+	}
+
+	now := time.Now()
+	deliveries := make([]models.WebhookDelivery, len(webhooks))
+	for i, webhook := range webhooks {
+		deliveries[i] = models.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			EventType:     eventType,
+			Payload:       string(payload),
+			Status:        models.WebhookDeliveryPending,
+			NextAttemptAt: now,
+		}
+	}
+	if err := s.WebhookRepo.EnqueueDeliveries(deliveries); err != nil {
+		log.Printf("Error queueing %d %s webhook deliveries: %v", len(deliveries), eventType, err)
+	}
+}
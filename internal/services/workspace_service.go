@@ -0,0 +1,360 @@
+package service
+
+import (
+	config "TaskManagmentApis/configs"
+	"TaskManagmentApis/internal/mail"
+	"TaskManagmentApis/internal/models"
+	"TaskManagmentApis/internal/repositories"
+	"TaskManagmentApis/pkg/utils"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrWorkspaceNotFound is returned when a workspace does not exist or the user is not a member
+var ErrWorkspaceNotFound = errors.New("workspace not found")
+
+// ErrWorkspaceForbidden is returned when the user's role in the workspace does not allow the action
+var ErrWorkspaceForbidden = errors.New("your workspace role does not allow this")
+
+// ErrWorkspaceMemberNotFound is returned when the user to change is not a member of the workspace
+var ErrWorkspaceMemberNotFound = errors.New("workspace member not found")
+
+// ErrWorkspaceMemberExists is returned when adding a user who already belongs to the workspace
+var ErrWorkspaceMemberExists = errors.New("user is already a member of this workspace")
+
+// ErrLastWorkspaceOwner is returned when a change would leave a workspace without an owner
+var ErrLastWorkspaceOwner = errors.New("workspace must keep at least one owner")
+
+// ErrInvalidWorkspaceInput is returned for empty names and similar bad values
+var ErrInvalidWorkspaceInput = errors.New("invalid workspace input")
+
+// WorkspaceService defines the interface for workspace and membership operations
+type WorkspaceService interface {
+	CreateWorkspace(userID uuid.UUID, input models.CreateWorkspaceRequest) (*models.Workspace, error)
+	ListWorkspaces(userID uuid.UUID) ([]models.WorkspaceMember, error)
+	GetWorkspace(userID, workspaceID uuid.UUID) (*models.WorkspaceMember, error)
+	UpdateWorkspace(userID, workspaceID uuid.UUID, input models.UpdateWorkspaceRequest) (*models.Workspace, error)
+	DeleteWorkspace(userID, workspaceID uuid.UUID) error
+	ListMembers(userID, workspaceID uuid.UUID) ([]models.WorkspaceMember, error)
+	AddMember(userID, workspaceID uuid.UUID, input models.AddWorkspaceMemberRequest) (*models.WorkspaceMember, error)
+	UpdateMemberRole(userID, workspaceID, memberID uuid.UUID, input models.UpdateWorkspaceMemberRequest) (*models.WorkspaceMember, error)
+	RemoveMember(userID, workspaceID, memberID uuid.UUID) error
+	SwitchWorkspace(userID, workspaceID uuid.UUID) (*models.WorkspaceSwitchResult, error)
+}
+
+// WorkspaceServiceImpl is the concrete implementation of WorkspaceService
+type WorkspaceServiceImpl struct {
+	WorkspaceRepo       repositories.WorkspaceRepository
+	UserRepo            repositories.AuthRepository
+	Emails              EmailService
+	Attachments         AttachmentService
+	GenerateAccessToken func(string, string, string, string) (string, error)
+}
+
+// NewWorkspaceService creates a new WorkspaceService instance
+func NewWorkspaceService(workspaceRepo repositories.WorkspaceRepository, userRepo repositories.AuthRepository, emailService EmailService, attachmentService AttachmentService) WorkspaceService {
+	return &WorkspaceServiceImpl{
+		WorkspaceRepo:       workspaceRepo,
+		UserRepo:            userRepo,
+		Emails:              emailService,
+		Attachments:         attachmentService,
+		GenerateAccessToken: utils.GenerateAccessToken,
+	}
+}
+
+// requireWorkspaceRole returns the user's membership if it grants at least minRole.
+// Non-members get ErrWorkspaceNotFound so they cannot probe which workspaces exist.
+func requireWorkspaceRole(repo repositories.WorkspaceRepository, workspaceID, userID uuid.UUID, minRole string) (*models.WorkspaceMember, error) {
+	member, err := repo.GetMembership(workspaceID, userID)
+	if err != nil {
+		log.Printf("Error fetching membership of user %s in workspace %s: %v", userID, workspaceID, err)
+		return nil, fmt.Errorf("failed to fetch workspace membership: %v", err)
+	}
+	if member == nil {
+		return nil, ErrWorkspaceNotFound
+	}
+	if models.WorkspaceRoleRank(member.Role) < models.WorkspaceRoleRank(minRole) {
+		return nil, ErrWorkspaceForbidden
+	}
+	return member, nil
+}
+
+// canManageMember reports whether actorRole may change a member holding currentRole into newRole.
+// Owners may do anything; admins may manage everyone below owner but never grant ownership.
+func canManageMember(actorRole, currentRole, newRole string) bool {
+	switch actorRole {
+	case models.WorkspaceRoleOwner:
+		return true
+	case models.WorkspaceRoleAdmin:
+		return currentRole != models.WorkspaceRoleOwner && newRole != models.WorkspaceRoleOwner
+	default:
+		return false
+	}
+}
+
+// mapMemberError converts repository errors into service errors
+func mapMemberError(err error, action string) error {
+	if errors.Is(err, repositories.ErrLastWorkspaceOwner) {
+		return ErrLastWorkspaceOwner
+	}
+	return fmt.Errorf("failed to %s: %v", action, err)
+}
+
+// CreateWorkspace creates a workspace with the user as its owner
+func (s *WorkspaceServiceImpl) CreateWorkspace(userID uuid.UUID, input models.CreateWorkspaceRequest) (*models.Workspace, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidWorkspaceInput)
+	}
+
+	workspace, err := s.WorkspaceRepo.CreateWorkspace(&models.Workspace{Name: name, CreatedBy: &userID}, userID)
+	if err != nil {
+		log.Printf("Error creating workspace for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to create workspace: %v", err)
+	}
+
+	log.Printf("Workspace %s created by user %s", workspace.ID, userID)
+	return workspace, nil
+}
+
+// ListWorkspaces returns the user's memberships together with their workspaces
+func (s *WorkspaceServiceImpl) ListWorkspaces(userID uuid.UUID) ([]models.WorkspaceMember, error) {
+	memberships, err := s.WorkspaceRepo.ListMemberships(userID)
+	if err != nil {
+		log.Printf("Error listing workspaces for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to list workspaces: %v", err)
+	}
+	if memberships == nil {
+		memberships = []models.WorkspaceMember{}
+	}
+	return memberships, nil
+}
+
+// GetWorkspace returns the user's membership in a workspace, including the workspace itself
+func (s *WorkspaceServiceImpl) GetWorkspace(userID, workspaceID uuid.UUID) (*models.WorkspaceMember, error) {
+	return requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleGuest)
+}
+
+// UpdateWorkspace renames a workspace; owners and admins only
+func (s *WorkspaceServiceImpl) UpdateWorkspace(userID, workspaceID uuid.UUID, input models.UpdateWorkspaceRequest) (*models.Workspace, error) {
+	member, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidWorkspaceInput)
+	}
+
+	workspace := member.Workspace
+	workspace.Name = name
+	updated, err := s.WorkspaceRepo.UpdateWorkspace(workspace)
+	if err != nil {
+		log.Printf("Error updating workspace %s for user %s: %v", workspaceID, userID, err)
+		return nil, fmt.Errorf("failed to update workspace: %v", err)
+	}
+	return updated, nil
+}
+
+// DeleteWorkspace deletes a workspace with all of its tasks and projects; owners only
+func (s *WorkspaceServiceImpl) DeleteWorkspace(userID, workspaceID uuid.UUID) error {
+	if _, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleOwner); err != nil {
+		return err
+	}
+
+	deleted, objects, err := s.WorkspaceRepo.DeleteWorkspace(workspaceID)
+	if err != nil {
+		log.Printf("Error deleting workspace %s for user %s: %v", workspaceID, userID, err)
+		return fmt.Errorf("failed to delete workspace: %v", err)
+	}
+	if !deleted {
+		return ErrWorkspaceNotFound
+	}
+
+	log.Printf("Workspace %s deleted by user %s", workspaceID, userID)
+	s.Attachments.RemoveUnusedObjects(objects)
+	return nil
+}
+
+// ListMembers returns the members of a workspace the user belongs to
+func (s *WorkspaceServiceImpl) ListMembers(userID, workspaceID uuid.UUID) ([]models.WorkspaceMember, error) {
+	if _, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleGuest); err != nil {
+		return nil, err
+	}
+
+	members, err := s.WorkspaceRepo.ListMembers(workspaceID)
+	if err != nil {
+		log.Printf("Error listing members of workspace %s: %v", workspaceID, err)
+		return nil, fmt.Errorf("failed to list workspace members: %v", err)
+	}
+	if members == nil {
+		members = []models.WorkspaceMember{}
+	}
+	return members, nil
+}
+
+// getMember returns a member of the workspace or ErrWorkspaceMemberNotFound
+func (s *WorkspaceServiceImpl) getMember(workspaceID, memberID uuid.UUID) (*models.WorkspaceMember, error) {
+	member, err := s.WorkspaceRepo.GetMembership(workspaceID, memberID)
+	if err != nil {
+		log.Printf("Error fetching member %s of workspace %s: %v", memberID, workspaceID, err)
+		return nil, fmt.Errorf("failed to fetch workspace member: %v", err)
+	}
+	if member == nil {
+		return nil, ErrWorkspaceMemberNotFound
+	}
+	return member, nil
+}
+
+// AddMember adds an existing user, found by email, to the workspace
+func (s *WorkspaceServiceImpl) AddMember(userID, workspaceID uuid.UUID, input models.AddWorkspaceMemberRequest) (*models.WorkspaceMember, error) {
+	actor, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageMember(actor.Role, "", input.Role) {
+		return nil, fmt.Errorf("%w: only owners can add owners", ErrWorkspaceForbidden)
+	}
+
+	user, err := s.UserRepo.GetUserByEmail(strings.TrimSpace(input.Email))
+	if err != nil {
+		log.Printf("Error fetching user %s for workspace %s: %v", input.Email, workspaceID, err)
+		return nil, fmt.Errorf("failed to fetch user: %v", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("%w: no user with email %s", ErrWorkspaceMemberNotFound, input.Email)
+	}
+
+	added, err := s.WorkspaceRepo.AddMember(&models.WorkspaceMember{WorkspaceID: workspaceID, UserID: user.ID, Role: input.Role})
+	if err != nil {
+		log.Printf("Error adding user %s to workspace %s: %v", user.ID, workspaceID, err)
+		return nil, fmt.Errorf("failed to add workspace member: %v", err)
+	}
+	if !added {
+		return nil, ErrWorkspaceMemberExists
+	}
+
+	log.Printf("User %s added to workspace %s as %s by user %s", user.ID, workspaceID, input.Role, userID)
+	s.emailMemberAdded(userID, workspaceID, user, input.Role)
+	member, err := s.getMember(workspaceID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	member.User = user
+	return member, nil
+}
+
+// emailMemberAdded tells a user that they were added to a workspace. Failing to is only logged.
+func (s *WorkspaceServiceImpl) emailMemberAdded(actorID, workspaceID uuid.UUID, user *models.User, role string) {
+	actor, err := s.UserRepo.GetUserByID(actorID)
+	if err != nil || actor == nil {
+		log.Printf("Error fetching user %s for the member added email: %v", actorID, err)
+		return
+	}
+	workspace, err := s.WorkspaceRepo.GetWorkspace(workspaceID)
+	if err != nil || workspace == nil {
+		log.Printf("Error fetching workspace %s for the member added email: %v", workspaceID, err)
+		return
+	}
+	s.Emails.Enqueue(mail.TemplateWorkspaceMemberAdded, user.Email, mail.WorkspaceMemberAddedData{
+		UserName:      user.Name,
+		ActorName:     actor.Name,
+		WorkspaceName: workspace.Name,
+		Role:          role,
+		WorkspaceURL:  config.Config.PublicBaseURL + "/workspaces/" + workspaceID.String(),
+	})
+}
+
+// UpdateMemberRole changes the role of a workspace member
+func (s *WorkspaceServiceImpl) UpdateMemberRole(userID, workspaceID, memberID uuid.UUID, input models.UpdateWorkspaceMemberRequest) (*models.WorkspaceMember, error) {
+	actor, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	member, err := s.getMember(workspaceID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageMember(actor.Role, member.Role, input.Role) {
+		return nil, fmt.Errorf("%w: only owners can grant or change ownership", ErrWorkspaceForbidden)
+	}
+
+	updated, err := s.WorkspaceRepo.SetMemberRole(workspaceID, memberID, input.Role)
+	if err != nil {
+		log.Printf("Error changing role of user %s in workspace %s: %v", memberID, workspaceID, err)
+		return nil, mapMemberError(err, "change member role")
+	}
+	if !updated {
+		return nil, ErrWorkspaceMemberNotFound
+	}
+
+	log.Printf("User %s is now %s in workspace %s (changed by user %s)", memberID, input.Role, workspaceID, userID)
+	member.Role = input.Role
+	return member, nil
+}
+
+// RemoveMember removes a member from the workspace. Every member may leave on their own;
+// removing someone else needs a role that can manage them.
+func (s *WorkspaceServiceImpl) RemoveMember(userID, workspaceID, memberID uuid.UUID) error {
+	actor, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleGuest)
+	if err != nil {
+		return err
+	}
+	if memberID != userID {
+		member, err := s.getMember(workspaceID, memberID)
+		if err != nil {
+			return err
+		}
+		if !canManageMember(actor.Role, member.Role, "") {
+			return ErrWorkspaceForbidden
+		}
+	}
+
+	removed, err := s.WorkspaceRepo.RemoveMember(workspaceID, memberID)
+	if err != nil {
+		log.Printf("Error removing user %s from workspace %s: %v", memberID, workspaceID, err)
+		return mapMemberError(err, "remove workspace member")
+	}
+	if !removed {
+		return ErrWorkspaceMemberNotFound
+	}
+
+	log.Printf("User %s removed from workspace %s by user %s", memberID, workspaceID, userID)
+	return nil
+}
+
+// SwitchWorkspace makes a workspace the user's active one and issues an access token scoped to it
+func (s *WorkspaceServiceImpl) SwitchWorkspace(userID, workspaceID uuid.UUID) (*models.WorkspaceSwitchResult, error) {
+	member, err := requireWorkspaceRole(s.WorkspaceRepo, workspaceID, userID, models.WorkspaceRoleGuest)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.UserRepo.GetUserByID(userID)
+	if err != nil || user == nil {
+		log.Printf("Error fetching user %s for workspace switch: %v", userID, err)
+		return nil, errors.New("user not found")
+	}
+
+	if err := s.WorkspaceRepo.SetActiveWorkspace(userID, &workspaceID); err != nil {
+		log.Printf("Error switching user %s to workspace %s: %v", userID, workspaceID, err)
+		return nil, fmt.Errorf("failed to switch workspace: %v", err)
+	}
+
+	accessToken, err := s.GenerateAccessToken(user.ID.String(), user.Email, workspaceID.String(), user.Role)
+	if err != nil {
+		log.Printf("Error generating access token for user %s: %v", userID, err)
+		return nil, fmt.Errorf("failed to generate access token: %v", err)
+	}
+
+	log.Printf("User %s switched to workspace %s", userID, workspaceID)
+	return &models.WorkspaceSwitchResult{
+		AccessToken: accessToken,
+		Workspace:   *member.Workspace,
+		Role:        member.Role,
+	}, nil
+}
@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalStorage keeps objects as files below a root directory. Its signed URLs point back at
+// this API (see Verify), since a plain directory cannot check signatures itself.
+type LocalStorage struct {
+	root       string
+	baseURL    string
+	signingKey []byte
+}
+
+// NewLocalStorage creates the root directory if needed. baseURL is the public URL of the
+// route that serves signed downloads.
+func NewLocalStorage(root, baseURL string, signingKey []byte) (*LocalStorage, error) {
+	if len(signingKey) == 0 {
+		return nil, errors.New("local storage needs a signing key")
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %v", err)
+	}
+	return &LocalStorage{
+		root:       root,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		signingKey: signingKey,
+	}, nil
+}
+
+// path maps a key to a file below root, rejecting keys that would escape it
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || clean != "/"+key {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(clean)), nil
+}
+
+// Put writes to a temporary file first so readers never see a partial object
+func (s *LocalStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType, checksum string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	hasher := sha256.New()
+	written, err := io.Copy(io.MultiWriter(tmp, hasher), body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if written != size || hex.EncodeToString(hasher.Sum(nil)) != checksum {
+		return errors.New("stored content does not match the expected size or checksum")
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+func (s *LocalStorage) Exists(ctx context.Context, key string) (bool, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(target); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *LocalStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(target)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, err
+	}
+	return file, nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// signature authenticates everything a signed URL controls: the key, how it is presented and when it expires
+func (s *LocalStorage) signature(key string, download Download, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%d", key, download.FileName, download.ContentType, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *LocalStorage) SignedURL(key string, download Download, expiresIn time.Duration) (string, error) {
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+	expires := time.Now().Add(expiresIn).Unix()
+	query := url.Values{}
+	query.Set("name", download.FileName)
+	query.Set("type", download.ContentType)
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.signature(key, download, expires))
+	return s.baseURL + "/" + key + "?" + query.Encode(), nil
+}
+
+// Verify checks the query of a URL produced by SignedURL and returns how to present the object
+func (s *LocalStorage) Verify(key string, query url.Values) (Download, error) {
+	download := Download{FileName: query.Get("name"), ContentType: query.Get("type")}
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return Download{}, ErrInvalidSignature
+	}
+	expected := s.signature(key, download, expires)
+	if !hmac.Equal([]byte(expected), []byte(query.Get("signature"))) {
+		return Download{}, ErrInvalidSignature
+	}
+	return download, nil
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	config "TaskManagmentApis/configs"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrObjectNotFound is returned when no object is stored under a key
+var ErrObjectNotFound = errors.New("object not found")
+
+// ErrInvalidSignature is returned when a signed URL is malformed, tampered with or expired
+var ErrInvalidSignature = errors.New("invalid or expired signature")
+
+// Download describes how a signed URL should present the object to the browser
+type Download struct {
+	FileName    string
+	ContentType string
+}
+
+// Storage is a blob store for uploaded files. Keys are opaque, slash separated paths.
+type Storage interface {
+	// Put stores size bytes from body under key; checksum is the hex SHA-256 of the content
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType, checksum string) error
+	Exists(ctx context.Context, key string) (bool, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a URL that downloads the object without further authentication until it expires
+	SignedURL(key string, download Download, expiresIn time.Duration) (string, error)
+}
+
+// NewFromConfig builds the backend selected by STORAGE_BACKEND
+func NewFromConfig() (Storage, error) {
+	cfg := config.Config
+	switch cfg.StorageBackend {
+	case "local":
+		return NewLocalStorage(cfg.StorageLocalDir, cfg.PublicBaseURL+"/files", []byte(cfg.StorageSigningKey))
+	case "s3":
+		return NewS3Storage(S3Options{
+			Endpoint:     cfg.S3Endpoint,
+			Region:       cfg.S3Region,
+			Bucket:       cfg.S3Bucket,
+			AccessKey:    cfg.S3AccessKey,
+			SecretKey:    cfg.S3SecretKey,
+			UsePathStyle: cfg.S3UsePathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.StorageBackend)
+	}
+}
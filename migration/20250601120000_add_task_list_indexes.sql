@@ -0,0 +1,30 @@
+-- +goose Up
+-- Composite indexes backing GET /tasks keyset pagination on (created_at, id)
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_tasks_user_created_id ON tasks (user_id, created_at DESC, id DESC);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_tasks_user_status_created_id ON tasks (user_id, status, created_at DESC, id DESC);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_tasks_user_priority_created_id ON tasks (user_id, priority, created_at DESC, id DESC);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_tasks_user_due_date ON tasks (user_id, due_date);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_tasks_user_updated_id ON tasks (user_id, updated_at DESC, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_user_updated_id;
+DROP INDEX IF EXISTS idx_tasks_user_due_date;
+DROP INDEX IF EXISTS idx_tasks_user_priority_created_id;
+DROP INDEX IF EXISTS idx_tasks_user_status_created_id;
+DROP INDEX IF EXISTS idx_tasks_user_created_id;
+-- +goose StatementEnd
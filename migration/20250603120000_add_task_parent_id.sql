@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES tasks(id),
+    ADD CONSTRAINT chk_tasks_parent_not_self CHECK (parent_id IS NULL OR parent_id <> id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks (parent_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_parent_id;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS chk_tasks_parent_not_self;
+ALTER TABLE tasks DROP COLUMN IF EXISTS parent_id;
+-- +goose StatementEnd
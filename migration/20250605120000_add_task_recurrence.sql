@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS recurrence_series (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rule VARCHAR(255) NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    start_at TIMESTAMPTZ NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    priority VARCHAR(20) DEFAULT 'medium',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_recurrence_series_user_id ON recurrence_series (user_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS series_id UUID REFERENCES recurrence_series(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS occurrence_index INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- one task per occurrence, so completing an occurrence twice cannot spawn two next instances
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_series_occurrence ON tasks (series_id, occurrence_index) WHERE series_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_series_occurrence;
+ALTER TABLE tasks DROP COLUMN IF EXISTS occurrence_index;
+ALTER TABLE tasks DROP COLUMN IF EXISTS series_id;
+DROP TABLE IF EXISTS recurrence_series;
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+UPDATE tasks SET status = 'pending' WHERE status IS NULL OR status NOT IN ('pending', 'in_progress', 'done', 'reopened', 'cancelled');
+UPDATE tasks SET priority = 'medium' WHERE priority IS NULL OR priority NOT IN ('low', 'medium', 'high', 'urgent');
+UPDATE recurrence_series SET priority = 'medium' WHERE priority IS NULL OR priority NOT IN ('low', 'medium', 'high', 'urgent');
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE tasks
+    ALTER COLUMN status SET NOT NULL,
+    ALTER COLUMN priority SET NOT NULL,
+    ADD CONSTRAINT chk_tasks_status CHECK (status IN ('pending', 'in_progress', 'done', 'reopened', 'cancelled')),
+    ADD CONSTRAINT chk_tasks_priority CHECK (priority IN ('low', 'medium', 'high', 'urgent'));
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE recurrence_series
+    ADD CONSTRAINT chk_recurrence_series_priority CHECK (priority IN ('low', 'medium', 'high', 'urgent'));
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS task_status_transitions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    actor_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_task_status_transitions_task_created ON task_status_transitions (task_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS task_status_transitions;
+ALTER TABLE recurrence_series DROP CONSTRAINT IF EXISTS chk_recurrence_series_priority;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS chk_tasks_priority;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS chk_tasks_status;
+ALTER TABLE tasks ALTER COLUMN priority DROP NOT NULL;
+ALTER TABLE tasks ALTER COLUMN status DROP NOT NULL;
+-- +goose StatementEnd
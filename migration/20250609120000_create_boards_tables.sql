@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS boards (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    project_id UUID REFERENCES projects(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_boards_owner_id ON boards (owner_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS board_columns (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'in_progress', 'done', 'reopened', 'cancelled')),
+    position INTEGER NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_board_columns_board_position ON board_columns (board_id, position);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- rank is compared byte-wise, so it must not use a locale collation
+CREATE TABLE IF NOT EXISTS board_cards (
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    column_id UUID NOT NULL REFERENCES board_columns(id) ON DELETE CASCADE,
+    rank VARCHAR(64) COLLATE "C" NOT NULL,
+    PRIMARY KEY (board_id, task_id)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_board_cards_column_rank ON board_cards (column_id, rank);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS board_cards;
+DROP TABLE IF EXISTS board_columns;
+DROP TABLE IF EXISTS boards;
+-- +goose StatementEnd
@@ -0,0 +1,70 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS workspaces (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS workspace_members (
+    workspace_id UUID NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'admin', 'member', 'guest')),
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (workspace_id, user_id)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_workspace_members_user_id ON workspace_members (user_id, joined_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- every existing user gets a personal workspace that reuses their user ID, so the
+-- backfill below can map tasks and projects to it without a lookup table
+INSERT INTO workspaces (id, name, created_by)
+SELECT id, name || '''s workspace', id FROM users
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO workspace_members (workspace_id, user_id, role)
+SELECT id, id, 'owner' FROM users
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN IF NOT EXISTS active_workspace_id UUID REFERENCES workspaces(id) ON DELETE SET NULL;
+UPDATE users SET active_workspace_id = id WHERE active_workspace_id IS NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS workspace_id UUID REFERENCES workspaces(id) ON DELETE CASCADE;
+UPDATE tasks SET workspace_id = user_id WHERE workspace_id IS NULL;
+ALTER TABLE tasks ALTER COLUMN workspace_id SET NOT NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS workspace_id UUID REFERENCES workspaces(id) ON DELETE CASCADE;
+UPDATE projects SET workspace_id = owner_id WHERE workspace_id IS NULL;
+ALTER TABLE projects ALTER COLUMN workspace_id SET NOT NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- GET /tasks and GET /projects now filter by workspace before anything else
+CREATE INDEX IF NOT EXISTS idx_tasks_workspace_user_created_id ON tasks (workspace_id, user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_projects_workspace_id ON projects (workspace_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_projects_workspace_id;
+DROP INDEX IF EXISTS idx_tasks_workspace_user_created_id;
+ALTER TABLE projects DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE users DROP COLUMN IF EXISTS active_workspace_id;
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- roles are now enforced, so anything that is not a known role falls back to a plain user
+UPDATE users SET role = 'user' WHERE role IS NULL OR role NOT IN ('user', 'admin');
+ALTER TABLE users ALTER COLUMN role SET DEFAULT 'user';
+ALTER TABLE users ALTER COLUMN role SET NOT NULL;
+ALTER TABLE users ADD CONSTRAINT chk_users_role CHECK (role IN ('user', 'admin'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_role;
+ALTER TABLE users ALTER COLUMN role DROP NOT NULL;
+-- +goose StatementEnd
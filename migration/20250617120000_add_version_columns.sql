@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- bumped on every write; clients send it back in If-Match so a stale edit is rejected
+-- instead of silently overwriting someone else's change
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS version;
+ALTER TABLE tasks DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
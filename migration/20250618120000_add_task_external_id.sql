@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- set by imports; re-importing a row with the same external ID finds the task instead of duplicating it.
+-- Trashed tasks keep their external ID so that a re-import does not resurrect them as copies.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS external_id VARCHAR(255);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_external_id ON tasks (user_id, workspace_id, external_id) WHERE external_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_external_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS external_id;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- one feed per user; only the SHA-256 of the URL token is stored, so a leaked database does not
+-- leak working feed URLs. Regenerating the token replaces the row.
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    last_accessed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS calendar_feeds;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- minutes before the due date to remind at; negative offsets remind after it, an empty list turns reminders off
+ALTER TABLE users ADD COLUMN IF NOT EXISTS reminder_offsets VARCHAR(100) NOT NULL DEFAULT '1440,60';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- one row per reminder that was due: the primary key is what makes each reminder fire only once,
+-- across restarts and server instances. A new due date starts a fresh set of reminders.
+CREATE TABLE IF NOT EXISTS task_reminders (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    offset_minutes INTEGER NOT NULL,
+    due_date TIMESTAMPTZ NOT NULL,
+    notified BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, user_id, offset_minutes, due_date)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_tasks_due_date_open ON tasks (due_date)
+    WHERE deleted_at IS NULL AND due_date IS NOT NULL AND status NOT IN ('done', 'cancelled');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_tasks_due_date_open;
+DROP TABLE IF EXISTS task_reminders;
+ALTER TABLE users DROP COLUMN IF EXISTS reminder_offsets;
+-- +goose StatementEnd
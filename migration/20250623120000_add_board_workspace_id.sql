@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+-- boards made before workspaces follow their project, or else their owner's personal workspace
+-- (which reuses the owner's ID) and, if that has been deleted since, the owner's active one
+ALTER TABLE boards ADD COLUMN IF NOT EXISTS workspace_id UUID REFERENCES workspaces(id) ON DELETE CASCADE;
+UPDATE boards SET workspace_id = COALESCE(
+    (SELECT p.workspace_id FROM projects p WHERE p.id = boards.project_id),
+    (SELECT w.id FROM workspaces w WHERE w.id = boards.owner_id),
+    (SELECT u.active_workspace_id FROM users u WHERE u.id = boards.owner_id)
+) WHERE workspace_id IS NULL;
+ALTER TABLE boards ALTER COLUMN workspace_id SET NOT NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- a board only holds tasks of its own workspace
+DELETE FROM board_cards bc
+USING boards b, tasks t
+WHERE b.id = bc.board_id AND t.id = bc.task_id AND t.workspace_id <> b.workspace_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_boards_workspace_owner ON boards (workspace_id, owner_id);
+-- a status change looks up every card of the task to move it to the matching column
+CREATE INDEX IF NOT EXISTS idx_board_cards_task_id ON board_cards (task_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_board_cards_task_id;
+DROP INDEX IF EXISTS idx_boards_workspace_owner;
+ALTER TABLE boards DROP COLUMN IF EXISTS workspace_id;
+-- +goose StatementEnd
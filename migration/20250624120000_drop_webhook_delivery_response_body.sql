@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- response bodies could hold whatever the webhook URL returns, so deliveries only keep the status
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS response_body;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS response_body TEXT;
+-- +goose StatementEnd
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// iCalMaxLineOctets is the longest content line RFC 5545 allows before it has to be folded
+const iCalMaxLineOctets = 75
+
+// ICalWriter builds an iCalendar (RFC 5545) document line by line
+type ICalWriter struct {
+	b strings.Builder
+}
+
+// Line writes "NAME:value", folding it at 75 octets without splitting a UTF-8 character.
+// value must already be escaped where the property's type requires it.
+func (w *ICalWriter) Line(name, value string) {
+	line := name + ":" + value
+	limit := iCalMaxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.b.WriteString(line[:cut])
+		w.b.WriteString("\r\n ")
+		line = line[cut:]
+		// the space that starts a continuation line counts towards its length
+		limit = iCalMaxLineOctets - 1
+	}
+	w.b.WriteString(line)
+	w.b.WriteString("\r\n")
+}
+
+// Text writes a TEXT property, escaping its value
+func (w *ICalWriter) Text(name, value string) {
+	w.Line(name, ICalEscapeText(value))
+}
+
+// String returns the document written so far
+func (w *ICalWriter) String() string {
+	return w.b.String()
+}
+
+// ICalEscapeText escapes backslashes, semicolons, commas and newlines in a TEXT value
+func ICalEscapeText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(value)
+}
+
+// ICalDateTime formats a time as a UTC DATE-TIME value
+func ICalDateTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// ICalDate formats the calendar day of t, in t's own location, as a DATE value
+func ICalDate(t time.Time) string {
+	return t.Format("20060102")
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Mentions are "@" followed by an email address, a quoted name (@"Jane Doe") or a bare name
+// (@jane). The "@" must start the text or follow a character that cannot be part of a word,
+// so plain email addresses in the text are not read as mentions.
+var (
+	emailMentionPattern  = regexp.MustCompile(`(^|[^\w@])@([\w.%+-]+@[\w-]+(?:\.[\w-]+)+)`)
+	quotedMentionPattern = regexp.MustCompile(`(^|[^\w@])@"([^"\n]{1,100})"`)
+	nameMentionPattern   = regexp.MustCompile(`(^|[^\w@])@([\p{L}\p{N}_.-]+)`)
+)
+
+// Mentions holds the distinct, lower-cased emails and names mentioned in a text
+type Mentions struct {
+	Emails []string
+	Names  []string
+}
+
+// IsEmpty reports whether the text mentioned nobody
+func (m Mentions) IsEmpty() bool {
+	return len(m.Emails) == 0 && len(m.Names) == 0
+}
+
+// ParseMentions extracts @email, @"quoted name" and @name mentions from text
+func ParseMentions(text string) Mentions {
+	var m Mentions
+	seenEmails := map[string]bool{}
+	seenNames := map[string]bool{}
+
+	// emails and quoted names are blanked out once matched so the bare-name pattern
+	// does not pick up their pieces
+	text = emailMentionPattern.ReplaceAllStringFunc(text, func(match string) string {
+		sub := emailMentionPattern.FindStringSubmatch(match)
+		email := strings.ToLower(sub[2])
+		if !seenEmails[email] {
+			seenEmails[email] = true
+			m.Emails = append(m.Emails, email)
+		}
+		return sub[1] + " "
+	})
+	text = quotedMentionPattern.ReplaceAllStringFunc(text, func(match string) string {
+		sub := quotedMentionPattern.FindStringSubmatch(match)
+		name := strings.ToLower(strings.Join(strings.Fields(sub[2]), " "))
+		if name != "" && !seenNames[name] {
+			seenNames[name] = true
+			m.Names = append(m.Names, name)
+		}
+		return sub[1] + " "
+	})
+	for _, sub := range nameMentionPattern.FindAllStringSubmatch(text, -1) {
+		// a trailing dot is almost always sentence punctuation: "thanks @jane."
+		name := strings.ToLower(strings.TrimRight(sub[2], ".-"))
+		if name != "" && !seenNames[name] {
+			seenNames[name] = true
+			m.Names = append(m.Names, name)
+		}
+	}
+	return m
+}
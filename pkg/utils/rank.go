@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+// rankDigits are base 62 digits in ASCII order, so ranks sort correctly as plain byte strings
+// (the column must use COLLATE "C").
+const rankDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// MaxRankLength is the length after which a column should be rebalanced
+const MaxRankLength = 48
+
+var ErrInvalidRankOrder = errors.New("rank bounds are not in order")
+
+// RankBetween returns a key that sorts strictly between a and b. An empty a means "before
+// everything" and an empty b means "after everything". Generated keys never end in '0',
+// so there is always room to insert before them.
+func RankBetween(a, b string) (string, error) {
+	if b != "" && a >= b {
+		return "", ErrInvalidRankOrder
+	}
+	return rankMidpoint(a, b), nil
+}
+
+func rankMidpoint(a, b string) string {
+	if b != "" {
+		// keep the common prefix, treating a as padded with '0'
+		n := 0
+		for n < len(b) && rankDigitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + rankMidpoint(rest, b[n:])
+		}
+	}
+
+	digitA := 0
+	if a != "" {
+		digitA = strings.IndexByte(rankDigits, a[0])
+	}
+	digitB := len(rankDigits)
+	if b != "" {
+		digitB = strings.IndexByte(rankDigits, b[0])
+	}
+
+	if digitB-digitA > 1 {
+		return string(rankDigits[(digitA+digitB)/2])
+	}
+	// the first digits are consecutive
+	if b != "" && len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(rankDigits[digitA]) + rankMidpoint(rest, "")
+}
+
+func rankDigitAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return '0'
+}
+
+// EvenRanks returns n evenly spaced, increasing keys of equal length, used to rebalance a column
+func EvenRanks(n int) []string {
+	width := 1
+	for capacity := len(rankDigits); capacity <= n*2; capacity *= len(rankDigits) {
+		width++
+	}
+	space := 1
+	for i := 0; i < width; i++ {
+		space *= len(rankDigits)
+	}
+	step := space / (n + 1)
+
+	ranks := make([]string, n)
+	for i := range ranks {
+		value := (i + 1) * step
+		key := make([]byte, width)
+		for j := width - 1; j >= 0; j-- {
+			key[j] = rankDigits[value%len(rankDigits)]
+			value /= len(rankDigits)
+		}
+		ranks[i] = strings.TrimRight(string(key), "0")
+	}
+	return ranks
+}
@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// checkRank fails the test unless key sorts strictly between a and b and can still be inserted before
+func checkRank(t *testing.T, a, b, key string) {
+	t.Helper()
+	if key <= a || (b != "" && key >= b) {
+		t.Fatalf("rank %q is not between %q and %q", key, a, b)
+	}
+	if strings.HasSuffix(key, "0") {
+		t.Fatalf("rank %q ends in '0'", key)
+	}
+}
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty column", a: "", b: "", want: "V"},
+		{name: "after the last card", a: "z", b: "", want: "zV"},
+		{name: "after a long last card", a: "zz", b: "", want: "zzV"},
+		{name: "before the first card", a: "", b: "V", want: "F"},
+		{name: "before the lowest one digit card", a: "", b: "1", want: "0V"},
+		{name: "before a card below it", a: "", b: "01", want: "00V"},
+		{name: "between distant cards", a: "0", b: "z", want: "U"},
+		{name: "between consecutive digits", a: "a", b: "b", want: "aV"},
+		{name: "between a card and its extension", a: "a", b: "a1", want: "a0V"},
+		{name: "between cards of different length", a: "0V", b: "1", want: "0k"},
+		{name: "equal bounds", a: "a", b: "a", wantErr: true},
+		{name: "reversed bounds", a: "b", b: "a", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RankBetween(tt.a, tt.b)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRankOrder) {
+					t.Fatalf("RankBetween(%q, %q) error = %v, want ErrInvalidRankOrder", tt.a, tt.b, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RankBetween(%q, %q): %v", tt.a, tt.b, err)
+			}
+			if got != tt.want {
+				t.Errorf("RankBetween(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+			}
+			checkRank(t, tt.a, tt.b, got)
+		})
+	}
+}
+
+// TestRankBetweenLength inserts at the same spot over and over, the worst case for key length,
+// and checks that keys stay ordered and grow slowly enough that rebalancing stays rare
+func TestRankBetweenLength(t *testing.T) {
+	const inserts = 100
+
+	tests := []struct {
+		name string
+		// bounds returns where the next card goes, given the key of the card inserted last
+		bounds func(last string) (string, string)
+		maxLen int
+	}{
+		{"append to the end", func(last string) (string, string) { return last, "" }, 20},
+		{"insert at the front", func(last string) (string, string) { return "", last }, 24},
+		{"insert right after the same card", func(last string) (string, string) { return "a", last }, 24},
+		{"insert right before the same card", func(last string) (string, string) { return last, "b" }, 24},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			last := ""
+			for i := 0; i < inserts; i++ {
+				a, b := tt.bounds(last)
+				key, err := RankBetween(a, b)
+				if err != nil {
+					t.Fatalf("insert %d: RankBetween(%q, %q): %v", i, a, b, err)
+				}
+				checkRank(t, a, b, key)
+				last = key
+			}
+			if len(last) > tt.maxLen {
+				t.Errorf("key after %d inserts is %d long, want at most %d", inserts, len(last), tt.maxLen)
+			}
+		})
+	}
+}
+
+func TestEvenRanks(t *testing.T) {
+	for _, n := range []int{1, 2, 30, 31, 100, 2000} {
+		ranks := EvenRanks(n)
+		if len(ranks) != n {
+			t.Fatalf("EvenRanks(%d) returned %d keys", n, len(ranks))
+		}
+		previous := ""
+		for i, key := range ranks {
+			checkRank(t, previous, "", key)
+			if len(key) > MaxRankLength/4 {
+				t.Fatalf("EvenRanks(%d)[%d] = %q is too long", n, i, key)
+			}
+			previous = key
+		}
+		// a rebalanced column must leave room around and between its cards
+		if _, err := RankBetween("", ranks[0]); err != nil {
+			t.Fatalf("no room before the first of %d keys: %v", n, err)
+		}
+		if n > 1 {
+			key, err := RankBetween(ranks[0], ranks[1])
+			if err != nil {
+				t.Fatalf("no room between the first two of %d keys: %v", n, err)
+			}
+			checkRank(t, ranks[0], ranks[1], key)
+		}
+	}
+}
@@ -0,0 +1,405 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RRule is the subset of an RFC 5545 recurrence rule that tasks support:
+// FREQ (DAILY, WEEKLY, MONTHLY, YEARLY), INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY and BYMONTH.
+type RRule struct {
+	Freq       string
+	Interval   int
+	Count      int
+	Until      *time.Time
+	ByDay      []RRuleWeekday
+	ByMonthDay []int
+	ByMonth    []int
+	// floatingUntil is set when UNTIL has no "Z": it is wall clock time in dtstart's location,
+	// kept in Until as if it were UTC
+	floatingUntil bool
+}
+
+// RRuleWeekday is a BYDAY entry such as "MO", "2TU" or "-1FR". N is 0 when there is no ordinal.
+type RRuleWeekday struct {
+	N       int
+	Weekday time.Weekday
+}
+
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+// Iteration of a rule that can never match (e.g. BYMONTHDAY=31;BYMONTH=2) stops after at least
+// minEmptyPeriods periods in a row, together spanning more than maxEmptyYears, had no occurrence.
+// Both are needed: a daily February 29 rule goes nearly eight years without a match around 2100,
+// while a rule every 100 years can still match after three empty periods.
+const (
+	minEmptyPeriods = 8
+	maxEmptyYears   = 10
+)
+
+// rrulePeriodsPerYear converts a run of empty periods into years, rounding the length of a year down
+var rrulePeriodsPerYear = map[string]int{
+	FreqDaily:   365,
+	FreqWeekly:  52,
+	FreqMonthly: 12,
+	FreqYearly:  1,
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+var ErrInvalidRRule = errors.New("invalid recurrence rule")
+
+// ParseRRule parses a rule like "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10".
+// A leading "RRULE:" is accepted.
+func ParseRRule(rule string) (*RRule, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRRule)
+	}
+
+	r := &RRule{Interval: 1}
+	for _, part := range strings.Split(rule, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRRule, part)
+		}
+
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			r.Freq = strings.ToUpper(value)
+			if r.Freq != FreqDaily && r.Freq != FreqWeekly && r.Freq != FreqMonthly && r.Freq != FreqYearly {
+				return nil, fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRRule, value)
+			}
+		case "INTERVAL":
+			r.Interval, err = parsePositive(value)
+		case "COUNT":
+			r.Count, err = parsePositive(value)
+		case "UNTIL":
+			var until time.Time
+			until, r.floatingUntil, err = parseRRuleUntil(value)
+			r.Until = &until
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseIntList(value, -31, 31)
+		case "BYMONTH":
+			r.ByMonth, err = parseIntList(value, 1, 12)
+		case "WKST":
+			// weeks always start on Monday
+		default:
+			return nil, fmt.Errorf("%w: unsupported part %q", ErrInvalidRRule, name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidRRule, name, err)
+		}
+	}
+
+	if r.Freq == "" {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRRule)
+	}
+	if r.Count > 0 && r.Until != nil {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL cannot both be set", ErrInvalidRRule)
+	}
+	return r, nil
+}
+
+func parsePositive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%q is not a positive number", value)
+	}
+	return n, nil
+}
+
+func parseIntList(value string, min, max int) ([]int, error) {
+	var result []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil || n == 0 || n < min || n > max {
+			return nil, fmt.Errorf("%q is out of range", item)
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+func parseByDay(value string) ([]RRuleWeekday, error) {
+	var result []RRuleWeekday
+	for _, item := range strings.Split(strings.ToUpper(value), ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("%q is not a weekday", item)
+		}
+		weekday, ok := rruleWeekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("%q is not a weekday", item)
+		}
+		n := 0
+		if prefix := item[:len(item)-2]; prefix != "" {
+			var err error
+			n, err = strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, fmt.Errorf("%q has an invalid ordinal", item)
+			}
+		}
+		result = append(result, RRuleWeekday{N: n, Weekday: weekday})
+	}
+	return result, nil
+}
+
+// parseRRuleUntil also reports whether UNTIL is floating, i.e. has no "Z" and so is local time
+func parseRRuleUntil(value string) (time.Time, bool, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			if layout == "20060102" {
+				// a date-only UNTIL includes the whole day
+				t = t.Add(24*time.Hour - time.Second)
+			}
+			return t, layout != "20060102T150405Z", nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("%q is not a date", value)
+}
+
+// untilIn returns the instant UNTIL stands for when occurrences are computed in loc
+func (r *RRule) untilIn(loc *time.Location) *time.Time {
+	if r.Until == nil || !r.floatingUntil {
+		return r.Until
+	}
+	u := r.Until
+	until := time.Date(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), 0, loc)
+	return &until
+}
+
+// Iterate calls fn for every occurrence in order, starting at dtstart, until fn returns false or the
+// rule ends. The wall clock time of dtstart is kept in dtstart's location, so daylight saving changes
+// do not shift occurrences. index counts occurrences from 0.
+func (r *RRule) Iterate(dtstart time.Time, fn func(occurrence time.Time, index int) bool) {
+	until := r.untilIn(dtstart.Location())
+	index := 0
+	empty := 0
+	for period := 0; ; period++ {
+		candidates := r.periodCandidates(dtstart, period*r.Interval)
+		if len(candidates) == 0 {
+			empty++
+			if empty > minEmptyPeriods && empty*r.Interval > maxEmptyYears*rrulePeriodsPerYear[r.Freq] {
+				return
+			}
+			continue
+		}
+		empty = 0
+
+		for _, candidate := range candidates {
+			if candidate.Before(dtstart) {
+				continue
+			}
+			if until != nil && candidate.After(*until) {
+				return
+			}
+			if r.Count > 0 && index >= r.Count {
+				return
+			}
+			if !fn(candidate, index) {
+				return
+			}
+			index++
+		}
+	}
+}
+
+// Nth returns the occurrence with the given zero based index
+func (r *RRule) Nth(dtstart time.Time, n int) (time.Time, bool) {
+	var result time.Time
+	found := false
+	r.Iterate(dtstart, func(occurrence time.Time, index int) bool {
+		if index == n {
+			result, found = occurrence, true
+			return false
+		}
+		return true
+	})
+	return result, found
+}
+
+// NextAfter returns up to limit occurrences strictly after the given time
+func (r *RRule) NextAfter(dtstart, after time.Time, limit int) []time.Time {
+	var result []time.Time
+	if limit <= 0 {
+		return result
+	}
+	r.Iterate(dtstart, func(occurrence time.Time, _ int) bool {
+		if occurrence.After(after) {
+			result = append(result, occurrence)
+		}
+		return len(result) < limit
+	})
+	return result
+}
+
+// periodCandidates returns the sorted occurrences of the period that lies offset FREQ units after dtstart
+func (r *RRule) periodCandidates(dtstart time.Time, offset int) []time.Time {
+	loc := dtstart.Location()
+	hour, minute, second := dtstart.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, 0, loc)
+	}
+
+	var days []time.Time
+	switch r.Freq {
+	case FreqDaily:
+		day := at(dtstart.Year(), dtstart.Month(), dtstart.Day()+offset)
+		if r.matchesMonth(day) && r.matchesMonthDay(day) && r.matchesWeekday(day) {
+			days = append(days, day)
+		}
+
+	case FreqWeekly:
+		// weeks start on Monday
+		monday := dtstart.Day() - (int(dtstart.Weekday())+6)%7 + offset*7
+		for i := 0; i < 7; i++ {
+			day := at(dtstart.Year(), dtstart.Month(), monday+i)
+			if len(r.ByDay) == 0 && day.Weekday() != dtstart.Weekday() {
+				continue
+			}
+			if r.matchesMonth(day) && r.matchesWeekday(day) {
+				days = append(days, day)
+			}
+		}
+
+	case FreqMonthly:
+		first := at(dtstart.Year(), dtstart.Month()+time.Month(offset), 1)
+		if r.matchesMonth(first) {
+			days = r.monthDays(first, dtstart.Day(), at)
+		}
+
+	case FreqYearly:
+		year := dtstart.Year() + offset
+		months := r.ByMonth
+		if len(months) == 0 && len(r.ByDay) > 0 && len(r.ByMonthDay) == 0 {
+			days = r.yearWeekdays(year, at)
+			break
+		}
+		if len(months) == 0 {
+			months = []int{int(dtstart.Month())}
+		}
+		for _, month := range months {
+			days = append(days, r.monthDays(at(year, time.Month(month), 1), dtstart.Day(), at)...)
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	return days
+}
+
+// monthDays expands BYMONTHDAY and BYDAY inside one month. Without either, defaultDay is used
+// and months that do not have that day are skipped.
+func (r *RRule) monthDays(first time.Time, defaultDay int, at func(int, time.Month, int) time.Time) []time.Time {
+	year, month := first.Year(), first.Month()
+	daysInMonth := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	var days []time.Time
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		if defaultDay <= daysInMonth {
+			days = append(days, at(year, month, defaultDay))
+		}
+		return days
+	}
+
+	for day := 1; day <= daysInMonth; day++ {
+		candidate := at(year, month, day)
+		if len(r.ByMonthDay) > 0 && !r.matchesMonthDay(candidate) {
+			continue
+		}
+		if len(r.ByDay) > 0 && !matchesOrdinalWeekday(r.ByDay, candidate, day, daysInMonth) {
+			continue
+		}
+		days = append(days, candidate)
+	}
+	return days
+}
+
+// yearWeekdays expands BYDAY across a whole year, where ordinals count weeks of the year
+func (r *RRule) yearWeekdays(year int, at func(int, time.Month, int) time.Time) []time.Time {
+	daysInYear := time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	var days []time.Time
+	for yearDay := 1; yearDay <= daysInYear; yearDay++ {
+		candidate := at(year, time.January, yearDay)
+		if matchesOrdinalWeekday(r.ByDay, candidate, yearDay, daysInYear) {
+			days = append(days, candidate)
+		}
+	}
+	return days
+}
+
+// matchesOrdinalWeekday checks "2TU" style entries, where pos is the day's position within a span of length days
+func matchesOrdinalWeekday(byDay []RRuleWeekday, day time.Time, pos, length int) bool {
+	for _, wd := range byDay {
+		if wd.Weekday != day.Weekday() {
+			continue
+		}
+		if wd.N == 0 {
+			return true
+		}
+		if wd.N > 0 && (pos-1)/7+1 == wd.N {
+			return true
+		}
+		if wd.N < 0 && (length-pos)/7+1 == -wd.N {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RRule) matchesMonth(day time.Time) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, month := range r.ByMonth {
+		if time.Month(month) == day.Month() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RRule) matchesMonthDay(day time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	daysInMonth := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, monthDay := range r.ByMonthDay {
+		if monthDay == day.Day() || (monthDay < 0 && daysInMonth+monthDay+1 == day.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWeekday ignores ordinals, which only apply to MONTHLY and YEARLY rules
+func (r *RRule) matchesWeekday(day time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, wd := range r.ByDay {
+		if wd.Weekday == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
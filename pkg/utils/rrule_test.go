@@ -0,0 +1,231 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s is not available: %v", name, err)
+	}
+	return loc
+}
+
+func TestParseRRuleErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+	}{
+		{"empty", ""},
+		{"missing FREQ", "INTERVAL=2"},
+		{"unsupported FREQ", "FREQ=HOURLY"},
+		{"malformed part", "FREQ=DAILY;COUNT"},
+		{"zero interval", "FREQ=DAILY;INTERVAL=0"},
+		{"COUNT and UNTIL", "FREQ=DAILY;COUNT=3;UNTIL=20250110"},
+		{"bad UNTIL", "FREQ=DAILY;UNTIL=2025-01-10"},
+		{"bad weekday", "FREQ=WEEKLY;BYDAY=XX"},
+		{"zero ordinal", "FREQ=MONTHLY;BYDAY=0MO"},
+		{"month day out of range", "FREQ=MONTHLY;BYMONTHDAY=32"},
+		{"month out of range", "FREQ=YEARLY;BYMONTH=13"},
+		{"unsupported part", "FREQ=DAILY;BYHOUR=9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseRRule(tt.rule); !errors.Is(err, ErrInvalidRRule) {
+				t.Fatalf("ParseRRule(%q) error = %v, want ErrInvalidRRule", tt.rule, err)
+			}
+		})
+	}
+}
+
+func TestRRuleOccurrences(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		limit   int
+		want    []time.Time
+	}{
+		{
+			name:    "daily with COUNT",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: time.Date(2025, 1, 30, 9, 0, 0, 0, time.UTC),
+			limit:   10,
+			want: []time.Time{
+				time.Date(2025, 1, 30, 9, 0, 0, 0, time.UTC),
+				time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2025, 2, 1, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "UTC UNTIL is an instant",
+			rule:    "FREQ=DAILY;UNTIL=20250103T080000Z",
+			dtstart: time.Date(2025, 1, 1, 9, 0, 0, 0, berlin),
+			limit:   10,
+			// 9:00 in Berlin is 8:00 UTC, so the third day is still included
+			want: []time.Time{
+				time.Date(2025, 1, 1, 9, 0, 0, 0, berlin),
+				time.Date(2025, 1, 2, 9, 0, 0, 0, berlin),
+				time.Date(2025, 1, 3, 9, 0, 0, 0, berlin),
+			},
+		},
+		{
+			name:    "floating UNTIL is wall clock time in dtstart's location",
+			rule:    "FREQ=DAILY;UNTIL=20250103T090000",
+			dtstart: time.Date(2025, 1, 1, 9, 0, 0, 0, newYork),
+			limit:   10,
+			// read as UTC, 9:00 would end the rule before 9:00 in New York on the third
+			want: []time.Time{
+				time.Date(2025, 1, 1, 9, 0, 0, 0, newYork),
+				time.Date(2025, 1, 2, 9, 0, 0, 0, newYork),
+				time.Date(2025, 1, 3, 9, 0, 0, 0, newYork),
+			},
+		},
+		{
+			name:    "floating UNTIL before the last candidate",
+			rule:    "FREQ=DAILY;UNTIL=20250103T085959",
+			dtstart: time.Date(2025, 1, 1, 9, 0, 0, 0, berlin),
+			limit:   10,
+			// read as UTC, 8:59:59 would still include 9:00 in Berlin on the third
+			want: []time.Time{
+				time.Date(2025, 1, 1, 9, 0, 0, 0, berlin),
+				time.Date(2025, 1, 2, 9, 0, 0, 0, berlin),
+			},
+		},
+		{
+			name:    "date-only UNTIL includes the whole local day",
+			rule:    "FREQ=DAILY;UNTIL=20250102",
+			dtstart: time.Date(2025, 1, 1, 22, 0, 0, 0, newYork),
+			limit:   10,
+			want: []time.Time{
+				time.Date(2025, 1, 1, 22, 0, 0, 0, newYork),
+				time.Date(2025, 1, 2, 22, 0, 0, 0, newYork),
+			},
+		},
+		{
+			name:    "wall clock time kept across daylight saving",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: time.Date(2025, 3, 29, 9, 0, 0, 0, berlin),
+			limit:   10,
+			want: []time.Time{
+				time.Date(2025, 3, 29, 9, 0, 0, 0, berlin),
+				time.Date(2025, 3, 30, 9, 0, 0, 0, berlin),
+				time.Date(2025, 3, 31, 9, 0, 0, 0, berlin),
+			},
+		},
+		{
+			name:    "weekly on several days",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR",
+			dtstart: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+			limit:   4,
+			want: []time.Time{
+				time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+				time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC),
+				time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC),
+				time.Date(2025, 1, 24, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "monthly skips months without the day",
+			rule:    "FREQ=MONTHLY",
+			dtstart: time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC),
+			limit:   3,
+			want: []time.Time{
+				time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2025, 5, 31, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "monthly on the last Friday",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart: time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC),
+			limit:   3,
+			want: []time.Time{
+				time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2025, 2, 28, 9, 0, 0, 0, time.UTC),
+				time.Date(2025, 3, 28, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "monthly on the last day",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+			limit:   3,
+			want: []time.Time{
+				time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 31, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "yearly on a leap day",
+			rule:    "FREQ=YEARLY",
+			dtstart: time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+			limit:   2,
+			want: []time.Time{
+				time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+				time.Date(2028, 2, 29, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "daily leap day rule waits for the next leap year",
+			rule:    "FREQ=DAILY;BYMONTH=2;BYMONTHDAY=29",
+			dtstart: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC),
+			limit:   2,
+			// about 1,100 empty days come before the first match
+			want: []time.Time{
+				time.Date(2028, 2, 29, 9, 0, 0, 0, time.UTC),
+				time.Date(2032, 2, 29, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "daily leap day rule skips 2100",
+			rule:    "FREQ=DAILY;BYMONTH=2;BYMONTHDAY=29",
+			dtstart: time.Date(2096, 3, 1, 9, 0, 0, 0, time.UTC),
+			limit:   1,
+			want:    []time.Time{time.Date(2104, 2, 29, 9, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:    "leap day every 100 years",
+			rule:    "FREQ=YEARLY;INTERVAL=100;BYMONTH=2;BYMONTHDAY=29",
+			dtstart: time.Date(2000, 2, 29, 9, 0, 0, 0, time.UTC),
+			limit:   2,
+			want: []time.Time{
+				time.Date(2000, 2, 29, 9, 0, 0, 0, time.UTC),
+				time.Date(2400, 2, 29, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "rule that never matches ends",
+			rule:    "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			dtstart: time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC),
+			limit:   3,
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRRule(%q): %v", tt.rule, err)
+			}
+			got := rule.NextAfter(tt.dtstart, tt.dtstart.Add(-time.Nanosecond), tt.limit)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d occurrences %v, want %d %v", len(got), got, len(tt.want), tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrence %d = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
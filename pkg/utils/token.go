@@ -12,26 +12,37 @@ import (
 const (
 	TokenIssuer   = "TaskManagerApis"
 	TokenAudience = "taskmanager-client"
+	// StreamTokenAudience marks tokens that only open the event stream; they are not access tokens
+	StreamTokenAudience = "taskmanager-events"
 )
 
 // Claims defines the payload structure for the JWT token
 type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
+	UserID      string `json:"user_id"`
+	Email       string `json:"email"`
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	Role        string `json:"role,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// GenerateToken creates a signed JWT with custom and registered claims
-func GenerateToken(userID, email string, duration time.Duration) (string, error) {
+// GenerateToken creates a signed JWT with custom and registered claims.
+// workspaceID and role scope access tokens; refresh tokens leave them empty.
+func GenerateToken(userID, email, workspaceID, role string, duration time.Duration) (string, error) {
+	return generateToken(userID, email, workspaceID, role, TokenAudience, duration)
+}
+
+func generateToken(userID, email, workspaceID, role, audience string, duration time.Duration) (string, error) {
 	now := time.Now()
 
 	claims := &Claims{
-		UserID: userID,
-		Email:  email,
+		UserID:      userID,
+		Email:       email,
+		WorkspaceID: workspaceID,
+		Role:        role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    TokenIssuer,
 			Subject:   userID,
-			Audience:  jwt.ClaimStrings{TokenAudience},
+			Audience:  jwt.ClaimStrings{audience},
 			ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
@@ -47,10 +58,10 @@ func GenerateToken(userID, email string, duration time.Duration) (string, error)
 	return signedToken, nil
 }
 
-// GenerateAccessToken generates a short-lived token for authentication
-func GenerateAccessToken(userID, email string) (string, error) {
+// GenerateAccessToken generates a short-lived token for authentication in a workspace
+func GenerateAccessToken(userID, email, workspaceID, role string) (string, error) {
 	expiration := time.Duration(config.Config.AccessTokenExpireMinutes) * time.Minute
-	return GenerateToken(userID, email, expiration)
+	return GenerateToken(userID, email, workspaceID, role, expiration)
 }
 
 // GenerateRefreshToken generates a long-lived token for re-authentication
@@ -58,7 +69,21 @@ func GenerateRefreshToken(userID, email string) (string, time.Time, error) {
 	duration := time.Duration(config.Config.RefreshTokenExpireHours) * time.Hour
 	expirationTime := time.Now().Add(duration)
 
-	token, err := GenerateToken(userID, email, duration)
+	token, err := GenerateToken(userID, email, "", "", duration)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expirationTime, nil
+}
+
+// GenerateStreamToken creates a token with the claims of an access token that only opens the
+// event stream, for clients such as browsers that cannot send it in a header
+func GenerateStreamToken(claims *Claims) (string, time.Time, error) {
+	duration := time.Duration(config.Config.EventsTokenExpireSecs) * time.Second
+	expirationTime := time.Now().Add(duration)
+
+	token, err := generateToken(claims.UserID, claims.Email, claims.WorkspaceID, claims.Role, StreamTokenAudience, duration)
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -68,6 +93,15 @@ func GenerateRefreshToken(userID, email string) (string, time.Time, error) {
 
 // ValidateToken parses and validates a JWT string and returns its claims
 func ValidateToken(tokenStr string) (*Claims, error) {
+	return validateToken(tokenStr, TokenAudience)
+}
+
+// ValidateStreamToken parses and validates a token made by GenerateStreamToken
+func ValidateStreamToken(tokenStr string) (*Claims, error) {
+	return validateToken(tokenStr, StreamTokenAudience)
+}
+
+func validateToken(tokenStr, audience string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		// Ensure the signing method is HS256
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -85,6 +119,10 @@ func ValidateToken(tokenStr string) (*Claims, error) {
 	if !ok || !token.Valid {
 		return nil, fmt.Errorf("invalid or expired token")
 	}
+	// stream tokens must not work as access tokens, nor the other way round
+	if !claims.VerifyAudience(audience, true) {
+		return nil, fmt.Errorf("token is not meant for %s", audience)
+	}
 
 	return claims, nil
 }